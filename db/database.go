@@ -6,34 +6,38 @@ import (
 	"os"
 
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+// Supported values of DB_DRIVER
+const (
+	DriverMySQL  = "mysql"
+	DriverSQLite = "sqlite"
+)
+
+// SQLiteMemory is the SQLite DSN for a private in-memory database
+const SQLiteMemory = ":memory:"
+
 var DB *gorm.DB
 
 // InitDatabase initializes the database connection
 func InitDatabase() error {
-	// Build DSN from environment variables
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbHost := os.Getenv("DB_HOST")
-	dbPort := os.Getenv("DB_PORT")
-	dbName := os.Getenv("DB_NAME")
-
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		dbUser, dbPassword, dbHost, dbPort, dbName)
+	driver := os.Getenv("DB_DRIVER")
+	if driver == "" {
+		driver = DriverMySQL
+	}
 
 	var err error
-	DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{
+	DB, err = Open(driver, dsnFromEnv(driver), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
 	})
-
 	if err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+		return err
 	}
 
-	log.Println("Database connection established successfully")
+	log.Printf("Database connection established successfully (%s)", driver)
 
 	// Get underlying sql.DB to configure connection pool
 	sqlDB, err := DB.DB()
@@ -42,12 +46,86 @@ func InitDatabase() error {
 	}
 
 	// Set connection pool settings
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
+	if driver == DriverMySQL {
+		sqlDB.SetMaxIdleConns(10)
+		sqlDB.SetMaxOpenConns(100)
+	}
+
+	// An in-memory database starts empty, so bring it up to the latest schema
+	if driver == DriverSQLite && dsnFromEnv(driver) == SQLiteMemory {
+		migrator, err := NewMigrator(DB)
+		if err != nil {
+			return err
+		}
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
+// Open opens a database connection for the given driver.
+// For SQLite, dsn is a file path or SQLiteMemory. Driver errors are translated
+// (e.g. to gorm.ErrDuplicatedKey) so repositories can classify them.
+func Open(driver, dsn string, config *gorm.Config) (*gorm.DB, error) {
+	config.TranslateError = true
+
+	var dialector gorm.Dialector
+	switch driver {
+	case DriverMySQL:
+		dialector = mysql.Open(dsn)
+	case DriverSQLite:
+		dialector = sqlite.Open(sqliteDSN(dsn))
+	default:
+		return nil, fmt.Errorf("unsupported DB_DRIVER %q (use %q or %q)", driver, DriverMySQL, DriverSQLite)
+	}
+
+	conn, err := gorm.Open(dialector, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if driver == DriverSQLite {
+		sqlDB, err := conn.DB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get database instance: %w", err)
+		}
+		// SQLite serializes writers, and every connection to :memory: is a separate
+		// database, so a single connection keeps all queries on the same data
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	return conn, nil
+}
+
+// dsnFromEnv builds the DSN for driver from environment variables.
+// SQLite uses DB_NAME as the database file path (default: in-memory).
+func dsnFromEnv(driver string) string {
+	dbName := os.Getenv("DB_NAME")
+
+	if driver == DriverSQLite {
+		if dbName == "" {
+			return SQLiteMemory
+		}
+		return dbName
+	}
+
+	// Build DSN from environment variables
+	dbUser := os.Getenv("DB_USER")
+	dbPassword := os.Getenv("DB_PASSWORD")
+	dbHost := os.Getenv("DB_HOST")
+	dbPort := os.Getenv("DB_PORT")
+
+	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		dbUser, dbPassword, dbHost, dbPort, dbName)
+}
+
+// sqliteDSN enables foreign key enforcement, which SQLite leaves off by default
+func sqliteDSN(path string) string {
+	return "file:" + path + "?_foreign_keys=on"
+}
+
 // CloseDatabase closes the database connection
 func CloseDatabase() error {
 	sqlDB, err := DB.DB()
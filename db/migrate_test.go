@@ -0,0 +1,161 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := LoadMigrations(DriverMySQL)
+	if err != nil {
+		t.Fatalf("LoadMigrations() error = %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("LoadMigrations() returned no migrations")
+	}
+
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Errorf("migration %d has version %d, want contiguous versions starting at 1", i, m.Version)
+		}
+		if len(splitStatements(m.Up)) == 0 {
+			t.Errorf("migration %d_%s has an empty up script", m.Version, m.Name)
+		}
+		if len(splitStatements(m.Down)) == 0 {
+			t.Errorf("migration %d_%s has an empty down script", m.Version, m.Name)
+		}
+	}
+}
+
+func TestLoadMigrations_DialectsInSync(t *testing.T) {
+	mysqlMigrations, err := LoadMigrations(DriverMySQL)
+	if err != nil {
+		t.Fatalf("LoadMigrations(mysql) error = %v", err)
+	}
+	sqliteMigrations, err := LoadMigrations(DriverSQLite)
+	if err != nil {
+		t.Fatalf("LoadMigrations(sqlite) error = %v", err)
+	}
+
+	if len(mysqlMigrations) != len(sqliteMigrations) {
+		t.Fatalf("mysql has %d migrations, sqlite has %d", len(mysqlMigrations), len(sqliteMigrations))
+	}
+	for i := range mysqlMigrations {
+		if mysqlMigrations[i].Version != sqliteMigrations[i].Version || mysqlMigrations[i].Name != sqliteMigrations[i].Name {
+			t.Errorf("migration %d differs: mysql %d_%s, sqlite %d_%s", i,
+				mysqlMigrations[i].Version, mysqlMigrations[i].Name,
+				sqliteMigrations[i].Version, sqliteMigrations[i].Name)
+		}
+	}
+}
+
+// lastMultiStatementMySQLMigration is the last released MySQL migration with
+// more than one statement in a script; see Migrator.run
+const lastMultiStatementMySQLMigration = 11
+
+func TestLoadMigrations_MySQLOneStatement(t *testing.T) {
+	migrations, err := LoadMigrations(DriverMySQL)
+	if err != nil {
+		t.Fatalf("LoadMigrations() error = %v", err)
+	}
+
+	for _, m := range migrations {
+		if m.Version <= lastMultiStatementMySQLMigration {
+			continue
+		}
+		if n := len(splitStatements(m.Up)); n != 1 {
+			t.Errorf("migration %d_%s has %d up statements, want 1", m.Version, m.Name, n)
+		}
+		if n := len(splitStatements(m.Down)); n != 1 {
+			t.Errorf("migration %d_%s has %d down statements, want 1", m.Version, m.Name, n)
+		}
+	}
+}
+
+func TestLoadMigrations_UnknownDialect(t *testing.T) {
+	if _, err := LoadMigrations("oracle"); err == nil {
+		t.Error("LoadMigrations() expected error for unknown dialect")
+	}
+}
+
+func TestParseMigrationFilename(t *testing.T) {
+	tests := []struct {
+		name          string
+		filename      string
+		wantVersion   int
+		wantName      string
+		wantDirection string
+		wantErr       bool
+	}{
+		{
+			name:          "up migration",
+			filename:      "0001_create_users.up.sql",
+			wantVersion:   1,
+			wantName:      "create_users",
+			wantDirection: "up",
+		},
+		{
+			name:          "down migration",
+			filename:      "0012_add_index.down.sql",
+			wantVersion:   12,
+			wantName:      "add_index",
+			wantDirection: "down",
+		},
+		{
+			name:     "missing direction",
+			filename: "0001_create_users.sql",
+			wantErr:  true,
+		},
+		{
+			name:     "missing name",
+			filename: "0001.up.sql",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid version",
+			filename: "abc_create_users.up.sql",
+			wantErr:  true,
+		},
+		{
+			name:     "not sql",
+			filename: "README.md",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, name, direction, err := parseMigrationFilename(tt.filename)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseMigrationFilename() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if version != tt.wantVersion || name != tt.wantName || direction != tt.wantDirection {
+				t.Errorf("parseMigrationFilename() = (%d, %q, %q), want (%d, %q, %q)",
+					version, name, direction, tt.wantVersion, tt.wantName, tt.wantDirection)
+			}
+		})
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := `-- users table
+CREATE TABLE a(
+    id INT NOT NULL
+);
+
+CREATE INDEX a_id_idx ON a(id);
+DROP TABLE b`
+
+	want := []string{
+		"CREATE TABLE a(\n    id INT NOT NULL\n)",
+		"CREATE INDEX a_id_idx ON a(id)",
+		"DROP TABLE b",
+	}
+
+	if got := splitStatements(script); !reflect.DeepEqual(got, want) {
+		t.Errorf("splitStatements() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP NULL DEFAULT NULL;
+
+-- Accounts created before verification existed are treated as verified
+-- (updated_at is assigned explicitly so ON UPDATE does not touch it)
+UPDATE users SET email_verified_at = created_at, updated_at = updated_at;
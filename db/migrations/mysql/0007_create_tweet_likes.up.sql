@@ -0,0 +1,9 @@
+CREATE TABLE tweet_likes(
+    user_id INT NOT NULL,
+    tweet_id INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, tweet_id),
+    KEY tweet_likes_tweet_id_idx (tweet_id, created_at, user_id),
+    CONSTRAINT tweet_likes_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id),
+    CONSTRAINT tweet_likes_tweet_id_fkey FOREIGN KEY (tweet_id) REFERENCES tweets(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+DROP INDEX tweets_in_reply_to_post_id_idx ON tweets;
+ALTER TABLE tweets DROP COLUMN in_reply_to_post_id;
@@ -0,0 +1,3 @@
+DROP INDEX tweets_user_id_repost_idx ON tweets;
+DROP INDEX tweets_original_post_id_idx ON tweets;
+ALTER TABLE tweets DROP COLUMN original_post_id, DROP COLUMN kind;
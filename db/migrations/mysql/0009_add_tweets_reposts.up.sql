@@ -0,0 +1,7 @@
+ALTER TABLE tweets
+    ADD COLUMN kind VARCHAR(10) NOT NULL DEFAULT 'post',
+    ADD COLUMN original_post_id INT NULL DEFAULT NULL;
+CREATE INDEX tweets_original_post_id_idx ON tweets(original_post_id);
+
+-- A user reposts a post at most once; quote posts are not limited
+CREATE UNIQUE INDEX tweets_user_id_repost_idx ON tweets(user_id, (CASE WHEN kind = 'repost' THEN original_post_id END));
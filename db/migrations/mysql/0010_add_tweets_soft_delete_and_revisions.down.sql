@@ -0,0 +1,4 @@
+DROP TABLE post_revisions;
+DROP INDEX tweets_user_id_repost_idx ON tweets;
+CREATE UNIQUE INDEX tweets_user_id_repost_idx ON tweets(user_id, (CASE WHEN kind = 'repost' THEN original_post_id END));
+ALTER TABLE tweets DROP COLUMN edited_at, DROP COLUMN deleted_at;
@@ -0,0 +1,22 @@
+-- Deleted posts are kept, hidden, so they can be restored and moderated.
+-- Replies keep pointing at a deleted parent (0008 left in_reply_to_post_id
+-- without a foreign key) and are reattached when it is restored.
+ALTER TABLE tweets
+    ADD COLUMN deleted_at TIMESTAMP NULL DEFAULT NULL,
+    ADD COLUMN edited_at TIMESTAMP NULL DEFAULT NULL;
+
+-- Only live reposts count towards the one repost per user and post, so a
+-- post can be reposted again while an earlier repost of it is deleted
+DROP INDEX tweets_user_id_repost_idx ON tweets;
+CREATE UNIQUE INDEX tweets_user_id_repost_idx ON tweets(user_id, (CASE WHEN kind = 'repost' AND deleted_at IS NULL THEN original_post_id END));
+
+-- Each row holds the content a post had before one of its edits
+CREATE TABLE post_revisions(
+    id INT NOT NULL AUTO_INCREMENT,
+    tweet_id INT NOT NULL,
+    tweet VARCHAR(300) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY post_revisions_tweet_id_idx (tweet_id, created_at, id),
+    CONSTRAINT post_revisions_tweet_id_fkey FOREIGN KEY (tweet_id) REFERENCES tweets(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
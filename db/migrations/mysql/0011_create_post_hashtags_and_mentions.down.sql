@@ -0,0 +1,3 @@
+DROP INDEX users_name_idx ON users;
+DROP TABLE post_mentions;
+DROP TABLE post_hashtags;
@@ -0,0 +1,22 @@
+-- Hashtags are stored lowercased and compared exactly
+CREATE TABLE post_hashtags(
+    tweet_id INT NOT NULL,
+    tag VARCHAR(100) COLLATE utf8mb4_bin NOT NULL,
+    PRIMARY KEY (tweet_id, tag),
+    KEY post_hashtags_tag_idx (tag, tweet_id),
+    CONSTRAINT post_hashtags_tweet_id_fkey FOREIGN KEY (tweet_id) REFERENCES tweets(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- name is the user's name as written after the @, so mentions keep pointing
+-- at the same user after a rename
+CREATE TABLE post_mentions(
+    tweet_id INT NOT NULL,
+    user_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    PRIMARY KEY (tweet_id, user_id),
+    KEY post_mentions_user_id_idx (user_id, tweet_id),
+    CONSTRAINT post_mentions_tweet_id_fkey FOREIGN KEY (tweet_id) REFERENCES tweets(id) ON DELETE CASCADE,
+    CONSTRAINT post_mentions_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX users_name_idx ON users(name);
@@ -0,0 +1,18 @@
+-- One row per event shown to user_id: actor_id followed them, or liked,
+-- replied to or mentioned them in the post tweet_id (NULL for follows)
+CREATE TABLE notifications(
+    id INT NOT NULL AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    actor_id INT NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    tweet_id INT NULL DEFAULT NULL,
+    read_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY notifications_user_id_idx (user_id, created_at, id),
+    KEY notifications_user_id_read_at_idx (user_id, read_at),
+    KEY notifications_tweet_id_idx (tweet_id),
+    CONSTRAINT notifications_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id),
+    CONSTRAINT notifications_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES users(id),
+    CONSTRAINT notifications_tweet_id_fkey FOREIGN KEY (tweet_id) REFERENCES tweets(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
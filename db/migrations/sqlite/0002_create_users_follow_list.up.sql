@@ -0,0 +1,8 @@
+CREATE TABLE users_follow_list(
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    follow_user_id INTEGER NOT NULL REFERENCES users(id),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, follow_user_id)
+);
+
+CREATE INDEX users_follow_list_follow_user_id_idx ON users_follow_list(follow_user_id, created_at);
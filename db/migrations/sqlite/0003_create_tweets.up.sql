@@ -0,0 +1,8 @@
+CREATE TABLE tweets(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    tweet VARCHAR(300) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX tweets_user_id_created_at_idx ON tweets(user_id, created_at, id);
@@ -0,0 +1,21 @@
+CREATE TABLE sessions(
+    id VARCHAR(36) NOT NULL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at DATETIME NULL DEFAULT NULL
+);
+
+CREATE INDEX sessions_user_id_idx ON sessions(user_id);
+
+CREATE TABLE refresh_tokens(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    session_id VARCHAR(36) NOT NULL REFERENCES sessions(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    token_hash CHAR(64) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    used_at DATETIME NULL DEFAULT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX token_hash ON refresh_tokens(token_hash);
+CREATE INDEX refresh_tokens_session_id_idx ON refresh_tokens(session_id);
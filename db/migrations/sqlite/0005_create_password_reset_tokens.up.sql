@@ -0,0 +1,11 @@
+CREATE TABLE password_reset_tokens(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    token_hash CHAR(64) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    used_at DATETIME NULL DEFAULT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX password_reset_tokens_token_hash ON password_reset_tokens(token_hash);
+CREATE INDEX password_reset_tokens_user_id_idx ON password_reset_tokens(user_id);
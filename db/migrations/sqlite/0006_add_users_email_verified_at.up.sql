@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN email_verified_at DATETIME NULL DEFAULT NULL;
+
+-- Accounts created before verification existed are treated as verified
+UPDATE users SET email_verified_at = created_at;
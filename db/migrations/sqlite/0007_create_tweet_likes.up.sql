@@ -0,0 +1,8 @@
+CREATE TABLE tweet_likes(
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    tweet_id INTEGER NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, tweet_id)
+);
+
+CREATE INDEX tweet_likes_tweet_id_idx ON tweet_likes(tweet_id, created_at, user_id);
@@ -0,0 +1,4 @@
+-- Replies are detached by the application when their parent is deleted,
+-- so the column carries an index but no foreign key
+ALTER TABLE tweets ADD COLUMN in_reply_to_post_id INTEGER NULL DEFAULT NULL;
+CREATE INDEX tweets_in_reply_to_post_id_idx ON tweets(in_reply_to_post_id, created_at, id);
@@ -0,0 +1,4 @@
+DROP INDEX tweets_user_id_repost_idx;
+DROP INDEX tweets_original_post_id_idx;
+ALTER TABLE tweets DROP COLUMN original_post_id;
+ALTER TABLE tweets DROP COLUMN kind;
@@ -0,0 +1,6 @@
+ALTER TABLE tweets ADD COLUMN kind VARCHAR(10) NOT NULL DEFAULT 'post';
+ALTER TABLE tweets ADD COLUMN original_post_id INTEGER NULL DEFAULT NULL;
+CREATE INDEX tweets_original_post_id_idx ON tweets(original_post_id);
+
+-- A user reposts a post at most once; quote posts are not limited
+CREATE UNIQUE INDEX tweets_user_id_repost_idx ON tweets(user_id, original_post_id) WHERE kind = 'repost';
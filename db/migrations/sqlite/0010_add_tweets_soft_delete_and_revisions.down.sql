@@ -0,0 +1,5 @@
+DROP TABLE post_revisions;
+DROP INDEX tweets_user_id_repost_idx;
+CREATE UNIQUE INDEX tweets_user_id_repost_idx ON tweets(user_id, original_post_id) WHERE kind = 'repost';
+ALTER TABLE tweets DROP COLUMN edited_at;
+ALTER TABLE tweets DROP COLUMN deleted_at;
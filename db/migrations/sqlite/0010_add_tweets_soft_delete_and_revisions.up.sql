@@ -0,0 +1,20 @@
+-- Deleted posts are kept, hidden, so they can be restored and moderated.
+-- Replies keep pointing at a deleted parent (0008 left in_reply_to_post_id
+-- without a foreign key) and are reattached when it is restored.
+ALTER TABLE tweets ADD COLUMN deleted_at DATETIME NULL DEFAULT NULL;
+ALTER TABLE tweets ADD COLUMN edited_at DATETIME NULL DEFAULT NULL;
+
+-- Only live reposts count towards the one repost per user and post, so a
+-- post can be reposted again while an earlier repost of it is deleted
+DROP INDEX tweets_user_id_repost_idx;
+CREATE UNIQUE INDEX tweets_user_id_repost_idx ON tweets(user_id, original_post_id) WHERE kind = 'repost' AND deleted_at IS NULL;
+
+-- Each row holds the content a post had before one of its edits
+CREATE TABLE post_revisions(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    tweet_id INTEGER NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    tweet VARCHAR(300) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX post_revisions_tweet_id_idx ON post_revisions(tweet_id, created_at, id);
@@ -0,0 +1,21 @@
+-- Hashtags are stored lowercased and compared exactly
+CREATE TABLE post_hashtags(
+    tweet_id INTEGER NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    tag VARCHAR(100) NOT NULL,
+    PRIMARY KEY (tweet_id, tag)
+);
+
+CREATE INDEX post_hashtags_tag_idx ON post_hashtags(tag, tweet_id);
+
+-- name is the user's name as written after the @, so mentions keep pointing
+-- at the same user after a rename
+CREATE TABLE post_mentions(
+    tweet_id INTEGER NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    name VARCHAR(255) NOT NULL,
+    PRIMARY KEY (tweet_id, user_id)
+);
+
+CREATE INDEX post_mentions_user_id_idx ON post_mentions(user_id, tweet_id);
+
+CREATE INDEX users_name_idx ON users(name);
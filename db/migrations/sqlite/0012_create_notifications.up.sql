@@ -0,0 +1,15 @@
+-- One row per event shown to user_id: actor_id followed them, or liked,
+-- replied to or mentioned them in the post tweet_id (NULL for follows)
+CREATE TABLE notifications(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    actor_id INTEGER NOT NULL REFERENCES users(id),
+    type VARCHAR(20) NOT NULL,
+    tweet_id INTEGER NULL DEFAULT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    read_at DATETIME NULL DEFAULT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX notifications_user_id_idx ON notifications(user_id, created_at, id);
+CREATE INDEX notifications_user_id_read_at_idx ON notifications(user_id, read_at);
+CREATE INDEX notifications_tweet_id_idx ON notifications(tweet_id);
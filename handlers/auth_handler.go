@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"encoding/json"
-	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -27,22 +26,16 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 	var req models.LoginRequest
 
 	// Decode request body
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		handleError(w, fmt.Errorf("invalid request body"), http.StatusBadRequest)
+	if err := decodeJSON(w, r, &req); err != nil {
+		handleError(w, err)
 		return
 	}
+	req.ClientIP = clientIP(r)
 
 	// Call service
 	resp, err := h.authService.Login(req)
 	if err != nil {
-		switch err.Error() {
-		case "email and password are required":
-			handleError(w, err, http.StatusBadRequest)
-		case "invalid email or password":
-			handleError(w, err, http.StatusUnauthorized)
-		default:
-			handleError(w, err, http.StatusInternalServerError)
-		}
+		handleError(w, err)
 		return
 	}
 
@@ -50,9 +43,95 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		handleError(w, err, http.StatusInternalServerError)
+		handleError(w, err)
 		return
 	}
 
 	slog.Info("User logged in successfully", "user_id", resp.UserID, "email", req.Email)
 }
+
+// HandleRefreshToken exchanges a refresh token for a new token pair
+func (h *AuthHandler) HandleRefreshToken(w http.ResponseWriter, r *http.Request) {
+	var req models.RefreshTokenRequest
+
+	// Decode request body
+	if err := decodeJSON(w, r, &req); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Call service
+	resp, err := h.authService.Refresh(req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("Token refreshed")
+}
+
+// HandleLogout revokes the session of the current access token
+func (h *AuthHandler) HandleLogout(w http.ResponseWriter, r *http.Request) {
+	principal, ok := CurrentUser(r.Context())
+	if !ok {
+		handleError(w, services.NewError(services.ErrUnauthenticated, "authentication required"))
+		return
+	}
+
+	// Call service
+	if err := h.authService.Logout(principal.SessionID); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	resp := models.SuccessResponse{
+		Message: "로그아웃 되었습니다.",
+		Status:  "success",
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("User logged out", "user_id", principal.UserID, "session_id", principal.SessionID)
+}
+
+// HandleLogoutAll revokes every session of the current user
+func (h *AuthHandler) HandleLogoutAll(w http.ResponseWriter, r *http.Request) {
+	principal, ok := CurrentUser(r.Context())
+	if !ok {
+		handleError(w, services.NewError(services.ErrUnauthenticated, "authentication required"))
+		return
+	}
+
+	// Call service
+	if err := h.authService.LogoutAll(principal.UserID); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	resp := models.SuccessResponse{
+		Message: "모든 세션에서 로그아웃 되었습니다.",
+		Status:  "success",
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("User logged out of all sessions", "user_id", principal.UserID)
+}
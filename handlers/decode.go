@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"python-backend-with-go/services"
+)
+
+// DefaultMaxBodyBytes is the request body limit used when BodyLimitMiddleware
+// has not configured one
+const DefaultMaxBodyBytes int64 = 64 << 10
+
+// requestError is a problem with the request itself, found before it reaches a
+// service, that has its own HTTP status
+type requestError struct {
+	status  int
+	code    string
+	message string
+}
+
+// Error returns the client-facing message
+func (e *requestError) Error() string {
+	return e.message
+}
+
+// decodeJSON decodes a JSON request body into v. The body must be sent as
+// application/json, fit within the body limit and hold exactly one JSON value
+// whose fields all exist in v.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	return decodeBody(w, r, v, false)
+}
+
+// decodeOptionalJSON is like decodeJSON but treats an empty body as valid
+func decodeOptionalJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	return decodeBody(w, r, v, true)
+}
+
+// decodeBody implements decodeJSON and decodeOptionalJSON
+func decodeBody(w http.ResponseWriter, r *http.Request, v any, optional bool) error {
+	limit := maxBodyBytes(r)
+	body := bufio.NewReader(http.MaxBytesReader(w, r.Body, limit))
+
+	if optional {
+		if _, err := body.Peek(1); errors.Is(err, io.EOF) {
+			return nil
+		}
+	}
+
+	if err := requireJSONContentType(r); err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return decodeFailure(err, limit)
+	}
+
+	// Anything but whitespace after the first value is rejected
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return bodyTooLarge(limit)
+		}
+		return services.NewError(services.ErrValidation, "request body must contain a single JSON value")
+	}
+
+	return nil
+}
+
+// requireJSONContentType rejects requests whose body is not declared as JSON
+func requireJSONContentType(r *http.Request) error {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return &requestError{
+			status:  http.StatusUnsupportedMediaType,
+			code:    "unsupported_media_type",
+			message: "Content-Type header must be application/json",
+		}
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "application/json" {
+		return &requestError{
+			status:  http.StatusUnsupportedMediaType,
+			code:    "unsupported_media_type",
+			message: fmt.Sprintf("Content-Type must be application/json, got %q", contentType),
+		}
+	}
+	return nil
+}
+
+// decodeFailure turns a JSON decoding error into a precise client-facing error
+func decodeFailure(err error, limit int64) error {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+		tooLarge  *http.MaxBytesError
+	)
+
+	switch {
+	case errors.As(err, &tooLarge):
+		return bodyTooLarge(limit)
+	case errors.Is(err, io.EOF):
+		return services.NewError(services.ErrValidation, "request body must not be empty")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return services.NewError(services.ErrValidation, "request body contains incomplete JSON")
+	case errors.As(err, &syntaxErr):
+		return services.NewError(services.ErrValidation, "request body contains malformed JSON at byte %d", syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return services.NewError(services.ErrValidation, "request body must be a JSON object")
+		}
+		return services.NewError(services.ErrValidation, "request body field %q must be %s", typeErr.Field, jsonTypeName(typeErr.Type))
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json has no error type for unknown fields
+		field := strings.TrimPrefix(err.Error(), "json: unknown field ")
+		return services.NewError(services.ErrValidation, "request body contains unknown field %s", field)
+	default:
+		return services.NewError(services.ErrValidation, "invalid request body")
+	}
+}
+
+// bodyTooLarge reports a request body over the limit
+func bodyTooLarge(limit int64) error {
+	return &requestError{
+		status:  http.StatusRequestEntityTooLarge,
+		code:    "payload_too_large",
+		message: fmt.Sprintf("request body must not be larger than %d bytes", limit),
+	}
+}
+
+// jsonTypeName describes the JSON value expected for a Go type
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+// maxBodyBytes returns the body limit configured for the request
+func maxBodyBytes(r *http.Request) int64 {
+	if limit, ok := r.Context().Value(bodyLimitKey).(int64); ok && limit > 0 {
+		return limit
+	}
+	return DefaultMaxBodyBytes
+}
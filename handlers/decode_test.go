@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"python-backend-with-go/models"
+)
+
+type decodeTestRequest struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// serveDecode runs decodeJSON (or decodeOptionalJSON) behind a 32-byte body limit
+func serveDecode(t *testing.T, optional bool, contentType, body string) (*httptest.ResponseRecorder, decodeTestRequest) {
+	t.Helper()
+
+	var decoded decodeTestRequest
+	handler := BodyLimitMiddleware(32)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		decode := decodeJSON
+		if optional {
+			decode = decodeOptionalJSON
+		}
+		if err := decode(w, r, &decoded); err != nil {
+			handleError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec, decoded
+}
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name            string
+		optional        bool
+		contentType     string
+		body            string
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{
+			name:           "valid",
+			contentType:    "application/json",
+			body:           `{"name":"홍길동","count":2}`,
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "charset parameter",
+			contentType:    "application/json; charset=utf-8",
+			body:           `{"name":"a"}` + "\n",
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:            "missing content type",
+			body:            `{"name":"a"}`,
+			expectedStatus:  http.StatusUnsupportedMediaType,
+			expectedMessage: "Content-Type header must be application/json",
+		},
+		{
+			name:            "wrong content type",
+			contentType:     "text/plain",
+			body:            `{"name":"a"}`,
+			expectedStatus:  http.StatusUnsupportedMediaType,
+			expectedMessage: `Content-Type must be application/json, got "text/plain"`,
+		},
+		{
+			name:            "too large",
+			contentType:     "application/json",
+			body:            `{"name":"` + strings.Repeat("a", 40) + `"}`,
+			expectedStatus:  http.StatusRequestEntityTooLarge,
+			expectedMessage: "request body must not be larger than 32 bytes",
+		},
+		{
+			name:            "empty",
+			contentType:     "application/json",
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "request body must not be empty",
+		},
+		{
+			name:            "unknown field",
+			contentType:     "application/json",
+			body:            `{"name":"a","admin":true}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: `request body contains unknown field "admin"`,
+		},
+		{
+			name:            "trailing data",
+			contentType:     "application/json",
+			body:            `{"name":"a"}{"name":"b"}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "request body must contain a single JSON value",
+		},
+		{
+			name:            "malformed",
+			contentType:     "application/json",
+			body:            `{"name":'a'}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "request body contains malformed JSON at byte 9",
+		},
+		{
+			name:            "incomplete",
+			contentType:     "application/json",
+			body:            `{"name":"a"`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "request body contains incomplete JSON",
+		},
+		{
+			name:            "wrong field type",
+			contentType:     "application/json",
+			body:            `{"count":"two"}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: `request body field "count" must be an integer`,
+		},
+		{
+			name:            "not an object",
+			contentType:     "application/json",
+			body:            `["a"]`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "request body must be a JSON object",
+		},
+		{
+			name:           "optional and empty",
+			optional:       true,
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:            "optional with a body still needs a content type",
+			optional:        true,
+			body:            `{"name":"a"}`,
+			expectedStatus:  http.StatusUnsupportedMediaType,
+			expectedMessage: "Content-Type header must be application/json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, _ := serveDecode(t, tt.optional, tt.contentType, tt.body)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tt.expectedMessage == "" {
+				return
+			}
+
+			var resp models.ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if resp.Message != tt.expectedMessage {
+				t.Errorf("Expected message '%s', got '%s'", tt.expectedMessage, resp.Message)
+			}
+		})
+	}
+}
+
+func TestDecodeJSON_Decodes(t *testing.T) {
+	rec, decoded := serveDecode(t, false, "application/json", `{"name":"홍길동","count":2}`)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusNoContent, rec.Code, rec.Body.String())
+	}
+	if decoded.Name != "홍길동" || decoded.Count != 2 {
+		t.Errorf("Unexpected decoded value: %+v", decoded)
+	}
+}
@@ -26,9 +26,9 @@ func NewFollowHandler(followService *services.FollowService) *FollowHandler {
 func (h *FollowHandler) HandleFollow(w http.ResponseWriter, r *http.Request) {
 	var req models.FollowRequest
 
-	// Decode request body
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		handleError(w, fmt.Errorf("invalid request body"), http.StatusBadRequest)
+	// Decode request body (optional)
+	if err := decodeOptionalJSON(w, r, &req); err != nil {
+		handleError(w, err)
 		return
 	}
 
@@ -36,25 +36,20 @@ func (h *FollowHandler) HandleFollow(w http.ResponseWriter, r *http.Request) {
 	followingIDStr := r.PathValue("userID")
 	followingID := 0
 	if _, err := fmt.Sscanf(followingIDStr, "%d", &followingID); err != nil {
-		handleError(w, fmt.Errorf("invalid user ID"), http.StatusBadRequest)
+		handleError(w, services.NewError(services.ErrValidation, "invalid user ID"))
+		return
+	}
+
+	// Resolve follower from the access token
+	followerID, ok := actingUser(w, r, req.FollowerID)
+	if !ok {
 		return
 	}
 
 	// Call service
-	resp, err := h.followService.Follow(req.FollowerID, followingID)
+	resp, err := h.followService.Follow(followerID, followingID)
 	if err != nil {
-		switch err.Error() {
-		case "follower_id and following user ID are required":
-			handleError(w, err, http.StatusBadRequest)
-		case "cannot follow yourself":
-			handleError(w, err, http.StatusBadRequest)
-		case "follower user not found", "following user not found":
-			handleError(w, err, http.StatusNotFound)
-		case "already following this user":
-			handleError(w, err, http.StatusConflict)
-		default:
-			handleError(w, err, http.StatusInternalServerError)
-		}
+		handleError(w, err)
 		return
 	}
 
@@ -62,20 +57,20 @@ func (h *FollowHandler) HandleFollow(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		handleError(w, err, http.StatusInternalServerError)
+		handleError(w, err)
 		return
 	}
 
-	slog.Info("Follow created", "follower_id", req.FollowerID, "following_id", followingID)
+	slog.Info("Follow created", "follower_id", followerID, "following_id", followingID)
 }
 
 // HandleUnfollow handles unfollow requests
 func (h *FollowHandler) HandleUnfollow(w http.ResponseWriter, r *http.Request) {
 	var req models.FollowRequest
 
-	// Decode request body
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		handleError(w, fmt.Errorf("invalid request body"), http.StatusBadRequest)
+	// Decode request body (optional)
+	if err := decodeOptionalJSON(w, r, &req); err != nil {
+		handleError(w, err)
 		return
 	}
 
@@ -83,21 +78,20 @@ func (h *FollowHandler) HandleUnfollow(w http.ResponseWriter, r *http.Request) {
 	followingIDStr := r.PathValue("userID")
 	followingID := 0
 	if _, err := fmt.Sscanf(followingIDStr, "%d", &followingID); err != nil {
-		handleError(w, fmt.Errorf("invalid user ID"), http.StatusBadRequest)
+		handleError(w, services.NewError(services.ErrValidation, "invalid user ID"))
+		return
+	}
+
+	// Resolve follower from the access token
+	followerID, ok := actingUser(w, r, req.FollowerID)
+	if !ok {
 		return
 	}
 
 	// Call service
-	resp, err := h.followService.Unfollow(req.FollowerID, followingID)
+	resp, err := h.followService.Unfollow(followerID, followingID)
 	if err != nil {
-		switch err.Error() {
-		case "follower_id and following user ID are required":
-			handleError(w, err, http.StatusBadRequest)
-		case "follow relationship not found":
-			handleError(w, err, http.StatusNotFound)
-		default:
-			handleError(w, err, http.StatusInternalServerError)
-		}
+		handleError(w, err)
 		return
 	}
 
@@ -105,11 +99,11 @@ func (h *FollowHandler) HandleUnfollow(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		handleError(w, err, http.StatusInternalServerError)
+		handleError(w, err)
 		return
 	}
 
-	slog.Info("Follow deleted", "follower_id", req.FollowerID, "following_id", followingID)
+	slog.Info("Follow deleted", "follower_id", followerID, "following_id", followingID)
 }
 
 // HandleGetFollowers handles get followers requests
@@ -118,18 +112,21 @@ func (h *FollowHandler) HandleGetFollowers(w http.ResponseWriter, r *http.Reques
 	userIDStr := r.PathValue("userID")
 	userID := 0
 	if _, err := fmt.Sscanf(userIDStr, "%d", &userID); err != nil {
-		handleError(w, fmt.Errorf("invalid user ID"), http.StatusBadRequest)
+		handleError(w, services.NewError(services.ErrValidation, "invalid user ID"))
+		return
+	}
+
+	// Get pagination parameters
+	page, err := parsePageRequest(r)
+	if err != nil {
+		handleError(w, err)
 		return
 	}
 
 	// Call service
-	resp, err := h.followService.GetFollowers(userID)
+	resp, err := h.followService.GetFollowers(userID, page)
 	if err != nil {
-		if err.Error() == "user not found" {
-			handleError(w, err, http.StatusNotFound)
-		} else {
-			handleError(w, err, http.StatusInternalServerError)
-		}
+		handleError(w, err)
 		return
 	}
 
@@ -137,7 +134,7 @@ func (h *FollowHandler) HandleGetFollowers(w http.ResponseWriter, r *http.Reques
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		handleError(w, err, http.StatusInternalServerError)
+		handleError(w, err)
 		return
 	}
 
@@ -150,18 +147,21 @@ func (h *FollowHandler) HandleGetFollowing(w http.ResponseWriter, r *http.Reques
 	userIDStr := r.PathValue("userID")
 	userID := 0
 	if _, err := fmt.Sscanf(userIDStr, "%d", &userID); err != nil {
-		handleError(w, fmt.Errorf("invalid user ID"), http.StatusBadRequest)
+		handleError(w, services.NewError(services.ErrValidation, "invalid user ID"))
+		return
+	}
+
+	// Get pagination parameters
+	page, err := parsePageRequest(r)
+	if err != nil {
+		handleError(w, err)
 		return
 	}
 
 	// Call service
-	resp, err := h.followService.GetFollowing(userID)
+	resp, err := h.followService.GetFollowing(userID, page)
 	if err != nil {
-		if err.Error() == "user not found" {
-			handleError(w, err, http.StatusNotFound)
-		} else {
-			handleError(w, err, http.StatusInternalServerError)
-		}
+		handleError(w, err)
 		return
 	}
 
@@ -169,7 +169,7 @@ func (h *FollowHandler) HandleGetFollowing(w http.ResponseWriter, r *http.Reques
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		handleError(w, err, http.StatusInternalServerError)
+		handleError(w, err)
 		return
 	}
 
@@ -182,20 +182,20 @@ func (h *FollowHandler) HandleGetFollowStatus(w http.ResponseWriter, r *http.Req
 	followingIDStr := r.PathValue("userID")
 	followingID := 0
 	if _, err := fmt.Sscanf(followingIDStr, "%d", &followingID); err != nil {
-		handleError(w, fmt.Errorf("invalid user ID"), http.StatusBadRequest)
+		handleError(w, services.NewError(services.ErrValidation, "invalid user ID"))
 		return
 	}
 
 	// Get follower ID from query parameter
 	followerIDStr := r.URL.Query().Get("follower_id")
 	if followerIDStr == "" {
-		handleError(w, fmt.Errorf("follower_id query parameter is required"), http.StatusBadRequest)
+		handleError(w, services.NewError(services.ErrValidation, "follower_id query parameter is required"))
 		return
 	}
 
 	followerID := 0
 	if _, err := fmt.Sscanf(followerIDStr, "%d", &followerID); err != nil {
-		handleError(w, fmt.Errorf("invalid follower_id"), http.StatusBadRequest)
+		handleError(w, services.NewError(services.ErrValidation, "invalid follower_id"))
 		return
 	}
 
@@ -206,7 +206,7 @@ func (h *FollowHandler) HandleGetFollowStatus(w http.ResponseWriter, r *http.Req
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		handleError(w, err, http.StatusInternalServerError)
+		handleError(w, err)
 		return
 	}
 
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"python-backend-with-go/models"
+)
+
+func TestFollowHandler_Lists(t *testing.T) {
+	env := setupHandlerTest(t)
+
+	for _, followerID := range []int{2, 3} {
+		if rec := env.do(t, http.MethodPost, "/api/users/1/follow", followerID, nil); rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+		}
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want []int
+	}{
+		{name: "followers", path: "/api/users/1/followers", want: []int{3, 2}},
+		{name: "following", path: "/api/users/2/following", want: []int{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := env.do(t, http.MethodGet, tt.path, 0, nil)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+			}
+			var resp models.FollowListResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if resp.Count != len(tt.want) {
+				t.Fatalf("Expected %d users, got %+v", len(tt.want), resp.Users)
+			}
+			for i, id := range tt.want {
+				if resp.Users[i].ID != id || resp.Users[i].Name == "" {
+					t.Errorf("Expected user %d at %d, got %+v", id, i, resp.Users[i])
+				}
+			}
+			// Follow lists are public, so they must not reveal email addresses
+			if body := rec.Body.String(); strings.Contains(body, `"email"`) || strings.Contains(body, "@test.com") {
+				t.Errorf("Expected no email in the list, got %s", body)
+			}
+		})
+	}
+}
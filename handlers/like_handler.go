@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"python-backend-with-go/services"
+)
+
+// LikeHandler handles like-related HTTP requests
+type LikeHandler struct {
+	likeService *services.LikeService
+}
+
+// NewLikeHandler creates a new like handler
+func NewLikeHandler(likeService *services.LikeService) *LikeHandler {
+	return &LikeHandler{
+		likeService: likeService,
+	}
+}
+
+// HandleLike handles like requests
+func (h *LikeHandler) HandleLike(w http.ResponseWriter, r *http.Request) {
+	// Get post ID from URL path
+	postIDStr := r.PathValue("postID")
+	postID := 0
+	if _, err := fmt.Sscanf(postIDStr, "%d", &postID); err != nil {
+		handleError(w, services.NewError(services.ErrValidation, "invalid post ID"))
+		return
+	}
+
+	// Resolve acting user from the access token
+	userID, ok := actingUser(w, r, 0)
+	if !ok {
+		return
+	}
+
+	// Call service
+	resp, err := h.likeService.Like(postID, userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("Post liked", "post_id", postID, "user_id", userID)
+}
+
+// HandleUnlike handles unlike requests
+func (h *LikeHandler) HandleUnlike(w http.ResponseWriter, r *http.Request) {
+	// Get post ID from URL path
+	postIDStr := r.PathValue("postID")
+	postID := 0
+	if _, err := fmt.Sscanf(postIDStr, "%d", &postID); err != nil {
+		handleError(w, services.NewError(services.ErrValidation, "invalid post ID"))
+		return
+	}
+
+	// Resolve acting user from the access token
+	userID, ok := actingUser(w, r, 0)
+	if !ok {
+		return
+	}
+
+	// Call service
+	resp, err := h.likeService.Unlike(postID, userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("Post unliked", "post_id", postID, "user_id", userID)
+}
+
+// HandleGetLikes handles requests for the users who liked a post
+func (h *LikeHandler) HandleGetLikes(w http.ResponseWriter, r *http.Request) {
+	// Get post ID from URL path
+	postIDStr := r.PathValue("postID")
+	postID := 0
+	if _, err := fmt.Sscanf(postIDStr, "%d", &postID); err != nil {
+		handleError(w, services.NewError(services.ErrValidation, "invalid post ID"))
+		return
+	}
+
+	// Get pagination parameters
+	page, err := parsePageRequest(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Call service
+	resp, err := h.likeService.GetLikes(postID, page)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+}
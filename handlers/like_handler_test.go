@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"python-backend-with-go/models"
+)
+
+func TestLikeHandler(t *testing.T) {
+	env := setupHandlerTest(t)
+
+	rec := env.do(t, http.MethodPost, "/api/posts", 1, map[string]interface{}{"content": "좋아요 받을 글"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	// Liking twice succeeds both times and counts once
+	for i := 0; i < 2; i++ {
+		rec = env.do(t, http.MethodPost, "/api/posts/1/like", 2, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var resp models.LikeResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if !resp.Liked || resp.LikeCount != 1 {
+			t.Errorf("Expected liked with count 1, got %+v", resp)
+		}
+	}
+
+	userPosts := func(asUserID int) models.PostWithUser {
+		t.Helper()
+		rec := env.do(t, http.MethodGet, "/api/users/1/posts", asUserID, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var resp models.UserPostsResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if len(resp.Posts) != 1 {
+			t.Fatalf("Expected 1 post, got %d", len(resp.Posts))
+		}
+		return resp.Posts[0]
+	}
+
+	if post := userPosts(2); post.LikeCount != 1 || !post.LikedByMe {
+		t.Errorf("Expected the liker to see count 1 and liked_by_me, got %+v", post)
+	}
+	if post := userPosts(3); post.LikeCount != 1 || post.LikedByMe {
+		t.Errorf("Expected another user to see count 1 without liked_by_me, got %+v", post)
+	}
+	if post := userPosts(0); post.LikeCount != 1 || post.LikedByMe {
+		t.Errorf("Expected an anonymous viewer to see count 1 without liked_by_me, got %+v", post)
+	}
+
+	rec = env.do(t, http.MethodGet, "/api/posts/1/likes", 0, nil)
+	var likes models.LikeListResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &likes); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if rec.Code != http.StatusOK || likes.Count != 1 || likes.Users[0].ID != 2 {
+		t.Errorf("Expected user 2 in the likes list, got %d: %s", rec.Code, rec.Body.String())
+	}
+	// The likes list is public, so it must not reveal email addresses
+	if body := rec.Body.String(); strings.Contains(body, `"email"`) || strings.Contains(body, "@test.com") {
+		t.Errorf("Expected no email in the likes list, got %s", body)
+	}
+
+	// Unliking twice succeeds both times
+	for i := 0; i < 2; i++ {
+		rec = env.do(t, http.MethodDelete, "/api/posts/1/like", 2, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+	}
+	if post := userPosts(2); post.LikeCount != 0 || post.LikedByMe {
+		t.Errorf("Expected no likes after unliking, got %+v", post)
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		asUserID       int
+		expectedStatus int
+	}{
+		{name: "like without token", method: http.MethodPost, path: "/api/posts/1/like", expectedStatus: http.StatusUnauthorized},
+		{name: "like missing post", method: http.MethodPost, path: "/api/posts/999/like", asUserID: 2, expectedStatus: http.StatusNotFound},
+		{name: "like invalid post ID", method: http.MethodPost, path: "/api/posts/abc/like", asUserID: 2, expectedStatus: http.StatusBadRequest},
+		{name: "likes of missing post", method: http.MethodGet, path: "/api/posts/999/likes", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := env.do(t, tt.method, tt.path, tt.asUserID, nil)
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestOptionalAuthMiddleware_RejectsInvalidToken(t *testing.T) {
+	env := setupHandlerTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/users/1/posts", nil)
+	req.Header.Set("Authorization", "Bearer not-a-token")
+	rec := httptest.NewRecorder()
+	env.mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+	}
+}
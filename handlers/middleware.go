@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"python-backend-with-go/repository"
 	"python-backend-with-go/services"
 )
 
@@ -19,7 +22,7 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 
 		// Generate request ID
 		requestID := uuid.New().String()
-		ctx := context.WithValue(r.Context(), "request_id", requestID)
+		ctx := context.WithValue(r.Context(), requestIDKey, requestID)
 		r = r.WithContext(ctx)
 
 		// Create response writer wrapper to capture status code
@@ -45,14 +48,13 @@ func RecoveryMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				requestID, _ := r.Context().Value("request_id").(string)
 				slog.Error("Panic recovered",
-					"request_id", requestID,
+					"request_id", RequestID(r.Context()),
 					"error", err,
 					"path", r.URL.Path,
 				)
 
-				handleError(w, fmt.Errorf("internal server error"), http.StatusInternalServerError)
+				handleError(w, fmt.Errorf("internal server error"))
 			}
 		}()
 
@@ -64,8 +66,9 @@ func RecoveryMiddleware(next http.Handler) http.Handler {
 func CORSMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, If-None-Match")
+		w.Header().Set("Access-Control-Expose-Headers", "ETag, Retry-After, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset")
 
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusOK)
@@ -88,6 +91,61 @@ func SecurityHeadersMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// BodyLimitMiddleware sets the largest request body, in bytes, that handlers
+// decode. Larger bodies are rejected with 413.
+func BodyLimitMiddleware(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), bodyLimitKey, maxBytes)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RateLimitMiddleware throttles requests with a token bucket per client.
+// Authenticated requests are keyed by user ID and anonymous ones by client IP, so
+// on protected routes it must run inside AuthMiddleware. name keeps the buckets of
+// differently configured routes apart.
+func RateLimitMiddleware(store repository.RateLimitStore, name string, limit repository.RateLimit) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := name + ":ip:" + clientIP(r)
+			if principal, ok := CurrentUser(r.Context()); ok {
+				key = name + ":user:" + strconv.Itoa(principal.UserID)
+			}
+
+			result, err := store.Take(key, limit, time.Now())
+			if err != nil {
+				// Fail open: an unavailable limiter must not take the API down
+				slog.Warn("Rate limit check failed", "request_id", RequestID(r.Context()), "key", key, "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit.Requests))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			w.Header().Set("X-RateLimit-Reset", strconv.Itoa(services.CeilSeconds(result.ResetAfter)))
+
+			if !result.Allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(max(services.CeilSeconds(result.RetryAfter), 1)))
+				handleError(w, services.NewError(services.ErrRateLimited, "too many requests, please retry later"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientIP returns the IP address of the directly connected client
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 // responseWriter is a wrapper for http.ResponseWriter to capture status code
 type responseWriter struct {
 	http.ResponseWriter
@@ -106,14 +164,14 @@ func AuthMiddleware(authService *services.AuthService) func(http.Handler) http.H
 			// Extract token from Authorization header
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
-				handleError(w, fmt.Errorf("authorization header required"), http.StatusUnauthorized)
+				handleError(w, services.NewError(services.ErrUnauthenticated, "authorization header required"))
 				return
 			}
 
 			// Check if it's a Bearer token
 			parts := strings.SplitN(authHeader, " ", 2)
 			if len(parts) != 2 || parts[0] != "Bearer" {
-				handleError(w, fmt.Errorf("invalid authorization header format"), http.StatusUnauthorized)
+				handleError(w, services.NewError(services.ErrUnauthenticated, "invalid authorization header format"))
 				return
 			}
 
@@ -123,16 +181,36 @@ func AuthMiddleware(authService *services.AuthService) func(http.Handler) http.H
 			claims, err := authService.ValidateToken(tokenString)
 			if err != nil {
 				slog.Warn("Token validation failed", "error", err)
-				handleError(w, fmt.Errorf("invalid or expired token"), http.StatusUnauthorized)
+				handleError(w, services.NewError(services.ErrUnauthenticated, "invalid or expired token"))
 				return
 			}
 
-			// Add user_id to request context
-			ctx := context.WithValue(r.Context(), "user_id", claims.UserID)
-			ctx = context.WithValue(ctx, "user_email", claims.Email)
+			// Add authenticated principal to request context
+			ctx := WithPrincipal(r.Context(), Principal{
+				UserID:    claims.UserID,
+				Email:     claims.Email,
+				SessionID: claims.SessionID,
+			})
 			r = r.WithContext(ctx)
 
 			next.ServeHTTP(w, r)
 		})
 	}
 }
+
+// OptionalAuthMiddleware authenticates requests that carry an Authorization
+// header, like AuthMiddleware, and lets requests without one through anonymously.
+// A header with an invalid token is still rejected rather than silently ignored.
+func OptionalAuthMiddleware(authService *services.AuthService) func(http.Handler) http.Handler {
+	authenticate := AuthMiddleware(authService)
+	return func(next http.Handler) http.Handler {
+		authenticated := authenticate(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			authenticated.ServeHTTP(w, r)
+		})
+	}
+}
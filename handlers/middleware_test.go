@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"python-backend-with-go/repository"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	store := repository.NewInMemoryRateLimitStore()
+	limit := repository.RateLimit{Requests: 2, Per: time.Minute}
+	handler := RateLimitMiddleware(store, "test", limit)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	send := func(remoteAddr string, userID int) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.RemoteAddr = remoteAddr
+		if userID != 0 {
+			req = req.WithContext(WithPrincipal(req.Context(), Principal{UserID: userID}))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Anonymous clients are limited per IP, regardless of port
+	for i, wantRemaining := range []string{"1", "0"} {
+		rec := send("10.0.0.1:1000"+string(rune('0'+i)), 0)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("request %d: expected status %d, got %d", i+1, http.StatusOK, rec.Code)
+		}
+		if got := rec.Header().Get("X-RateLimit-Remaining"); got != wantRemaining {
+			t.Errorf("request %d: X-RateLimit-Remaining = %q, want %q", i+1, got, wantRemaining)
+		}
+		if got := rec.Header().Get("X-RateLimit-Limit"); got != "2" {
+			t.Errorf("request %d: X-RateLimit-Limit = %q, want \"2\"", i+1, got)
+		}
+	}
+
+	rec := send("10.0.0.1:20000", 0)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status %d, got %d", http.StatusTooManyRequests, rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("Retry-After = %q, want \"30\"", got)
+	}
+	if got := rec.Header().Get("X-RateLimit-Reset"); got != "60" {
+		t.Errorf("X-RateLimit-Reset = %q, want \"60\"", got)
+	}
+
+	// Another IP and an authenticated user behind the same IP get their own buckets
+	if rec := send("10.0.0.2:10000", 0); rec.Code != http.StatusOK {
+		t.Errorf("other IP: expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if rec := send("10.0.0.1:10000", 1); rec.Code != http.StatusOK {
+		t.Errorf("authenticated user: expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
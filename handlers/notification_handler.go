@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/services"
+)
+
+// NotificationHandler handles notification HTTP requests
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(notificationService *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// HandleGetNotifications handles requests for the signed-in user's notifications
+func (h *NotificationHandler) HandleGetNotifications(w http.ResponseWriter, r *http.Request) {
+	// Resolve the recipient from the access token
+	userID, ok := actingUser(w, r, 0)
+	if !ok {
+		return
+	}
+
+	// Get pagination parameters
+	page, err := parsePageRequest(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Call service
+	resp, err := h.notificationService.GetNotifications(userID, page)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("Notifications retrieved", "user_id", userID, "count", resp.Count, "unread_count", resp.UnreadCount)
+}
+
+// HandleMarkRead handles requests to mark notifications read
+func (h *NotificationHandler) HandleMarkRead(w http.ResponseWriter, r *http.Request) {
+	var req models.MarkNotificationsReadRequest
+
+	// Decode request body (optional; an empty body marks every notification read)
+	if err := decodeOptionalJSON(w, r, &req); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Resolve acting user from the access token
+	userID, ok := actingUser(w, r, 0)
+	if !ok {
+		return
+	}
+
+	// Call service
+	resp, err := h.notificationService.MarkRead(userID, req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("Notifications marked read", "user_id", userID, "marked", resp.Marked)
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"python-backend-with-go/models"
+)
+
+func TestNotificationHandler(t *testing.T) {
+	env := setupHandlerTest(t)
+	env.do(t, http.MethodPost, "/api/users/1/follow", 2, nil)
+	env.do(t, http.MethodPost, "/api/users/1/follow", 3, nil)
+	env.do(t, http.MethodPost, "/api/posts", 2, map[string]interface{}{"content": "@User1 안녕하세요"})
+
+	// Notifications are private
+	if rec := env.do(t, http.MethodGet, "/api/notifications", 0, nil); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d without a token, got %d", http.StatusUnauthorized, rec.Code)
+	}
+
+	rec := env.do(t, http.MethodGet, "/api/notifications?limit=10", 1, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var resp models.NotificationsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if resp.Count != 2 || resp.UnreadCount != 3 {
+		t.Fatalf("Expected a mention and a follow group with 3 unread, got %s", rec.Body.String())
+	}
+	if group := resp.Notifications[1]; group.Message != "User3님 외 1명이 회원님을 팔로우했습니다." || group.ActorCount != 2 {
+		t.Errorf("Unexpected follow group: %+v", group)
+	}
+	// Actors are other users, so their email addresses are not shown
+	if body := rec.Body.String(); strings.Contains(body, `"email"`) || strings.Contains(body, "@test.com") {
+		t.Errorf("Expected no email in the notifications, got %s", body)
+	}
+
+	// Mark the mention read, then everything
+	rec = env.do(t, http.MethodPost, "/api/notifications/read", 1, map[string]interface{}{"ids": resp.Notifications[0].NotificationIDs})
+	var marked models.MarkNotificationsReadResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &marked); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if rec.Code != http.StatusOK || marked.Marked != 1 || marked.UnreadCount != 2 {
+		t.Errorf("Expected 1 marked and 2 unread, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/notifications/read", nil)
+	req.Header.Set("Authorization", "Bearer "+env.tokens[1])
+	rec = httptest.NewRecorder()
+	env.mux.ServeHTTP(rec, req)
+	if err := json.Unmarshal(rec.Body.Bytes(), &marked); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if rec.Code != http.StatusOK || marked.Marked != 2 || marked.UnreadCount != 0 {
+		t.Errorf("Expected an empty body to mark the rest read, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
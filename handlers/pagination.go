@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/services"
+)
+
+// parsePageRequest reads the ?limit=&cursor= query parameters
+func parsePageRequest(r *http.Request) (models.PageRequest, error) {
+	query := r.URL.Query()
+	page := models.PageRequest{Cursor: query.Get("cursor")}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return models.PageRequest{}, services.NewError(services.ErrValidation, "invalid limit")
+		}
+		page.Limit = limit
+	}
+
+	return page, nil
+}
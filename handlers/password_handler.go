@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/services"
+)
+
+// PasswordHandler handles password change and reset HTTP requests
+type PasswordHandler struct {
+	passwordService *services.PasswordService
+}
+
+// NewPasswordHandler creates a new password handler
+func NewPasswordHandler(passwordService *services.PasswordService) *PasswordHandler {
+	return &PasswordHandler{
+		passwordService: passwordService,
+	}
+}
+
+// HandleChangePassword handles changing the authenticated user's password
+func (h *PasswordHandler) HandleChangePassword(w http.ResponseWriter, r *http.Request) {
+	var req models.ChangePasswordRequest
+
+	// Decode request body
+	if err := decodeJSON(w, r, &req); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	userID, ok := actingUser(w, r, 0)
+	if !ok {
+		return
+	}
+
+	// Call service
+	resp, err := h.passwordService.ChangePassword(userID, req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("Password changed", "user_id", userID)
+}
+
+// HandleForgotPassword handles requesting a password reset email
+func (h *PasswordHandler) HandleForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req models.ForgotPasswordRequest
+
+	// Decode request body
+	if err := decodeJSON(w, r, &req); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Call service
+	resp, err := h.passwordService.RequestPasswordReset(req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+}
+
+// HandleResetPassword handles setting a new password with a reset token
+func (h *PasswordHandler) HandleResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req models.ResetPasswordRequest
+
+	// Decode request body
+	if err := decodeJSON(w, r, &req); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Call service
+	resp, err := h.passwordService.ResetPassword(req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("Password reset completed")
+}
@@ -27,31 +27,105 @@ func (h *PostHandler) HandleCreatePost(w http.ResponseWriter, r *http.Request) {
 	var req models.CreatePostRequest
 
 	// Decode request body
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		slog.Error("Failed to decode create post request", "error", err)
-		handleError(w, fmt.Errorf("invalid request body: %v", err), http.StatusBadRequest)
+	if err := decodeJSON(w, r, &req); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Resolve author from the access token
+	userID, ok := actingUser(w, r, req.UserID)
+	if !ok {
+		return
+	}
+
+	// Call service
+	resp, err := h.postService.CreatePost(userID, req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("Post created", "post_id", resp.PostID, "user_id", userID)
+}
+
+// HandleCreateReply handles reply requests
+func (h *PostHandler) HandleCreateReply(w http.ResponseWriter, r *http.Request) {
+	var req models.CreatePostRequest
+
+	// Decode request body
+	if err := decodeJSON(w, r, &req); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Get parent post ID from URL path
+	postIDStr := r.PathValue("postID")
+	postID := 0
+	if _, err := fmt.Sscanf(postIDStr, "%d", &postID); err != nil {
+		handleError(w, services.NewError(services.ErrValidation, "invalid post ID"))
+		return
+	}
+
+	// Resolve author from the access token
+	userID, ok := actingUser(w, r, req.UserID)
+	if !ok {
 		return
 	}
 
 	// Call service
-	resp, err := h.postService.CreatePost(req)
-	if err != nil {
-		switch err.Error() {
-		case "user_id is required":
-			handleError(w, err, http.StatusBadRequest)
-		case "user not found":
-			handleError(w, err, http.StatusNotFound)
-		case "content is required":
-			handleError(w, err, http.StatusBadRequest)
-		case fmt.Sprintf("content must be %d characters or less", services.MaxPostContentLength):
-			handleError(w, err, http.StatusBadRequest)
-		default:
-			if err.Error() == fmt.Sprintf("content must be %d characters or less", services.MaxPostContentLength) {
-				handleError(w, err, http.StatusBadRequest)
-			} else {
-				handleError(w, err, http.StatusInternalServerError)
-			}
-		}
+	resp, err := h.postService.CreateReply(postID, userID, req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("Reply created", "post_id", resp.PostID, "in_reply_to_post_id", postID, "user_id", userID)
+}
+
+// HandleQuotePost handles quote post requests
+func (h *PostHandler) HandleQuotePost(w http.ResponseWriter, r *http.Request) {
+	var req models.CreatePostRequest
+
+	// Decode request body
+	if err := decodeJSON(w, r, &req); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Get quoted post ID from URL path
+	postIDStr := r.PathValue("postID")
+	postID := 0
+	if _, err := fmt.Sscanf(postIDStr, "%d", &postID); err != nil {
+		handleError(w, services.NewError(services.ErrValidation, "invalid post ID"))
+		return
+	}
+
+	// Resolve author from the access token
+	userID, ok := actingUser(w, r, req.UserID)
+	if !ok {
+		return
+	}
+
+	// Call service
+	resp, err := h.postService.QuotePost(postID, userID, req)
+	if err != nil {
+		handleError(w, err)
 		return
 	}
 
@@ -59,11 +133,79 @@ func (h *PostHandler) HandleCreatePost(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		handleError(w, err, http.StatusInternalServerError)
+		handleError(w, err)
 		return
 	}
 
-	slog.Info("Post created", "post_id", resp.PostID, "user_id", req.UserID)
+	slog.Info("Quote post created", "post_id", resp.PostID, "original_post_id", postID, "user_id", userID)
+}
+
+// HandleRepost handles repost requests
+func (h *PostHandler) HandleRepost(w http.ResponseWriter, r *http.Request) {
+	// Get reposted post ID from URL path
+	postIDStr := r.PathValue("postID")
+	postID := 0
+	if _, err := fmt.Sscanf(postIDStr, "%d", &postID); err != nil {
+		handleError(w, services.NewError(services.ErrValidation, "invalid post ID"))
+		return
+	}
+
+	// Resolve acting user from the access token
+	userID, ok := actingUser(w, r, 0)
+	if !ok {
+		return
+	}
+
+	// Call service
+	resp, err := h.postService.Repost(postID, userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("Post reposted", "post_id", resp.PostID, "original_post_id", postID, "user_id", userID)
+}
+
+// HandleUndoRepost handles undo repost requests
+func (h *PostHandler) HandleUndoRepost(w http.ResponseWriter, r *http.Request) {
+	// Get reposted post ID from URL path
+	postIDStr := r.PathValue("postID")
+	postID := 0
+	if _, err := fmt.Sscanf(postIDStr, "%d", &postID); err != nil {
+		handleError(w, services.NewError(services.ErrValidation, "invalid post ID"))
+		return
+	}
+
+	// Resolve acting user from the access token
+	userID, ok := actingUser(w, r, 0)
+	if !ok {
+		return
+	}
+
+	// Call service
+	resp, err := h.postService.UndoRepost(postID, userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("Repost undone", "post_id", resp.PostID, "original_post_id", resp.OriginalPostID, "user_id", userID)
 }
 
 // HandleUpdatePost handles update post requests
@@ -71,8 +213,8 @@ func (h *PostHandler) HandleUpdatePost(w http.ResponseWriter, r *http.Request) {
 	var req models.UpdatePostRequest
 
 	// Decode request body
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		handleError(w, fmt.Errorf("invalid request body"), http.StatusBadRequest)
+	if err := decodeJSON(w, r, &req); err != nil {
+		handleError(w, err)
 		return
 	}
 
@@ -80,29 +222,20 @@ func (h *PostHandler) HandleUpdatePost(w http.ResponseWriter, r *http.Request) {
 	postIDStr := r.PathValue("postID")
 	postID := 0
 	if _, err := fmt.Sscanf(postIDStr, "%d", &postID); err != nil {
-		handleError(w, fmt.Errorf("invalid post ID"), http.StatusBadRequest)
+		handleError(w, services.NewError(services.ErrValidation, "invalid post ID"))
+		return
+	}
+
+	// Resolve acting user from the access token
+	userID, ok := actingUser(w, r, req.UserID)
+	if !ok {
 		return
 	}
 
 	// Call service
-	resp, err := h.postService.UpdatePost(postID, req)
-	if err != nil {
-		switch err.Error() {
-		case "post_id and user_id are required":
-			handleError(w, err, http.StatusBadRequest)
-		case "content is required":
-			handleError(w, err, http.StatusBadRequest)
-		case "post not found":
-			handleError(w, err, http.StatusNotFound)
-		case "unauthorized to update this post":
-			handleError(w, err, http.StatusForbidden)
-		default:
-			if err.Error() == fmt.Sprintf("content must be %d characters or less", services.MaxPostContentLength) {
-				handleError(w, err, http.StatusBadRequest)
-			} else {
-				handleError(w, err, http.StatusInternalServerError)
-			}
-		}
+	resp, err := h.postService.UpdatePost(postID, userID, req)
+	if err != nil {
+		handleError(w, err)
 		return
 	}
 
@@ -110,44 +243,75 @@ func (h *PostHandler) HandleUpdatePost(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		handleError(w, err, http.StatusInternalServerError)
+		handleError(w, err)
 		return
 	}
 
-	slog.Info("Post updated", "post_id", postID, "user_id", req.UserID)
+	slog.Info("Post updated", "post_id", postID, "user_id", userID)
 }
 
 // HandleDeletePost handles delete post requests
 func (h *PostHandler) HandleDeletePost(w http.ResponseWriter, r *http.Request) {
 	var req models.DeletePostRequest
 
-	// Decode request body
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		handleError(w, fmt.Errorf("invalid request body"), http.StatusBadRequest)
+	// Decode request body (optional)
+	if err := decodeOptionalJSON(w, r, &req); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Get post ID from URL path
+	postIDStr := r.PathValue("postID")
+	postID := 0
+	if _, err := fmt.Sscanf(postIDStr, "%d", &postID); err != nil {
+		handleError(w, services.NewError(services.ErrValidation, "invalid post ID"))
+		return
+	}
+
+	// Resolve acting user from the access token
+	userID, ok := actingUser(w, r, req.UserID)
+	if !ok {
+		return
+	}
+
+	// Call service
+	resp, err := h.postService.DeletePost(postID, userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
 		return
 	}
 
+	slog.Info("Post deleted", "post_id", postID, "user_id", userID)
+}
+
+// HandleRestorePost handles restore post requests
+func (h *PostHandler) HandleRestorePost(w http.ResponseWriter, r *http.Request) {
 	// Get post ID from URL path
 	postIDStr := r.PathValue("postID")
 	postID := 0
 	if _, err := fmt.Sscanf(postIDStr, "%d", &postID); err != nil {
-		handleError(w, fmt.Errorf("invalid post ID"), http.StatusBadRequest)
+		handleError(w, services.NewError(services.ErrValidation, "invalid post ID"))
+		return
+	}
+
+	// Resolve acting user from the access token
+	userID, ok := actingUser(w, r, 0)
+	if !ok {
 		return
 	}
 
 	// Call service
-	resp, err := h.postService.DeletePost(postID, req.UserID)
+	resp, err := h.postService.RestorePost(postID, userID)
 	if err != nil {
-		switch err.Error() {
-		case "post_id and user_id are required":
-			handleError(w, err, http.StatusBadRequest)
-		case "post not found":
-			handleError(w, err, http.StatusNotFound)
-		case "unauthorized to delete this post":
-			handleError(w, err, http.StatusForbidden)
-		default:
-			handleError(w, err, http.StatusInternalServerError)
-		}
+		handleError(w, err)
 		return
 	}
 
@@ -155,11 +319,44 @@ func (h *PostHandler) HandleDeletePost(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		handleError(w, err, http.StatusInternalServerError)
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("Post restored", "post_id", postID, "user_id", userID)
+}
+
+// HandleGetPostHistory handles requests for the earlier versions of a post
+func (h *PostHandler) HandleGetPostHistory(w http.ResponseWriter, r *http.Request) {
+	// Get post ID from URL path
+	postIDStr := r.PathValue("postID")
+	postID := 0
+	if _, err := fmt.Sscanf(postIDStr, "%d", &postID); err != nil {
+		handleError(w, services.NewError(services.ErrValidation, "invalid post ID"))
+		return
+	}
+
+	// Get pagination parameters
+	page, err := parsePageRequest(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Call service
+	resp, err := h.postService.GetPostHistory(postID, page)
+	if err != nil {
+		handleError(w, err)
 		return
 	}
 
-	slog.Info("Post deleted", "post_id", postID, "user_id", req.UserID)
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
 }
 
 // HandleGetUserPosts handles get user posts requests
@@ -168,18 +365,21 @@ func (h *PostHandler) HandleGetUserPosts(w http.ResponseWriter, r *http.Request)
 	userIDStr := r.PathValue("userID")
 	userID := 0
 	if _, err := fmt.Sscanf(userIDStr, "%d", &userID); err != nil {
-		handleError(w, fmt.Errorf("invalid user ID"), http.StatusBadRequest)
+		handleError(w, services.NewError(services.ErrValidation, "invalid user ID"))
+		return
+	}
+
+	// Get pagination parameters
+	page, err := parsePageRequest(r)
+	if err != nil {
+		handleError(w, err)
 		return
 	}
 
 	// Call service
-	resp, err := h.postService.GetUserPosts(userID)
+	resp, err := h.postService.GetUserPosts(userID, viewerID(r), page)
 	if err != nil {
-		if err.Error() == "user not found" {
-			handleError(w, err, http.StatusNotFound)
-		} else {
-			handleError(w, err, http.StatusInternalServerError)
-		}
+		handleError(w, err)
 		return
 	}
 
@@ -187,31 +387,96 @@ func (h *PostHandler) HandleGetUserPosts(w http.ResponseWriter, r *http.Request)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		handleError(w, err, http.StatusInternalServerError)
+		handleError(w, err)
 		return
 	}
 
 	slog.Info("User posts retrieved", "user_id", userID, "count", resp.Count)
 }
 
+// HandleGetHashtagPosts handles requests for the posts tagged with a hashtag
+func (h *PostHandler) HandleGetHashtagPosts(w http.ResponseWriter, r *http.Request) {
+	// Get pagination parameters
+	page, err := parsePageRequest(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Call service with the hashtag from the URL path
+	resp, err := h.postService.GetHashtagPosts(r.PathValue("tag"), viewerID(r), page)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("Hashtag posts retrieved", "tag", resp.Tag, "count", resp.Count)
+}
+
+// HandleGetMentions handles requests for the posts mentioning a user
+func (h *PostHandler) HandleGetMentions(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from URL path
+	userIDStr := r.PathValue("userID")
+	userID := 0
+	if _, err := fmt.Sscanf(userIDStr, "%d", &userID); err != nil {
+		handleError(w, services.NewError(services.ErrValidation, "invalid user ID"))
+		return
+	}
+
+	// Get pagination parameters
+	page, err := parsePageRequest(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Call service
+	resp, err := h.postService.GetMentions(userID, viewerID(r), page)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("Mentions retrieved", "user_id", userID, "count", resp.Count)
+}
+
 // HandleGetTimeline handles get timeline requests
 func (h *PostHandler) HandleGetTimeline(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from URL path
 	userIDStr := r.PathValue("userID")
 	userID := 0
 	if _, err := fmt.Sscanf(userIDStr, "%d", &userID); err != nil {
-		handleError(w, fmt.Errorf("invalid user ID"), http.StatusBadRequest)
+		handleError(w, services.NewError(services.ErrValidation, "invalid user ID"))
+		return
+	}
+
+	// Get pagination parameters
+	page, err := parsePageRequest(r)
+	if err != nil {
+		handleError(w, err)
 		return
 	}
 
 	// Call service
-	resp, err := h.postService.GetTimeline(userID)
+	resp, err := h.postService.GetTimeline(userID, viewerID(r), page)
 	if err != nil {
-		if err.Error() == "user not found" {
-			handleError(w, err, http.StatusNotFound)
-		} else {
-			handleError(w, err, http.StatusInternalServerError)
-		}
+		handleError(w, err)
 		return
 	}
 
@@ -219,9 +484,67 @@ func (h *PostHandler) HandleGetTimeline(w http.ResponseWriter, r *http.Request)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		handleError(w, err, http.StatusInternalServerError)
+		handleError(w, err)
 		return
 	}
 
 	slog.Info("Timeline retrieved", "user_id", userID, "count", resp.Count)
 }
+
+// HandleGetPost handles get post requests. Clients revalidate a post they
+// already have by sending its ETag in If-None-Match.
+func (h *PostHandler) HandleGetPost(w http.ResponseWriter, r *http.Request) {
+	// Get post ID from URL path
+	postIDStr := r.PathValue("postID")
+	postID := 0
+	if _, err := fmt.Sscanf(postIDStr, "%d", &postID); err != nil {
+		handleError(w, services.NewError(services.ErrValidation, "invalid post ID"))
+		return
+	}
+
+	// Call service
+	resp, err := h.postService.GetPost(postID, viewerID(r))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response, or 304 if the client's copy is current
+	if err := writeJSONWithETag(w, r, resp); err != nil {
+		handleError(w, err)
+		return
+	}
+}
+
+// HandleGetThread handles get thread requests
+func (h *PostHandler) HandleGetThread(w http.ResponseWriter, r *http.Request) {
+	// Get post ID from URL path
+	postIDStr := r.PathValue("postID")
+	postID := 0
+	if _, err := fmt.Sscanf(postIDStr, "%d", &postID); err != nil {
+		handleError(w, services.NewError(services.ErrValidation, "invalid post ID"))
+		return
+	}
+
+	// Get pagination parameters
+	page, err := parsePageRequest(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Call service
+	resp, err := h.postService.GetThread(postID, viewerID(r), page)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+}
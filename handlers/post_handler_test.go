@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"python-backend-with-go/models"
+)
+
+func TestPostHandler_Replies(t *testing.T) {
+	env := setupHandlerTest(t)
+
+	rec := env.do(t, http.MethodPost, "/api/posts", 1, map[string]interface{}{"content": "원글"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	rec = env.do(t, http.MethodPost, "/api/posts/1/replies", 2, map[string]interface{}{"content": "답글"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	rec = env.do(t, http.MethodGet, "/api/posts/2/thread", 0, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var thread models.ThreadResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &thread); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(thread.Ancestors) != 1 || thread.Ancestors[0].ID != 1 || thread.Ancestors[0].ReplyCount != 1 {
+		t.Errorf("Expected post 1 with one reply as the ancestor, got %+v", thread.Ancestors)
+	}
+	if thread.Post.InReplyToPostID == nil || *thread.Post.InReplyToPostID != 1 {
+		t.Errorf("Expected post 2 to reply to post 1, got %+v", thread.Post)
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		asUserID       int
+		body           interface{}
+		expectedStatus int
+	}{
+		{
+			name:           "reply without token",
+			method:         http.MethodPost,
+			path:           "/api/posts/1/replies",
+			body:           map[string]interface{}{"content": "익명 답글"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "reply as another user",
+			method:         http.MethodPost,
+			path:           "/api/posts/1/replies",
+			asUserID:       2,
+			body:           map[string]interface{}{"user_id": 1, "content": "사칭 답글"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "reply to missing post",
+			method:         http.MethodPost,
+			path:           "/api/posts/999/replies",
+			asUserID:       2,
+			body:           map[string]interface{}{"content": "답글"},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "thread of missing post",
+			method:         http.MethodGet,
+			path:           "/api/posts/999/thread",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := env.do(t, tt.method, tt.path, tt.asUserID, tt.body)
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestPostHandler_Reposts(t *testing.T) {
+	env := setupHandlerTest(t)
+
+	rec := env.do(t, http.MethodPost, "/api/posts", 1, map[string]interface{}{"content": "원글"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	rec = env.do(t, http.MethodPost, "/api/posts/1/repost", 2, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	var repost models.CreatePostResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &repost); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if repost.Post.Kind != models.PostKindRepost || repost.Post.OriginalPostID == nil || *repost.Post.OriginalPostID != 1 {
+		t.Errorf("Expected a repost of post 1, got %+v", repost.Post)
+	}
+
+	rec = env.do(t, http.MethodPost, "/api/posts/1/quote", 3, map[string]interface{}{"content": "인용"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		asUserID       int
+		body           interface{}
+		expectedStatus int
+	}{
+		{
+			name:           "repost without token",
+			method:         http.MethodPost,
+			path:           "/api/posts/1/repost",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "repost twice",
+			method:         http.MethodPost,
+			path:           "/api/posts/1/repost",
+			asUserID:       2,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "quote as another user",
+			method:         http.MethodPost,
+			path:           "/api/posts/1/quote",
+			asUserID:       3,
+			body:           map[string]interface{}{"user_id": 1, "content": "사칭 인용"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "undo a repost that does not exist",
+			method:         http.MethodDelete,
+			path:           "/api/posts/1/repost",
+			asUserID:       3,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "undo a repost",
+			method:         http.MethodDelete,
+			path:           "/api/posts/1/repost",
+			asUserID:       2,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := env.do(t, tt.method, tt.path, tt.asUserID, tt.body)
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestPostHandler_GetPost_ETag(t *testing.T) {
+	env := setupHandlerTest(t)
+	env.do(t, http.MethodPost, "/api/posts", 1, map[string]interface{}{"content": "게시글"})
+
+	get := func(asUserID int, etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/posts/1", nil)
+		if asUserID != 0 {
+			req.Header.Set("Authorization", "Bearer "+env.tokens[asUserID])
+		}
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		rec := httptest.NewRecorder()
+		env.mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get(0, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var post models.PostWithUser
+	if err := json.Unmarshal(rec.Body.Bytes(), &post); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if post.ID != 1 || post.UserName != "User1" {
+		t.Errorf("Expected post 1 by User1, got %+v", post)
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected an ETag header")
+	}
+
+	// An unchanged post is not sent again
+	rec = get(0, etag)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("Expected an empty %d, got %d: %s", http.StatusNotModified, rec.Code, rec.Body.String())
+	}
+	if rec = get(0, `"other", W/`+etag); rec.Code != http.StatusNotModified {
+		t.Errorf("Expected %d for a weak match in a list, got %d", http.StatusNotModified, rec.Code)
+	}
+
+	// The ETag changes with the engagement and with the viewer
+	env.do(t, http.MethodPost, "/api/posts/1/like", 2, nil)
+	if rec = get(0, etag); rec.Code != http.StatusOK || rec.Header().Get("ETag") == etag {
+		t.Errorf("Expected a new ETag after a like, got %d with %s", rec.Code, rec.Header().Get("ETag"))
+	}
+	anonymous := rec.Header().Get("ETag")
+	if rec = get(2, anonymous); rec.Code != http.StatusOK || rec.Header().Get("ETag") == anonymous {
+		t.Errorf("Expected a different ETag for a viewer who liked the post, got %d", rec.Code)
+	}
+
+	// Deleted posts are not found, whatever the client has cached
+	env.do(t, http.MethodDelete, "/api/posts/1", 1, nil)
+	if rec = get(0, anonymous); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for a deleted post, got %d", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestPostHandler_RestoreAndHistory(t *testing.T) {
+	env := setupHandlerTest(t)
+	env.do(t, http.MethodPost, "/api/posts", 1, map[string]interface{}{"content": "원글"})
+	env.do(t, http.MethodPut, "/api/posts/1", 1, map[string]interface{}{"content": "수정본"})
+
+	rec := env.do(t, http.MethodGet, "/api/posts/1/history", 0, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var history models.PostHistoryResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &history); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if history.Count != 1 || history.Revisions[0].Content != "원글" {
+		t.Errorf("Expected the original content as the only revision, got %+v", history)
+	}
+
+	env.do(t, http.MethodDelete, "/api/posts/1", 1, nil)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		asUserID       int
+		expectedStatus int
+	}{
+		{"history of a deleted post", http.MethodGet, "/api/posts/1/history", 0, http.StatusNotFound},
+		{"restore without token", http.MethodPost, "/api/posts/1/restore", 0, http.StatusUnauthorized},
+		{"restore another user's post", http.MethodPost, "/api/posts/1/restore", 2, http.StatusForbidden},
+		{"restore own post", http.MethodPost, "/api/posts/1/restore", 1, http.StatusOK},
+		{"restore a live post", http.MethodPost, "/api/posts/1/restore", 1, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := env.do(t, tt.method, tt.path, tt.asUserID, nil)
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	// The restored post keeps its edit marker
+	rec = env.do(t, http.MethodGet, "/api/posts/1", 0, nil)
+	var post models.PostWithUser
+	if err := json.Unmarshal(rec.Body.Bytes(), &post); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if post.Content != "수정본" || post.EditedAt == nil {
+		t.Errorf("Expected the edited post back, got %+v", post)
+	}
+}
+
+func TestPostHandler_HashtagsAndMentions(t *testing.T) {
+	env := setupHandlerTest(t)
+	rec := env.do(t, http.MethodPost, "/api/posts", 1, map[string]interface{}{"content": "@User2 #점심 메뉴 추천"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	var created models.CreatePostResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	want := models.PostEntities{
+		Hashtags: []models.HashtagEntity{{Start: 7, End: 10, Tag: "점심"}},
+		Mentions: []models.MentionEntity{{Start: 0, End: 6, UserID: 2, Name: "User2"}},
+	}
+	if !reflect.DeepEqual(created.Post.Entities, want) {
+		t.Errorf("Expected entities %+v, got %+v", want, created.Post.Entities)
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedCount  int
+	}{
+		{"hashtag feed", "/api/hashtags/%EC%A0%90%EC%8B%AC/posts", http.StatusOK, 1}, // 점심
+		{"hashtag feed with #", "/api/hashtags/%23%EC%A0%90%EC%8B%AC/posts", http.StatusOK, 1},
+		{"unused hashtag", "/api/hashtags/dinner/posts", http.StatusOK, 0},
+		{"invalid hashtag", "/api/hashtags/123/posts", http.StatusBadRequest, 0},
+		{"mentions", "/api/users/2/mentions", http.StatusOK, 1},
+		{"no mentions", "/api/users/1/mentions", http.StatusOK, 0},
+		{"mentions of missing user", "/api/users/999/mentions", http.StatusNotFound, 0},
+		{"invalid user ID", "/api/users/abc/mentions", http.StatusBadRequest, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := env.do(t, http.MethodGet, tt.path, 0, nil)
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+			var resp models.TimelineResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if resp.Count != tt.expectedCount {
+				t.Errorf("Expected %d posts, got %d", tt.expectedCount, resp.Count)
+			}
+		})
+	}
+}
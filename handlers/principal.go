@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"python-backend-with-go/services"
+)
+
+// contextKey is an unexported type for request context keys so values set by
+// this package cannot collide with (or be forged by) other packages
+type contextKey int
+
+const (
+	principalKey contextKey = iota
+	requestIDKey
+	bodyLimitKey
+)
+
+// Principal identifies the authenticated user making a request
+type Principal struct {
+	UserID    int
+	Email     string
+	SessionID string
+}
+
+// WithPrincipal returns a copy of ctx carrying the authenticated principal
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey, p)
+}
+
+// CurrentUser returns the authenticated principal stored in ctx by AuthMiddleware
+func CurrentUser(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey).(Principal)
+	if !ok || p.UserID == 0 {
+		return Principal{}, false
+	}
+	return p, true
+}
+
+// RequestID returns the request ID stored in ctx by LoggingMiddleware
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// actingUser resolves the user a protected handler acts as. The identity always
+// comes from the JWT; a body-supplied ID is accepted only if it matches.
+func actingUser(w http.ResponseWriter, r *http.Request, bodyUserID int) (int, bool) {
+	principal, ok := CurrentUser(r.Context())
+	if !ok {
+		handleError(w, services.NewError(services.ErrUnauthenticated, "authentication required"))
+		return 0, false
+	}
+
+	if bodyUserID != 0 && bodyUserID != principal.UserID {
+		handleError(w, services.NewError(services.ErrForbidden, "cannot act on behalf of another user"))
+		return 0, false
+	}
+
+	return principal.UserID, true
+}
+
+// viewerID returns the authenticated user viewing a public resource, or 0 for
+// an anonymous request
+func viewerID(r *http.Request) int {
+	principal, _ := CurrentUser(r.Context())
+	return principal.UserID
+}
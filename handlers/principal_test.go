@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"python-backend-with-go/models"
+)
+
+func TestCurrentUser(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if _, ok := CurrentUser(req.Context()); ok {
+		t.Error("Expected no principal on a bare request")
+	}
+
+	ctx := WithPrincipal(req.Context(), Principal{UserID: 7, Email: "seven@test.com"})
+	principal, ok := CurrentUser(ctx)
+	if !ok {
+		t.Fatal("Expected principal, got none")
+	}
+	if principal.UserID != 7 || principal.Email != "seven@test.com" {
+		t.Errorf("Unexpected principal: %+v", principal)
+	}
+}
+
+func TestPostHandler_Impersonation(t *testing.T) {
+	env := setupHandlerTest(t)
+
+	// User 1 creates a post without naming themselves in the body
+	rec := env.do(t, http.MethodPost, "/api/posts", 1, map[string]interface{}{"content": "내 게시글"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	var created models.CreatePostResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if created.Post.UserID != 1 {
+		t.Errorf("Expected post author 1, got %d", created.Post.UserID)
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		asUserID       int
+		body           interface{}
+		expectedStatus int
+	}{
+		{
+			name:           "create post as another user",
+			method:         http.MethodPost,
+			path:           "/api/posts",
+			asUserID:       2,
+			body:           map[string]interface{}{"user_id": 1, "content": "사칭 게시글"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "update post claiming to be the owner",
+			method:         http.MethodPut,
+			path:           "/api/posts/1",
+			asUserID:       2,
+			body:           map[string]interface{}{"user_id": 1, "content": "사칭 수정"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "update someone else's post",
+			method:         http.MethodPut,
+			path:           "/api/posts/1",
+			asUserID:       2,
+			body:           map[string]interface{}{"content": "남의 글 수정"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "delete post claiming to be the owner",
+			method:         http.MethodDelete,
+			path:           "/api/posts/1",
+			asUserID:       2,
+			body:           map[string]interface{}{"user_id": 1},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "delete someone else's post",
+			method:         http.MethodDelete,
+			path:           "/api/posts/1",
+			asUserID:       2,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "create post without token",
+			method:         http.MethodPost,
+			path:           "/api/posts",
+			body:           map[string]interface{}{"user_id": 1, "content": "익명 게시글"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := env.do(t, tt.method, tt.path, tt.asUserID, tt.body)
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	// The original post must be untouched
+	post, err := env.postRepo.GetByID(created.PostID)
+	if err != nil {
+		t.Fatalf("Expected post to still exist: %v", err)
+	}
+	if post.Content != "내 게시글" {
+		t.Errorf("Expected content '내 게시글', got '%s'", post.Content)
+	}
+
+	// The owner can still delete with no body at all
+	rec = env.do(t, http.MethodDelete, "/api/posts/1", 1, nil)
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+}
+
+func TestFollowHandler_Impersonation(t *testing.T) {
+	env := setupHandlerTest(t)
+
+	// User 2 tries to make user 1 follow user 3
+	rec := env.do(t, http.MethodPost, "/api/users/3/follow", 2, map[string]interface{}{"follower_id": 1})
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+	}
+
+	// User 1 follows user 3 using only the token
+	rec = env.do(t, http.MethodPost, "/api/users/3/follow", 1, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	var resp models.FollowResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if resp.FollowerID != 1 || resp.FollowingID != 3 {
+		t.Errorf("Expected follower_id=1, following_id=3, got follower_id=%d, following_id=%d",
+			resp.FollowerID, resp.FollowingID)
+	}
+
+	// User 2 tries to unfollow on behalf of user 1
+	rec = env.do(t, http.MethodDelete, "/api/users/3/follow", 2, map[string]interface{}{"follower_id": 1})
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+	}
+
+	// A matching follower_id is accepted
+	rec = env.do(t, http.MethodDelete, "/api/users/3/follow", 1, map[string]interface{}{"follower_id": 1})
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+}
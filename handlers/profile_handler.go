@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/services"
+)
+
+// ProfileHandler handles user profile HTTP requests
+type ProfileHandler struct {
+	profileService *services.ProfileService
+}
+
+// NewProfileHandler creates a new profile handler
+func NewProfileHandler(profileService *services.ProfileService) *ProfileHandler {
+	return &ProfileHandler{
+		profileService: profileService,
+	}
+}
+
+// HandleGetUser handles getting a user's public profile
+func (h *ProfileHandler) HandleGetUser(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from URL path
+	userIDStr := r.PathValue("userID")
+	userID := 0
+	if _, err := fmt.Sscanf(userIDStr, "%d", &userID); err != nil {
+		handleError(w, services.NewError(services.ErrValidation, "invalid user ID"))
+		return
+	}
+
+	// Call service
+	resp, err := h.profileService.GetProfile(userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+}
+
+// HandleGetMe handles getting the authenticated user's own profile
+func (h *ProfileHandler) HandleGetMe(w http.ResponseWriter, r *http.Request) {
+	userID, ok := actingUser(w, r, 0)
+	if !ok {
+		return
+	}
+
+	// Call service
+	resp, err := h.profileService.GetMyProfile(userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+}
+
+// HandleUpdateMe handles updating the authenticated user's name and profile
+func (h *ProfileHandler) HandleUpdateMe(w http.ResponseWriter, r *http.Request) {
+	var req models.UpdateProfileRequest
+
+	// Decode request body
+	if err := decodeJSON(w, r, &req); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	userID, ok := actingUser(w, r, 0)
+	if !ok {
+		return
+	}
+
+	// Call service
+	resp, err := h.profileService.UpdateProfile(userID, req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("Profile updated", "user_id", userID)
+}
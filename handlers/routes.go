@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"python-backend-with-go/repository"
+	"python-backend-with-go/services"
+)
+
+// Services are the services the API routes call
+type Services struct {
+	User         *services.UserService
+	Auth         *services.AuthService
+	Follow       *services.FollowService
+	Post         *services.PostService
+	Like         *services.LikeService
+	Profile      *services.ProfileService
+	Password     *services.PasswordService
+	Verification *services.EmailVerificationService
+	Search       *services.SearchService
+	Trend        *services.TrendService
+	Notification *services.NotificationService
+}
+
+// NewRouter creates a ServeMux with every API route registered, rate limited
+// with buckets kept in rateLimitStore
+func NewRouter(svc Services, rateLimitStore repository.RateLimitStore) *http.ServeMux {
+	// Initialize handlers
+	userHandler := NewUserHandler(svc.User)
+	authHandler := NewAuthHandler(svc.Auth)
+	followHandler := NewFollowHandler(svc.Follow)
+	postHandler := NewPostHandler(svc.Post)
+	likeHandler := NewLikeHandler(svc.Like)
+	profileHandler := NewProfileHandler(svc.Profile)
+	passwordHandler := NewPasswordHandler(svc.Password)
+	verificationHandler := NewVerificationHandler(svc.Verification)
+	searchHandler := NewSearchHandler(svc.Search)
+	trendHandler := NewTrendHandler(svc.Trend)
+	notificationHandler := NewNotificationHandler(svc.Notification)
+
+	// Rate limits per route: anonymous routes are keyed by client IP,
+	// protected routes (wrapped inside authMiddleware) by user ID
+	signupLimit := RateLimitMiddleware(rateLimitStore, "signup", repository.RateLimit{Requests: 5, Per: time.Hour})
+	loginLimit := RateLimitMiddleware(rateLimitStore, "login", repository.RateLimit{Requests: 10, Per: time.Minute})
+	refreshLimit := RateLimitMiddleware(rateLimitStore, "refresh", repository.RateLimit{Requests: 30, Per: time.Minute})
+	passwordResetLimit := RateLimitMiddleware(rateLimitStore, "password-reset", repository.RateLimit{Requests: 5, Per: time.Hour})
+	verifyResendLimit := RateLimitMiddleware(rateLimitStore, "verify-resend", repository.RateLimit{Requests: 5, Per: time.Hour})
+	writeLimit := RateLimitMiddleware(rateLimitStore, "write", repository.RateLimit{Requests: 60, Per: time.Minute})
+
+	// Create new ServeMux (Go 1.22+ with enhanced routing)
+	mux := http.NewServeMux()
+
+	// Register routes with method-specific handlers
+	// Public routes
+	mux.HandleFunc("GET /", HandleRoot)
+	mux.HandleFunc("GET /health", HandleHealth)
+	mux.HandleFunc("GET /api/hello", HandleAPIHello)
+	mux.Handle("POST /api/signup", signupLimit(http.HandlerFunc(userHandler.HandleSignup)))
+	mux.Handle("POST /api/login", loginLimit(http.HandlerFunc(authHandler.HandleLogin)))
+	mux.Handle("POST /api/token/refresh", refreshLimit(http.HandlerFunc(authHandler.HandleRefreshToken)))
+	mux.Handle("POST /api/password/forgot", passwordResetLimit(http.HandlerFunc(passwordHandler.HandleForgotPassword)))
+	mux.Handle("POST /api/password/reset", passwordResetLimit(http.HandlerFunc(passwordHandler.HandleResetPassword)))
+	mux.HandleFunc("POST /api/verify-email", verificationHandler.HandleVerifyEmail)
+	mux.Handle("POST /api/verify-email/resend", verifyResendLimit(http.HandlerFunc(verificationHandler.HandleResendVerification)))
+
+	// Protected routes (require authentication)
+	authMiddleware := AuthMiddleware(svc.Auth)
+
+	// Public routes that personalize their response for a signed-in viewer
+	optionalAuth := OptionalAuthMiddleware(svc.Auth)
+
+	// Session routes
+	mux.Handle("POST /api/logout", authMiddleware(http.HandlerFunc(authHandler.HandleLogout)))
+	mux.Handle("POST /api/logout/all", authMiddleware(http.HandlerFunc(authHandler.HandleLogoutAll)))
+
+	// Profile routes
+	mux.HandleFunc("GET /api/users/{userID}", profileHandler.HandleGetUser)
+	mux.Handle("GET /api/me", authMiddleware(http.HandlerFunc(profileHandler.HandleGetMe)))
+	mux.Handle("PATCH /api/me", authMiddleware(writeLimit(http.HandlerFunc(profileHandler.HandleUpdateMe))))
+	mux.Handle("POST /api/me/password", authMiddleware(writeLimit(http.HandlerFunc(passwordHandler.HandleChangePassword))))
+
+	// Follow/Unfollow routes
+	mux.Handle("POST /api/users/{userID}/follow", authMiddleware(writeLimit(http.HandlerFunc(followHandler.HandleFollow))))
+	mux.Handle("DELETE /api/users/{userID}/follow", authMiddleware(writeLimit(http.HandlerFunc(followHandler.HandleUnfollow))))
+	mux.HandleFunc("GET /api/users/{userID}/followers", followHandler.HandleGetFollowers)
+	mux.HandleFunc("GET /api/users/{userID}/following", followHandler.HandleGetFollowing)
+	mux.HandleFunc("GET /api/users/{userID}/follow-status", followHandler.HandleGetFollowStatus)
+
+	// Post routes
+	mux.Handle("POST /api/posts", authMiddleware(writeLimit(http.HandlerFunc(postHandler.HandleCreatePost))))
+	mux.Handle("GET /api/posts/{postID}", optionalAuth(http.HandlerFunc(postHandler.HandleGetPost)))
+	mux.Handle("POST /api/posts/{postID}/replies", authMiddleware(writeLimit(http.HandlerFunc(postHandler.HandleCreateReply))))
+	mux.Handle("GET /api/posts/{postID}/thread", optionalAuth(http.HandlerFunc(postHandler.HandleGetThread)))
+	mux.Handle("POST /api/posts/{postID}/quote", authMiddleware(writeLimit(http.HandlerFunc(postHandler.HandleQuotePost))))
+	mux.Handle("POST /api/posts/{postID}/repost", authMiddleware(writeLimit(http.HandlerFunc(postHandler.HandleRepost))))
+	mux.Handle("DELETE /api/posts/{postID}/repost", authMiddleware(writeLimit(http.HandlerFunc(postHandler.HandleUndoRepost))))
+	mux.Handle("PUT /api/posts/{postID}", authMiddleware(writeLimit(http.HandlerFunc(postHandler.HandleUpdatePost))))
+	mux.Handle("DELETE /api/posts/{postID}", authMiddleware(writeLimit(http.HandlerFunc(postHandler.HandleDeletePost))))
+	mux.Handle("POST /api/posts/{postID}/restore", authMiddleware(writeLimit(http.HandlerFunc(postHandler.HandleRestorePost))))
+	mux.HandleFunc("GET /api/posts/{postID}/history", postHandler.HandleGetPostHistory)
+	mux.Handle("GET /api/users/{userID}/posts", optionalAuth(http.HandlerFunc(postHandler.HandleGetUserPosts)))
+	mux.Handle("GET /api/users/{userID}/timeline", optionalAuth(http.HandlerFunc(postHandler.HandleGetTimeline)))
+	mux.Handle("GET /api/users/{userID}/mentions", optionalAuth(http.HandlerFunc(postHandler.HandleGetMentions)))
+	mux.Handle("GET /api/hashtags/{tag}/posts", optionalAuth(http.HandlerFunc(postHandler.HandleGetHashtagPosts)))
+
+	// Like routes
+	mux.Handle("POST /api/posts/{postID}/like", authMiddleware(writeLimit(http.HandlerFunc(likeHandler.HandleLike))))
+	mux.Handle("DELETE /api/posts/{postID}/like", authMiddleware(writeLimit(http.HandlerFunc(likeHandler.HandleUnlike))))
+	mux.HandleFunc("GET /api/posts/{postID}/likes", likeHandler.HandleGetLikes)
+
+	// Search routes
+	mux.Handle("GET /api/search/posts", optionalAuth(http.HandlerFunc(searchHandler.HandleSearchPosts)))
+	mux.HandleFunc("GET /api/search/users", searchHandler.HandleSearchUsers)
+
+	// Trend routes
+	mux.HandleFunc("GET /api/trends", trendHandler.HandleGetTrends)
+
+	// Notification routes
+	mux.Handle("GET /api/notifications", authMiddleware(http.HandlerFunc(notificationHandler.HandleGetNotifications)))
+	mux.Handle("POST /api/notifications/read", authMiddleware(writeLimit(http.HandlerFunc(notificationHandler.HandleMarkRead))))
+
+	return mux
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+	"python-backend-with-go/services"
+)
+
+type handlerTestEnv struct {
+	mux      *http.ServeMux
+	postRepo *repository.InMemoryPostRepository
+	trends   *services.TrendService
+	tokens   map[int]string
+}
+
+// setupHandlerTest serves the routes of NewRouter over in-memory repositories
+// and signs in users 1..3
+func setupHandlerTest(t *testing.T) *handlerTestEnv {
+	t.Helper()
+	os.Setenv("JWT_SECRET", "test_secret_key_for_testing")
+	t.Cleanup(func() { os.Unsetenv("JWT_SECRET") })
+
+	userRepo := repository.NewInMemoryUserRepository()
+	followRepo := repository.NewInMemoryFollowRepository()
+	postRepo := repository.NewInMemoryPostRepository()
+	likeRepo := repository.NewInMemoryLikeRepository()
+	rateLimitStore := repository.NewInMemoryRateLimitStore()
+
+	verificationService := services.NewEmailVerificationService(userRepo, services.LogMailer{}, rateLimitStore)
+	searchService := services.NewSearchService(repository.NewInMemorySearchIndex(), repository.NewInMemorySearchIndex(), postRepo, userRepo, likeRepo)
+	trendService := services.NewTrendService(repository.NewInMemoryTrendStore(), postRepo)
+	notificationService := services.NewNotificationService(repository.NewInMemoryNotificationRepository(), postRepo, userRepo)
+	userService := services.NewUserService(userRepo, verificationService, searchService)
+	authService := services.NewAuthService(userRepo, repository.NewInMemorySessionRepository(), repository.NewInMemoryLoginAttemptStore())
+	timelineService := services.NewTimelineService(repository.NewInMemoryTimelineStore(), postRepo, userRepo, followRepo)
+
+	mux := NewRouter(Services{
+		User:         userService,
+		Auth:         authService,
+		Follow:       services.NewFollowService(followRepo, userRepo, timelineService, notificationService),
+		Post:         services.NewPostService(postRepo, userRepo, followRepo, likeRepo, timelineService, searchService, trendService, notificationService),
+		Like:         services.NewLikeService(likeRepo, postRepo, userRepo, notificationService),
+		Profile:      services.NewProfileService(userRepo, followRepo, postRepo, searchService),
+		Password:     services.NewPasswordService(userRepo, repository.NewInMemoryPasswordResetRepository(), authService, services.LogMailer{}),
+		Verification: verificationService,
+		Search:       searchService,
+		Trend:        trendService,
+		Notification: notificationService,
+	}, rateLimitStore)
+
+	// Create test users and log them in
+	tokens := make(map[int]string)
+	for i := 1; i <= 3; i++ {
+		email := "user" + string(rune('0'+i)) + "@test.com"
+		if _, err := userService.Signup(models.SignupRequest{
+			Name:     "User" + string(rune('0'+i)),
+			Email:    email,
+			Password: "password123",
+		}); err != nil {
+			t.Fatalf("Failed to create test user: %v", err)
+		}
+		loginResp, err := authService.Login(models.LoginRequest{Email: email, Password: "password123"})
+		if err != nil {
+			t.Fatalf("Failed to login: %v", err)
+		}
+		tokens[loginResp.UserID] = loginResp.AccessToken
+	}
+
+	return &handlerTestEnv{mux: mux, postRepo: postRepo, trends: trendService, tokens: tokens}
+}
+
+func (env *handlerTestEnv) do(t *testing.T, method, path string, asUserID int, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatalf("Failed to encode request body: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if asUserID != 0 {
+		req.Header.Set("Authorization", "Bearer "+env.tokens[asUserID])
+	}
+
+	rec := httptest.NewRecorder()
+	env.mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewRouter_RateLimitsWrites(t *testing.T) {
+	env := setupHandlerTest(t)
+
+	// Writes are limited per user; reads and other users are not affected
+	status := 0
+	for i := 0; i < 61 && status != http.StatusTooManyRequests; i++ {
+		status = env.do(t, http.MethodPost, "/api/posts", 1, map[string]interface{}{"content": "글"}).Code
+	}
+	if status != http.StatusTooManyRequests {
+		t.Fatalf("Expected status %d after 60 writes, got %d", http.StatusTooManyRequests, status)
+	}
+	if rec := env.do(t, http.MethodGet, "/api/users/1/posts", 1, nil); rec.Code != http.StatusOK {
+		t.Errorf("Expected reads to stay allowed, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := env.do(t, http.MethodPost, "/api/posts", 2, map[string]interface{}{"content": "글"}); rec.Code != http.StatusCreated {
+		t.Errorf("Expected another user's write to be allowed, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
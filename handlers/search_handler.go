@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"python-backend-with-go/services"
+)
+
+// SearchHandler handles search HTTP requests
+type SearchHandler struct {
+	searchService *services.SearchService
+}
+
+// NewSearchHandler creates a new search handler
+func NewSearchHandler(searchService *services.SearchService) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+// HandleSearchPosts handles post search requests (?q=)
+func (h *SearchHandler) HandleSearchPosts(w http.ResponseWriter, r *http.Request) {
+	// Get pagination parameters
+	page, err := parsePageRequest(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Call service
+	resp, err := h.searchService.SearchPosts(r.URL.Query().Get("q"), viewerID(r), page)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+}
+
+// HandleSearchUsers handles user search requests (?q=)
+func (h *SearchHandler) HandleSearchUsers(w http.ResponseWriter, r *http.Request) {
+	// Get pagination parameters
+	page, err := parsePageRequest(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Call service
+	resp, err := h.searchService.SearchUsers(r.URL.Query().Get("q"), page)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"python-backend-with-go/models"
+)
+
+func TestSearchHandler(t *testing.T) {
+	env := setupHandlerTest(t)
+	env.do(t, http.MethodPost, "/api/posts", 1, map[string]interface{}{"content": "오늘 점심은 김치찌개"})
+	env.do(t, http.MethodPost, "/api/posts", 2, map[string]interface{}{"content": "저녁은 된장찌개를 먹었다"})
+	env.do(t, http.MethodPost, "/api/posts/1/like", 2, nil)
+
+	rec := env.do(t, http.MethodGet, "/api/search/posts?q=%EA%B9%80%EC%B9%98%EC%B0%8C%EA%B0%9C", 2, nil) // 김치찌개
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var posts models.PostSearchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &posts); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if posts.Count != 1 || posts.Posts[0].ID != 1 || !posts.Posts[0].LikedByMe || posts.Posts[0].UserName != "User1" {
+		t.Errorf("Expected post 1 by User1 liked by the viewer, got %+v", posts.Posts)
+	}
+
+	rec = env.do(t, http.MethodGet, "/api/search/users?q=user2", 0, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var users models.UserSearchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &users); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if users.Count != 1 || users.Users[0].ID != 2 {
+		t.Errorf("Expected only User2, got %+v", users.Users)
+	}
+	// User search is public, so it must not reveal email addresses
+	if body := rec.Body.String(); strings.Contains(body, `"email"`) || strings.Contains(body, "@test.com") {
+		t.Errorf("Expected no email in the user search response, got %s", body)
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{"missing query", "/api/search/posts", http.StatusUnprocessableEntity},
+		{"blank query", "/api/search/users?q=%20", http.StatusUnprocessableEntity},
+		{"invalid cursor", "/api/search/posts?q=a&cursor=nope", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := env.do(t, http.MethodGet, tt.path, 0, nil)
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
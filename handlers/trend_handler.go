@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"python-backend-with-go/services"
+)
+
+// TrendHandler handles trending hashtag HTTP requests
+type TrendHandler struct {
+	trendService *services.TrendService
+}
+
+// NewTrendHandler creates a new trend handler
+func NewTrendHandler(trendService *services.TrendService) *TrendHandler {
+	return &TrendHandler{
+		trendService: trendService,
+	}
+}
+
+// HandleGetTrends handles trending hashtag requests
+func (h *TrendHandler) HandleGetTrends(w http.ResponseWriter, r *http.Request) {
+	// The ranking is recomputed in the background; serve the latest one
+	resp := h.trendService.GetTrends()
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("Trends retrieved", "count", resp.Count)
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/services"
+)
+
+func TestTrendHandler(t *testing.T) {
+	env := setupHandlerTest(t)
+	for i := 0; i < services.MinTrendUses; i++ {
+		env.do(t, http.MethodPost, "/api/posts", 1, map[string]interface{}{"content": "#GoLang 최고"})
+	}
+
+	// Trends are only recomputed in the background, so none show yet
+	rec := env.do(t, http.MethodGet, "/api/trends", 0, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var resp models.TrendsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if resp.Count != 0 || resp.Trends == nil {
+		t.Errorf("Expected an empty trend list before the first refresh, got %s", rec.Body.String())
+	}
+
+	if err := env.trends.Refresh(); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	rec = env.do(t, http.MethodGet, "/api/trends", 0, nil)
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if resp.Count != 1 || resp.Trends[0].Tag != "golang" || resp.Trends[0].HourCount != services.MinTrendUses {
+		t.Errorf("Expected golang to trend, got %+v", resp.Trends)
+	}
+}
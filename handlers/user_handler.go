@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -27,22 +28,15 @@ func (h *UserHandler) HandleSignup(w http.ResponseWriter, r *http.Request) {
 	var req models.SignupRequest
 
 	// Decode request body
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		handleError(w, fmt.Errorf("invalid request body"), http.StatusBadRequest)
+	if err := decodeJSON(w, r, &req); err != nil {
+		handleError(w, err)
 		return
 	}
 
 	// Call service
 	resp, err := h.userService.Signup(req)
 	if err != nil {
-		switch err.Error() {
-		case "name, email, and password are required":
-			handleError(w, err, http.StatusBadRequest)
-		case "email already exists":
-			handleError(w, err, http.StatusConflict)
-		default:
-			handleError(w, err, http.StatusInternalServerError)
-		}
+		handleError(w, err)
 		return
 	}
 
@@ -50,7 +44,7 @@ func (h *UserHandler) HandleSignup(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		handleError(w, err, http.StatusInternalServerError)
+		handleError(w, err)
 		return
 	}
 
@@ -79,20 +73,64 @@ func HandleAPIHello(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		handleError(w, err, http.StatusInternalServerError)
+		handleError(w, err)
 		return
 	}
 }
 
-// handleError sends an error response
-func handleError(w http.ResponseWriter, err error, statusCode int) {
+// handleError sends an error response, deriving the status code and error code
+// from the kind of domain error
+func handleError(w http.ResponseWriter, err error) {
+	statusCode, code := errorStatus(err)
+	if statusCode == http.StatusInternalServerError {
+		slog.Error("Request failed", "error", err)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 
 	errorResponse := models.ErrorResponse{
 		Error:   http.StatusText(statusCode),
+		Code:    code,
 		Message: err.Error(),
+		Fields:  invalidFields(err),
 	}
 
 	json.NewEncoder(w).Encode(errorResponse)
 }
+
+// errorStatus maps a domain error kind to an HTTP status and machine-readable code.
+// Requests with invalid fields are well-formed but unprocessable (422); other
+// validation failures, such as an unparsable body or path, are bad requests (400).
+func errorStatus(err error) (int, string) {
+	var reqErr *requestError
+	switch {
+	case errors.As(err, &reqErr):
+		return reqErr.status, reqErr.code
+	case errors.Is(err, services.ErrValidation) && invalidFields(err) != nil:
+		return http.StatusUnprocessableEntity, "validation_failed"
+	case errors.Is(err, services.ErrValidation):
+		return http.StatusBadRequest, "validation_failed"
+	case errors.Is(err, services.ErrUnauthenticated):
+		return http.StatusUnauthorized, "unauthenticated"
+	case errors.Is(err, services.ErrForbidden):
+		return http.StatusForbidden, "forbidden"
+	case errors.Is(err, services.ErrNotFound):
+		return http.StatusNotFound, "not_found"
+	case errors.Is(err, services.ErrConflict):
+		return http.StatusConflict, "conflict"
+	case errors.Is(err, services.ErrRateLimited):
+		return http.StatusTooManyRequests, "rate_limited"
+	default:
+		return http.StatusInternalServerError, "internal_error"
+	}
+}
+
+// invalidFields returns the field-level errors of a validation error, if any
+func invalidFields(err error) map[string]string {
+	var domainErr *services.Error
+	if errors.As(err, &domainErr) && len(domainErr.Fields) > 0 {
+		return domainErr.Fields
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+	"python-backend-with-go/services"
+)
+
+func TestHandleError_InvalidFields(t *testing.T) {
+	env := setupHandlerTest(t)
+
+	rec := env.do(t, http.MethodPost, "/api/posts", 1, map[string]interface{}{"content": "   "})
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	}
+
+	var resp models.ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if resp.Code != "validation_failed" {
+		t.Errorf("Expected code 'validation_failed', got '%s'", resp.Code)
+	}
+	if resp.Fields["content"] != "must not be blank" || len(resp.Fields) != 1 {
+		t.Errorf("Expected fields {content: must not be blank}, got %v", resp.Fields)
+	}
+}
+
+func TestHandleError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "validation",
+			err:            services.NewError(services.ErrValidation, "content is required"),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:           "unauthenticated",
+			err:            services.NewError(services.ErrUnauthenticated, "invalid email or password"),
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   "unauthenticated",
+		},
+		{
+			name:           "forbidden",
+			err:            services.NewError(services.ErrForbidden, "unauthorized to delete this post"),
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "forbidden",
+		},
+		{
+			name:           "repository not found",
+			err:            repository.ErrPostNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "not_found",
+		},
+		{
+			name:           "wrapped not found",
+			err:            fmt.Errorf("failed to update post: %w", repository.ErrPostNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "not_found",
+		},
+		{
+			name:           "conflict",
+			err:            services.NewError(services.ErrConflict, "email already exists"),
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "conflict",
+		},
+		{
+			name:           "rate limited",
+			err:            services.NewError(services.ErrRateLimited, "too many requests"),
+			expectedStatus: http.StatusTooManyRequests,
+			expectedCode:   "rate_limited",
+		},
+		{
+			name:           "unexpected",
+			err:            errors.New("connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "internal_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleError(rec, tt.err)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			var resp models.ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if resp.Code != tt.expectedCode {
+				t.Errorf("Expected code '%s', got '%s'", tt.expectedCode, resp.Code)
+			}
+			if resp.Message != tt.err.Error() {
+				t.Errorf("Expected message '%s', got '%s'", tt.err.Error(), resp.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/services"
+)
+
+// VerificationHandler handles email verification HTTP requests
+type VerificationHandler struct {
+	verificationService *services.EmailVerificationService
+}
+
+// NewVerificationHandler creates a new email verification handler
+func NewVerificationHandler(verificationService *services.EmailVerificationService) *VerificationHandler {
+	return &VerificationHandler{
+		verificationService: verificationService,
+	}
+}
+
+// HandleVerifyEmail handles verifying an email address with a verification token
+func (h *VerificationHandler) HandleVerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req models.VerifyEmailRequest
+
+	// Decode request body
+	if err := decodeJSON(w, r, &req); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Call service
+	resp, err := h.verificationService.Verify(req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	slog.Info("Email verified")
+}
+
+// HandleResendVerification handles sending a new verification email
+func (h *VerificationHandler) HandleResendVerification(w http.ResponseWriter, r *http.Request) {
+	var req models.ResendVerificationRequest
+
+	// Decode request body
+	if err := decodeJSON(w, r, &req); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Call service
+	resp, err := h.verificationService.ResendVerification(req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Return success response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		handleError(w, err)
+		return
+	}
+}
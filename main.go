@@ -2,10 +2,12 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -35,6 +37,16 @@ func main() {
 	}
 	defer db.CloseDatabase()
 
+	// Run "migrate" subcommand instead of the server
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			slog.Error("Migration failed", "error", err)
+			db.CloseDatabase()
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Get port from environment variable (default: 8080)
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -45,52 +57,100 @@ func main() {
 	userRepo := repository.NewGormUserRepository(db.DB)
 	followRepo := repository.NewGormFollowRepository(db.DB)
 	postRepo := repository.NewGormPostRepository(db.DB)
+	sessionRepo := repository.NewGormSessionRepository(db.DB)
+	resetRepo := repository.NewGormPasswordResetRepository(db.DB)
+	likeRepo := repository.NewGormLikeRepository(db.DB)
+	notificationRepo := repository.NewGormNotificationRepository(db.DB)
+
+	// Initialize timeline cache (in-process; swap for a shared store when running multiple instances)
+	timelineStore := repository.NewInMemoryTimelineStore()
+
+	// Initialize search indexes (in-process, rebuilt from the database below)
+	postIndex := repository.NewInMemorySearchIndex()
+	userIndex := repository.NewInMemorySearchIndex()
+
+	// Initialize hashtag counts for trends (in-process, reloaded from the database below)
+	trendStore := repository.NewInMemoryTrendStore()
+
+	// Initialize rate limiter and login throttling state (in-process, like the timeline cache)
+	rateLimitStore := repository.NewInMemoryRateLimitStore()
+	loginAttemptStore := repository.NewInMemoryLoginAttemptStore()
+
+	// Initialize mailer (MAIL_FILE appends outgoing mail to a file; otherwise it is only logged)
+	var mailer services.Mailer = services.LogMailer{}
+	if mailFile := os.Getenv("MAIL_FILE"); mailFile != "" {
+		mailer = services.NewFileMailer(mailFile)
+	}
+
+	// What unverified accounts may do; refuse to start with a typo in the setting
+	verificationPolicy, err := services.EmailVerificationPolicyFromEnv()
+	if err != nil {
+		slog.Error("Invalid configuration", "error", err)
+		db.CloseDatabase()
+		os.Exit(1)
+	}
+	slog.Info("Email verification policy", "policy", verificationPolicy)
 
 	// Initialize services
-	userService := services.NewUserService(userRepo)
-	authService := services.NewAuthService(userRepo)
-	followService := services.NewFollowService(followRepo, userRepo)
-	postService := services.NewPostService(postRepo, userRepo, followRepo)
-
-	// Initialize handlers
-	userHandler := handlers.NewUserHandler(userService)
-	authHandler := handlers.NewAuthHandler(authService)
-	followHandler := handlers.NewFollowHandler(followService)
-	postHandler := handlers.NewPostHandler(postService)
-
-	// Create new ServeMux (Go 1.22+ with enhanced routing)
-	mux := http.NewServeMux()
-
-	// Register routes with method-specific handlers
-	// Public routes
-	mux.HandleFunc("GET /", handlers.HandleRoot)
-	mux.HandleFunc("GET /health", handlers.HandleHealth)
-	mux.HandleFunc("GET /api/hello", handlers.HandleAPIHello)
-	mux.HandleFunc("POST /api/signup", userHandler.HandleSignup)
-	mux.HandleFunc("POST /api/login", authHandler.HandleLogin)
-
-	// Protected routes (require authentication)
-	authMiddleware := handlers.AuthMiddleware(authService)
-
-	// Follow/Unfollow routes
-	mux.Handle("POST /api/users/{userID}/follow", authMiddleware(http.HandlerFunc(followHandler.HandleFollow)))
-	mux.Handle("DELETE /api/users/{userID}/follow", authMiddleware(http.HandlerFunc(followHandler.HandleUnfollow)))
-	mux.HandleFunc("GET /api/users/{userID}/followers", followHandler.HandleGetFollowers)
-	mux.HandleFunc("GET /api/users/{userID}/following", followHandler.HandleGetFollowing)
-	mux.HandleFunc("GET /api/users/{userID}/follow-status", followHandler.HandleGetFollowStatus)
-
-	// Post routes
-	mux.Handle("POST /api/posts", authMiddleware(http.HandlerFunc(postHandler.HandleCreatePost)))
-	mux.Handle("PUT /api/posts/{postID}", authMiddleware(http.HandlerFunc(postHandler.HandleUpdatePost)))
-	mux.Handle("DELETE /api/posts/{postID}", authMiddleware(http.HandlerFunc(postHandler.HandleDeletePost)))
-	mux.HandleFunc("GET /api/users/{userID}/posts", postHandler.HandleGetUserPosts)
-	mux.HandleFunc("GET /api/users/{userID}/timeline", postHandler.HandleGetTimeline)
+	verificationService := services.NewEmailVerificationService(userRepo, mailer, rateLimitStore)
+	searchService := services.NewSearchService(postIndex, userIndex, postRepo, userRepo, likeRepo)
+	trendService := services.NewTrendService(trendStore, postRepo)
+	notificationService := services.NewNotificationService(notificationRepo, postRepo, userRepo)
+	userService := services.NewUserService(userRepo, verificationService, searchService)
+	authService := services.NewAuthService(userRepo, sessionRepo, loginAttemptStore)
+	timelineService := services.NewTimelineService(timelineStore, postRepo, userRepo, followRepo)
+	followService := services.NewFollowService(followRepo, userRepo, timelineService, notificationService)
+	postService := services.NewPostService(postRepo, userRepo, followRepo, likeRepo, timelineService, searchService, trendService, notificationService)
+	likeService := services.NewLikeService(likeRepo, postRepo, userRepo, notificationService)
+	profileService := services.NewProfileService(userRepo, followRepo, postRepo, searchService)
+	passwordService := services.NewPasswordService(userRepo, resetRepo, authService, mailer)
+
+	// Build the search indexes before serving requests
+	if err := searchService.Rebuild(); err != nil {
+		slog.Error("Failed to build search index", "error", err)
+		db.CloseDatabase()
+		os.Exit(1)
+	}
+	if err := trendService.Load(); err != nil {
+		slog.Error("Failed to load trends", "error", err)
+		db.CloseDatabase()
+		os.Exit(1)
+	}
+
+	// Register every API route
+	mux := handlers.NewRouter(handlers.Services{
+		User:         userService,
+		Auth:         authService,
+		Follow:       followService,
+		Post:         postService,
+		Like:         likeService,
+		Profile:      profileService,
+		Password:     passwordService,
+		Verification: verificationService,
+		Search:       searchService,
+		Trend:        trendService,
+		Notification: notificationService,
+	}, rateLimitStore)
+
+	// Request body limit (MAX_BODY_BYTES, default 64 KiB)
+	maxBodyBytes := handlers.DefaultMaxBodyBytes
+	if value := os.Getenv("MAX_BODY_BYTES"); value != "" {
+		limit, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || limit <= 0 {
+			slog.Error("Invalid configuration", "error", fmt.Errorf("invalid MAX_BODY_BYTES %q", value))
+			db.CloseDatabase()
+			os.Exit(1)
+		}
+		maxBodyBytes = limit
+	}
 
 	// Apply middleware chain
 	handler := handlers.LoggingMiddleware(
 		handlers.RecoveryMiddleware(
 			handlers.CORSMiddleware(
-				handlers.SecurityHeadersMiddleware(mux),
+				handlers.SecurityHeadersMiddleware(
+					handlers.BodyLimitMiddleware(maxBodyBytes)(mux),
+				),
 			),
 		),
 	)
@@ -104,6 +164,14 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Recompute trends in the background until shutdown
+	trendCtx, stopTrends := context.WithCancel(context.Background())
+	trendsDone := make(chan struct{})
+	go func() {
+		defer close(trendsDone)
+		trendService.Run(trendCtx)
+	}()
+
 	// Start server in a goroutine
 	go func() {
 		slog.Info("Server starting", "port", port)
@@ -128,5 +196,57 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Stop background work once no request can use it any more
+	stopTrends()
+	select {
+	case <-trendsDone:
+	case <-ctx.Done():
+		slog.Warn("Trend refresher did not stop in time")
+	}
+
 	slog.Info("Server exited gracefully")
 }
+
+// runMigrate handles "migrate up|down|status|to <version>"
+func runMigrate(args []string) error {
+	migrator, err := db.NewMigrator(db.DB)
+	if err != nil {
+		return err
+	}
+
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch command {
+	case "up":
+		return migrator.Up()
+	case "down":
+		return migrator.Down()
+	case "to":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: migrate to <version>")
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		return migrator.To(version)
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d  %-30s  %s\n", status.Version, status.Name, applied)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate command %q (use up, down, status or to <version>)", command)
+	}
+}
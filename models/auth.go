@@ -1,23 +1,125 @@
 package models
 
-import "github.com/golang-jwt/jwt/v5"
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
 
 // LoginRequest represents the login request body
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" validate:"required"`
+	Password string `json:"password" validate:"required"`
+	ClientIP string `json:"-"` // Set by the handler for login throttling
 }
 
 // LoginResponse represents the login response
 type LoginResponse struct {
-	Message     string `json:"message"`
-	AccessToken string `json:"access_token"`
-	UserID      int    `json:"user_id"`
+	Message      string `json:"message"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"` // access token lifetime in seconds
+	UserID       int    `json:"user_id"`
+}
+
+// RefreshTokenRequest represents the token refresh request body
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// TokenResponse represents a newly issued access/refresh token pair
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"` // access token lifetime in seconds
 }
 
 // Claims represents JWT claims
 type Claims struct {
+	UserID    int    `json:"user_id"`
+	Email     string `json:"email"`
+	SessionID string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// Session represents a login session. Every access and refresh token belongs
+// to exactly one session; revoking the session invalidates all of them.
+type Session struct {
+	ID        string     `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	UserID    int        `json:"user_id" gorm:"not null;index"`
+	CreatedAt time.Time  `json:"created_at" gorm:"not null;autoCreateTime"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// TableName overrides the table name for Session model
+func (Session) TableName() string {
+	return "sessions"
+}
+
+// RefreshToken represents a single-use refresh token. Only the SHA-256 hash
+// of the token is stored.
+type RefreshToken struct {
+	ID        int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	SessionID string     `json:"session_id" gorm:"type:varchar(36);not null;index"`
+	UserID    int        `json:"user_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"type:char(64);uniqueIndex;not null"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at" gorm:"not null;autoCreateTime"`
+}
+
+// TableName overrides the table name for RefreshToken model
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// PasswordResetToken represents a single-use password reset token. Only the
+// SHA-256 hash of the token is stored.
+type PasswordResetToken struct {
+	ID        int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID    int        `json:"user_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"type:char(64);uniqueIndex;not null"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at" gorm:"not null;autoCreateTime"`
+}
+
+// TableName overrides the table name for PasswordResetToken model
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
+
+// ChangePasswordRequest represents the password change request body
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8,maxbytes=72"`
+}
+
+// ForgotPasswordRequest represents the password reset request body
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordRequest represents the request body that completes a password reset
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=8,maxbytes=72"`
+}
+
+// EmailVerificationClaims represents the claims of a signed email verification token.
+// The token is only valid for the address it was issued for.
+type EmailVerificationClaims struct {
 	UserID int    `json:"user_id"`
 	Email  string `json:"email"`
 	jwt.RegisteredClaims
 }
+
+// VerifyEmailRequest represents the email verification request body
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// ResendVerificationRequest represents the request body for resending a verification email
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
@@ -0,0 +1,64 @@
+package models
+
+// PostEntities are the hashtags and mentions in a post's content, for clients
+// to render as links. Offsets count Unicode code points from the start of the
+// content; Start is inclusive, End is exclusive and both cover the # or @.
+type PostEntities struct {
+	Hashtags []HashtagEntity `json:"hashtags"`
+	Mentions []MentionEntity `json:"mentions"`
+}
+
+// HashtagEntity is a #hashtag in a post. Tag is lowercased and has no #.
+type HashtagEntity struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Tag   string `json:"tag"`
+}
+
+// MentionEntity is an @mention of a user in a post. Name is the name as
+// written, which stays the same when the user is later renamed.
+type MentionEntity struct {
+	Start  int    `json:"start"`
+	End    int    `json:"end"`
+	UserID int    `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+// PostHashtag links a post to a hashtag in its content
+type PostHashtag struct {
+	PostID int    `gorm:"primaryKey;column:tweet_id"`
+	Tag    string `gorm:"primaryKey;type:varchar(100)"`
+}
+
+// TableName overrides the table name for PostHashtag model
+func (PostHashtag) TableName() string {
+	return "post_hashtags"
+}
+
+// PostMention links a post to a user mentioned in its content by Name
+type PostMention struct {
+	PostID int    `gorm:"primaryKey;column:tweet_id"`
+	UserID int    `gorm:"primaryKey;column:user_id"`
+	Name   string `gorm:"type:varchar(255);not null"`
+}
+
+// TableName overrides the table name for PostMention model
+func (PostMention) TableName() string {
+	return "post_mentions"
+}
+
+// HashtagPostsResponse represents a page of the posts tagged with a hashtag
+type HashtagPostsResponse struct {
+	Tag        string         `json:"tag"`
+	Posts      []PostWithUser `json:"posts"`
+	Count      int            `json:"count"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// MentionsResponse represents a page of the posts mentioning a user
+type MentionsResponse struct {
+	UserID     int            `json:"user_id"`
+	Posts      []PostWithUser `json:"posts"`
+	Count      int            `json:"count"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
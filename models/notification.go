@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// NotificationType is the event a notification reports
+type NotificationType string
+
+const (
+	// NotificationFollow reports that the actor followed the user
+	NotificationFollow NotificationType = "follow"
+	// NotificationLike reports that the actor liked one of the user's posts
+	NotificationLike NotificationType = "like"
+	// NotificationReply reports that the actor replied to one of the user's posts
+	NotificationReply NotificationType = "reply"
+	// NotificationMention reports that the actor mentioned the user in a post
+	NotificationMention NotificationType = "mention"
+)
+
+// Notification is one event shown to UserID. PostID is the post ActorID liked,
+// replied with or mentioned the user in; it is nil for follows.
+type Notification struct {
+	ID        int              `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID    int              `json:"user_id" gorm:"not null"`
+	ActorID   int              `json:"actor_id" gorm:"not null"`
+	Type      NotificationType `json:"type" gorm:"type:varchar(20);not null"`
+	PostID    *int             `json:"post_id,omitempty" gorm:"column:tweet_id"`
+	ReadAt    *time.Time       `json:"read_at,omitempty"`
+	CreatedAt time.Time        `json:"created_at" gorm:"not null;autoCreateTime"`
+}
+
+// TableName overrides the table name for Notification model
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// NotificationGroup is one entry of the notification list: the notifications
+// of a type about the same post (or all follows) on a page, folded together.
+// Actors holds the most recent distinct actors, newest first; ActorCount
+// counts them all. Message reads like "A님 외 3명이 회원님을 팔로우했습니다."
+type NotificationGroup struct {
+	Type            NotificationType `json:"type"`
+	PostID          *int             `json:"post_id,omitempty"`
+	Actors          []PublicUser     `json:"actors"`
+	ActorCount      int              `json:"actor_count"`
+	Message         string           `json:"message"`
+	Read            bool             `json:"read"`       // true once every notification in the group is read
+	CreatedAt       time.Time        `json:"created_at"` // of the newest notification in the group
+	NotificationIDs []int            `json:"notification_ids"`
+}
+
+// NotificationsResponse represents a page of a user's notifications, newest first
+type NotificationsResponse struct {
+	Notifications []NotificationGroup `json:"notifications"`
+	Count         int                 `json:"count"`
+	UnreadCount   int                 `json:"unread_count"`
+	NextCursor    string              `json:"next_cursor,omitempty"`
+}
+
+// MarkNotificationsReadRequest represents the (optional) mark read request body.
+// An empty IDs marks every notification read.
+type MarkNotificationsReadRequest struct {
+	IDs []int `json:"ids,omitempty"`
+}
+
+// MarkNotificationsReadResponse represents the mark read response
+type MarkNotificationsReadResponse struct {
+	Message     string `json:"message"`
+	Marked      int    `json:"marked"`
+	UnreadCount int    `json:"unread_count"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// PageRequest represents cursor pagination parameters (?limit=&cursor=).
+// A zero limit selects the default page size.
+type PageRequest struct {
+	Limit  int    `json:"limit" validate:"min=0,max=100"`
+	Cursor string `json:"cursor"`
+}
@@ -1,13 +1,39 @@
 package models
 
-import "time"
+import (
+	"time"
 
-// Post represents a post/tweet in the system
+	"gorm.io/gorm"
+)
+
+// PostKind distinguishes original posts from posts that share another post
+type PostKind string
+
+const (
+	// PostKindPost is an original post (including replies)
+	PostKindPost PostKind = "post"
+	// PostKindRepost shares another post unchanged; it has no content of its own
+	PostKindRepost PostKind = "repost"
+	// PostKindQuote shares another post with added content
+	PostKindQuote PostKind = "quote"
+)
+
+// Post represents a post/tweet in the system.
+// InReplyToPostID is set on replies and OriginalPostID on reposts and quote
+// posts. Deleted posts are kept with DeletedAt set and hidden from every query;
+// reposts are deleted with their original, while replies and quote posts keep
+// their reference so that restoring the post reattaches them.
 type Post struct {
-	ID        int       `json:"id" gorm:"primaryKey;autoIncrement"`
-	UserID    int       `json:"user_id" gorm:"not null;index"`
-	Content   string    `json:"content" gorm:"column:tweet;type:varchar(300);not null"`
-	CreatedAt time.Time `json:"created_at" gorm:"not null;autoCreateTime"`
+	ID              int            `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID          int            `json:"user_id" gorm:"not null;index"`
+	Kind            PostKind       `json:"kind" gorm:"type:varchar(10);not null;default:post"`
+	Content         string         `json:"content" gorm:"column:tweet;type:varchar(300);not null"`
+	InReplyToPostID *int           `json:"in_reply_to_post_id,omitempty" gorm:"column:in_reply_to_post_id"`
+	OriginalPostID  *int           `json:"original_post_id,omitempty" gorm:"column:original_post_id"`
+	CreatedAt       time.Time      `json:"created_at" gorm:"not null;autoCreateTime"`
+	EditedAt        *time.Time     `json:"edited_at,omitempty"` // nil until the post is first edited
+	DeletedAt       gorm.DeletedAt `json:"-"`
+	Entities        PostEntities   `json:"entities" gorm:"-"` // saved by Create and Update, not loaded by reads
 }
 
 // TableName overrides the table name for Post model
@@ -15,10 +41,12 @@ func (Post) TableName() string {
 	return "tweets"
 }
 
-// CreatePostRequest represents the create post request body
+// CreatePostRequest represents the create post request body.
+// The author is taken from the access token; UserID is optional and, if set,
+// must match the authenticated user.
 type CreatePostRequest struct {
-	UserID  int    `json:"user_id"`
-	Content string `json:"content"`
+	UserID  int    `json:"user_id,omitempty"`
+	Content string `json:"content" validate:"required,notblank,max=300"`
 }
 
 // CreatePostResponse represents the create post response
@@ -28,10 +56,18 @@ type CreatePostResponse struct {
 	Post    Post   `json:"post"`
 }
 
-// UpdatePostRequest represents the update post request body
+// UndoRepostResponse represents the undo repost response
+type UndoRepostResponse struct {
+	Message        string `json:"message"`
+	PostID         int    `json:"post_id"`
+	OriginalPostID int    `json:"original_post_id"`
+}
+
+// UpdatePostRequest represents the update post request body.
+// UserID is optional and, if set, must match the authenticated user.
 type UpdatePostRequest struct {
-	UserID  int    `json:"user_id"`
-	Content string `json:"content"`
+	UserID  int    `json:"user_id,omitempty"`
+	Content string `json:"content" validate:"required,notblank,max=300"`
 }
 
 // UpdatePostResponse represents the update post response
@@ -41,9 +77,10 @@ type UpdatePostResponse struct {
 	Post    Post   `json:"post"`
 }
 
-// DeletePostRequest represents the delete post request body
+// DeletePostRequest represents the (optional) delete post request body.
+// UserID is optional and, if set, must match the authenticated user.
 type DeletePostRequest struct {
-	UserID int `json:"user_id"`
+	UserID int `json:"user_id,omitempty"`
 }
 
 // DeletePostResponse represents the delete post response
@@ -52,23 +89,100 @@ type DeletePostResponse struct {
 	PostID  int    `json:"post_id"`
 }
 
-// PostWithUser represents a post with user information
+// RestorePostResponse represents the restore post response
+type RestorePostResponse struct {
+	Message string `json:"message"`
+	PostID  int    `json:"post_id"`
+	Post    Post   `json:"post"`
+}
+
+// PostRevision records the content a post had before an edit made at CreatedAt
+type PostRevision struct {
+	ID        int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	PostID    int       `json:"post_id" gorm:"column:tweet_id;not null"`
+	Content   string    `json:"content" gorm:"column:tweet;type:varchar(300);not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;autoCreateTime"`
+}
+
+// TableName overrides the table name for PostRevision model
+func (PostRevision) TableName() string {
+	return "post_revisions"
+}
+
+// PostHistoryResponse represents a page of a post's earlier versions, newest first
+type PostHistoryResponse struct {
+	PostID     int            `json:"post_id"`
+	Revisions  []PostRevision `json:"revisions"`
+	Count      int            `json:"count"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// PostWithUser represents a post with user information and engagement counts.
+// Reposts and quote posts carry the post they share, with its author, in Original.
 type PostWithUser struct {
-	ID        int       `json:"id"`
-	UserID    int       `json:"user_id"`
-	UserName  string    `json:"user_name"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
+	ID              int           `json:"id"`
+	UserID          int           `json:"user_id"`
+	UserName        string        `json:"user_name"`
+	Kind            PostKind      `json:"kind"`
+	Content         string        `json:"content"`
+	InReplyToPostID *int          `json:"in_reply_to_post_id,omitempty"`
+	OriginalPostID  *int          `json:"original_post_id,omitempty"`
+	Original        *PostWithUser `json:"original,omitempty" gorm:"-"`
+	CreatedAt       time.Time     `json:"created_at"`
+	EditedAt        *time.Time    `json:"edited_at,omitempty"`
+	LikeCount       int           `json:"like_count" gorm:"-"`
+	LikedByMe       bool          `json:"liked_by_me" gorm:"-"`
+	ReplyCount      int           `json:"reply_count" gorm:"-"`
+	Entities        PostEntities  `json:"entities" gorm:"-"`
 }
 
 // TimelineResponse represents timeline response
 type TimelineResponse struct {
-	Posts []PostWithUser `json:"posts"`
-	Count int            `json:"count"`
+	Posts      []PostWithUser `json:"posts"`
+	Count      int            `json:"count"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// ThreadResponse represents a post in its conversation: the chain of posts it
+// replies to (oldest first) and a page of its direct replies (newest first)
+type ThreadResponse struct {
+	Ancestors  []PostWithUser `json:"ancestors"`
+	Post       PostWithUser   `json:"post"`
+	Replies    []PostWithUser `json:"replies"`
+	Count      int            `json:"count"`
+	NextCursor string         `json:"next_cursor,omitempty"`
 }
 
 // UserPostsResponse represents user posts response
 type UserPostsResponse struct {
-	Posts []Post `json:"posts"`
-	Count int    `json:"count"`
+	Posts      []PostWithUser `json:"posts"`
+	Count      int            `json:"count"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// Like represents a user liking a post
+type Like struct {
+	UserID    int       `json:"user_id" gorm:"primaryKey;column:user_id"`
+	PostID    int       `json:"post_id" gorm:"primaryKey;column:tweet_id"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;autoCreateTime"`
+}
+
+// TableName overrides the table name for Like model
+func (Like) TableName() string {
+	return "tweet_likes"
+}
+
+// LikeResponse represents like/unlike response
+type LikeResponse struct {
+	Message   string `json:"message"`
+	PostID    int    `json:"post_id"`
+	Liked     bool   `json:"liked"`
+	LikeCount int    `json:"like_count"`
+}
+
+// LikeListResponse represents the users who liked a post
+type LikeListResponse struct {
+	Users      []PublicUser `json:"users"`
+	Count      int          `json:"count"`
+	NextCursor string       `json:"next_cursor,omitempty"`
 }
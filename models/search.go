@@ -0,0 +1,20 @@
+package models
+
+// SearchRequest represents the search query parameter (?q=)
+type SearchRequest struct {
+	Query string `json:"q" validate:"required,notblank,max=100"`
+}
+
+// PostSearchResponse represents a page of posts matching a search, best match first
+type PostSearchResponse struct {
+	Posts      []PostWithUser `json:"posts"`
+	Count      int            `json:"count"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// UserSearchResponse represents a page of users matching a search, best match first
+type UserSearchResponse struct {
+	Users      []PublicUser `json:"users"`
+	Count      int          `json:"count"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
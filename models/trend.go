@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Trend is a hashtag used more than usual. HourCount and DayCount are its uses
+// in the last hour and the last 24 hours; Score measures how far the last hour
+// is above the rate of the rest of the day.
+type Trend struct {
+	Tag       string  `json:"tag"`
+	HourCount int     `json:"hour_count"`
+	DayCount  int     `json:"day_count"`
+	Score     float64 `json:"score"`
+}
+
+// TrendsResponse represents the trending hashtags, hottest first, as of ComputedAt
+type TrendsResponse struct {
+	Trends     []Trend   `json:"trends"`
+	Count      int       `json:"count"`
+	ComputedAt time.Time `json:"computed_at"`
+}
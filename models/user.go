@@ -4,14 +4,15 @@ import "time"
 
 // User represents a user in the system
 type User struct {
-	ID             int       `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name           string    `json:"name" gorm:"type:varchar(255);not null"`
-	Email          string    `json:"email" gorm:"type:varchar(255);uniqueIndex;not null"`
-	HashedPassword string    `json:"-" gorm:"column:hashed_password;type:varchar(255);not null"`
-	Password       string    `json:"password,omitempty" gorm:"-"` // For request handling only
-	Profile        string    `json:"profile" gorm:"type:varchar(2000);not null"`
-	CreatedAt      time.Time `json:"created_at" gorm:"not null;autoCreateTime"`
-	UpdatedAt      time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID              int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name            string     `json:"name" gorm:"type:varchar(255);not null"`
+	Email           string     `json:"email" gorm:"type:varchar(255);uniqueIndex;not null"`
+	HashedPassword  string     `json:"-" gorm:"column:hashed_password;type:varchar(255);not null"`
+	Password        string     `json:"password,omitempty" gorm:"-"` // For request handling only
+	Profile         string     `json:"profile" gorm:"type:varchar(2000);not null"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"` // nil until the address is verified
+	CreatedAt       time.Time  `json:"created_at" gorm:"not null;autoCreateTime"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 // Follow represents a follow relationship
@@ -26,12 +27,13 @@ func (Follow) TableName() string {
 	return "users_follow_list"
 }
 
-// SignupRequest represents the signup request body
+// SignupRequest represents the signup request body.
+// Passwords are limited to 72 bytes, the most bcrypt hashes.
 type SignupRequest struct {
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	Profile  string `json:"profile"`
+	Name     string `json:"name" validate:"required,notblank,max=255"`
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required,min=8,maxbytes=72"`
+	Profile  string `json:"profile" validate:"max=2000"`
 }
 
 // SignupResponse represents the signup response
@@ -40,9 +42,11 @@ type SignupResponse struct {
 	UserID  int    `json:"user_id"`
 }
 
-// FollowRequest represents the follow request body
+// FollowRequest represents the (optional) follow request body.
+// The follower is taken from the access token; FollowerID is optional and,
+// if set, must match the authenticated user.
 type FollowRequest struct {
-	FollowerID int `json:"follower_id"`
+	FollowerID int `json:"follower_id,omitempty"`
 }
 
 // FollowResponse represents the follow response
@@ -53,18 +57,45 @@ type FollowResponse struct {
 	CreatedAt   string `json:"created_at,omitempty"`
 }
 
-// UserInfo represents basic user information for follow lists
-type UserInfo struct {
+// PublicUser represents the user information shown to anyone, without
+// private fields such as the email address
+type PublicUser struct {
 	ID      int    `json:"id"`
 	Name    string `json:"name"`
-	Email   string `json:"email"`
 	Profile string `json:"profile"`
 }
 
+// UserProfile represents a user's public profile with activity counts
+type UserProfile struct {
+	ID             int       `json:"id"`
+	Name           string    `json:"name"`
+	Profile        string    `json:"profile"`
+	CreatedAt      time.Time `json:"created_at"`
+	FollowerCount  int       `json:"follower_count"`
+	FollowingCount int       `json:"following_count"`
+	PostCount      int       `json:"post_count"`
+}
+
+// MyProfile represents the authenticated user's own profile, including private fields
+type MyProfile struct {
+	UserProfile
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// UpdateProfileRequest represents the profile update request body.
+// Omitted fields are left unchanged.
+type UpdateProfileRequest struct {
+	Name    *string `json:"name,omitempty" validate:"notblank,max=255"`
+	Profile *string `json:"profile,omitempty" validate:"max=2000"`
+}
+
 // FollowListResponse represents followers/following list response
 type FollowListResponse struct {
-	Users []UserInfo `json:"users"`
-	Count int        `json:"count"`
+	Users      []PublicUser `json:"users"`
+	Count      int          `json:"count"`
+	NextCursor string       `json:"next_cursor,omitempty"`
 }
 
 // FollowStatusResponse represents follow status response
@@ -76,8 +107,10 @@ type FollowStatusResponse struct {
 
 // ErrorResponse represents a standardized error response
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
+	Error   string            `json:"error"`
+	Code    string            `json:"code"`
+	Message string            `json:"message,omitempty"`
+	Fields  map[string]string `json:"fields,omitempty"` // invalid request fields and the reason for each
 }
 
 // SuccessResponse represents a standardized success response
@@ -0,0 +1,1263 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"python-backend-with-go/models"
+)
+
+// Repositories bundles the repositories of one storage backend
+type Repositories struct {
+	Users         UserRepository
+	Posts         PostRepository
+	Follows       FollowRepository
+	Likes         LikeRepository
+	Notifications NotificationRepository
+}
+
+// RepositoryFactory returns fresh, empty repositories for a single test
+type RepositoryFactory func(t *testing.T) Repositories
+
+// contractBackends lists every backend the contract suite runs against
+var contractBackends = map[string]RepositoryFactory{
+	"InMemory": func(t *testing.T) Repositories {
+		return Repositories{
+			Users:         NewInMemoryUserRepository(),
+			Posts:         NewInMemoryPostRepository(),
+			Follows:       NewInMemoryFollowRepository(),
+			Likes:         NewInMemoryLikeRepository(),
+			Notifications: NewInMemoryNotificationRepository(),
+		}
+	},
+	"SQLite": func(t *testing.T) Repositories {
+		conn := newSQLiteDB(t)
+		return Repositories{
+			Users:         NewGormUserRepository(conn),
+			Posts:         NewGormPostRepository(conn),
+			Follows:       NewGormFollowRepository(conn),
+			Likes:         NewGormLikeRepository(conn),
+			Notifications: NewGormNotificationRepository(conn),
+		}
+	},
+}
+
+func TestRepositoryContracts(t *testing.T) {
+	for name, factory := range contractBackends {
+		t.Run(name, func(t *testing.T) {
+			t.Run("UserRepository", func(t *testing.T) { RunUserRepositoryContract(t, factory) })
+			t.Run("PostRepository", func(t *testing.T) { RunPostRepositoryContract(t, factory) })
+			t.Run("FollowRepository", func(t *testing.T) { RunFollowRepositoryContract(t, factory) })
+			t.Run("LikeRepository", func(t *testing.T) { RunLikeRepositoryContract(t, factory) })
+			t.Run("NotificationRepository", func(t *testing.T) { RunNotificationRepositoryContract(t, factory) })
+		})
+	}
+}
+
+// contractTime is a second-aligned base time, since MySQL TIMESTAMP columns drop fractions
+var contractTime = time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local)
+
+// createUsers stores n users named user1..userN and returns them in creation order
+func createUsers(t *testing.T, repo UserRepository, n int) []models.User {
+	t.Helper()
+
+	users := make([]models.User, n)
+	for i := range users {
+		user := models.User{
+			Name:           fmt.Sprintf("user%d", i+1),
+			Email:          fmt.Sprintf("user%d@example.com", i+1),
+			HashedPassword: "hashed",
+			Profile:        "profile",
+		}
+		if err := repo.Create(&user); err != nil {
+			t.Fatalf("Create user error = %v", err)
+		}
+		users[i] = user
+	}
+	return users
+}
+
+// RunUserRepositoryContract checks the behavior every UserRepository must share
+func RunUserRepositoryContract(t *testing.T, newRepos RepositoryFactory) {
+	t.Run("Create assigns ID and created_at", func(t *testing.T) {
+		repo := newRepos(t).Users
+		users := createUsers(t, repo, 2)
+
+		if users[0].ID == 0 || users[1].ID == 0 || users[0].ID == users[1].ID {
+			t.Errorf("Create() IDs = %d, %d, want distinct non-zero IDs", users[0].ID, users[1].ID)
+		}
+		if users[0].CreatedAt.IsZero() {
+			t.Error("Create() did not set CreatedAt")
+		}
+	})
+
+	t.Run("Create rejects duplicate email", func(t *testing.T) {
+		repo := newRepos(t).Users
+		createUsers(t, repo, 1)
+
+		dup := models.User{Name: "dup", Email: "user1@example.com", HashedPassword: "hashed"}
+		if err := repo.Create(&dup); !errors.Is(err, ErrEmailTaken) {
+			t.Errorf("Create() error = %v, want %v", err, ErrEmailTaken)
+		}
+		if !errors.Is(ErrEmailTaken, ErrAlreadyExists) {
+			t.Error("ErrEmailTaken does not wrap ErrAlreadyExists")
+		}
+	})
+
+	t.Run("GetByID", func(t *testing.T) {
+		repo := newRepos(t).Users
+		users := createUsers(t, repo, 1)
+
+		got, err := repo.GetByID(users[0].ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Name != "user1" || got.Email != "user1@example.com" || got.HashedPassword != "hashed" || got.Profile != "profile" {
+			t.Errorf("GetByID() = %+v, want stored fields", got)
+		}
+
+		if _, err := repo.GetByID(users[0].ID + 100); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("GetByID(missing) error = %v, want %v", err, ErrUserNotFound)
+		}
+	})
+
+	t.Run("GetByIDs omits missing users", func(t *testing.T) {
+		repo := newRepos(t).Users
+		users := createUsers(t, repo, 2)
+
+		got, err := repo.GetByIDs([]int{users[0].ID, users[1].ID, users[1].ID + 100})
+		if err != nil {
+			t.Fatalf("GetByIDs() error = %v", err)
+		}
+		if len(got) != 2 || got[users[0].ID].Name != "user1" || got[users[1].ID].Name != "user2" {
+			t.Errorf("GetByIDs() = %v, want user1 and user2", got)
+		}
+
+		empty, err := repo.GetByIDs(nil)
+		if err != nil || len(empty) != 0 {
+			t.Errorf("GetByIDs(nil) = (%v, %v), want empty map", empty, err)
+		}
+	})
+
+	t.Run("List pages newest first", func(t *testing.T) {
+		repo := newRepos(t).Users
+		users := createUsers(t, repo, 3)
+
+		first, err := repo.List(PageQuery{Limit: 2})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if len(first) != 2 || first[0].ID != users[2].ID || first[1].ID != users[1].ID {
+			t.Fatalf("List() = %v, want user3 and user2", first)
+		}
+
+		last := first[len(first)-1]
+		rest, err := repo.List(PageQuery{Cursor: &Cursor{CreatedAt: last.CreatedAt, ID: last.ID}})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if len(rest) != 1 || rest[0].ID != users[0].ID {
+			t.Errorf("List() after cursor = %v, want user1", rest)
+		}
+	})
+
+	t.Run("GetByNames matches names exactly", func(t *testing.T) {
+		repo := newRepos(t).Users
+		users := createUsers(t, repo, 3)
+		twin := models.User{Name: "user1", Email: "twin@example.com", HashedPassword: "hashed"}
+		if err := repo.Create(&twin); err != nil {
+			t.Fatalf("Create user error = %v", err)
+		}
+
+		got, err := repo.GetByNames([]string{"user1", "USER2", "nobody"})
+		if err != nil {
+			t.Fatalf("GetByNames() error = %v", err)
+		}
+		ids := make(map[int]bool)
+		for _, user := range got {
+			ids[user.ID] = true
+		}
+		if len(got) != 2 || !ids[users[0].ID] || !ids[twin.ID] {
+			t.Errorf("GetByNames() = %v, want both users named user1", got)
+		}
+
+		empty, err := repo.GetByNames(nil)
+		if err != nil || len(empty) != 0 {
+			t.Errorf("GetByNames(nil) = (%v, %v), want empty", empty, err)
+		}
+	})
+
+	t.Run("GetByEmail and EmailExists", func(t *testing.T) {
+		repo := newRepos(t).Users
+		users := createUsers(t, repo, 2)
+
+		got, err := repo.GetByEmail("user2@example.com")
+		if err != nil || got.ID != users[1].ID {
+			t.Errorf("GetByEmail() = (%d, %v), want (%d, nil)", got.ID, err, users[1].ID)
+		}
+		if _, err := repo.GetByEmail("missing@example.com"); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("GetByEmail(missing) error = %v, want %v", err, ErrUserNotFound)
+		}
+
+		if !repo.EmailExists("user1@example.com") {
+			t.Error("EmailExists() = false for stored email")
+		}
+		if repo.EmailExists("missing@example.com") {
+			t.Error("EmailExists() = true for unknown email")
+		}
+	})
+
+	t.Run("Update saves name and profile", func(t *testing.T) {
+		repo := newRepos(t).Users
+		users := createUsers(t, repo, 1)
+
+		update := models.User{ID: users[0].ID, Name: "renamed", Profile: "new profile", Email: "ignored@example.com"}
+		if err := repo.Update(&update); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if update.UpdatedAt.IsZero() {
+			t.Error("Update() did not set UpdatedAt")
+		}
+
+		got, err := repo.GetByID(users[0].ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Name != "renamed" || got.Profile != "new profile" || got.Email != "user1@example.com" {
+			t.Errorf("after Update() user = %+v, want only name and profile changed", got)
+		}
+
+		if err := repo.Update(&models.User{ID: users[0].ID + 100, Name: "x"}); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("Update(missing) error = %v, want %v", err, ErrUserNotFound)
+		}
+	})
+
+	t.Run("UpdatePassword", func(t *testing.T) {
+		repo := newRepos(t).Users
+		users := createUsers(t, repo, 2)
+
+		if err := repo.UpdatePassword(users[0].ID, "rehashed"); err != nil {
+			t.Fatalf("UpdatePassword() error = %v", err)
+		}
+
+		got, err := repo.GetByID(users[0].ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.HashedPassword != "rehashed" || got.Name != "user1" {
+			t.Errorf("after UpdatePassword() user = %+v, want only the hash changed", got)
+		}
+		if other, _ := repo.GetByID(users[1].ID); other.HashedPassword != "hashed" {
+			t.Errorf("UpdatePassword() changed another user's hash")
+		}
+
+		if err := repo.UpdatePassword(users[1].ID+100, "x"); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("UpdatePassword(missing) error = %v, want %v", err, ErrUserNotFound)
+		}
+	})
+
+	t.Run("MarkEmailVerified", func(t *testing.T) {
+		repo := newRepos(t).Users
+		users := createUsers(t, repo, 2)
+		first := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		if users[0].EmailVerifiedAt != nil {
+			t.Fatalf("new user EmailVerifiedAt = %v, want nil", users[0].EmailVerifiedAt)
+		}
+		if err := repo.MarkEmailVerified(users[0].ID, first); err != nil {
+			t.Fatalf("MarkEmailVerified() error = %v", err)
+		}
+		// Verifying again keeps the original timestamp
+		if err := repo.MarkEmailVerified(users[0].ID, first.Add(time.Hour)); err != nil {
+			t.Fatalf("MarkEmailVerified(again) error = %v", err)
+		}
+
+		got, err := repo.GetByID(users[0].ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.EmailVerifiedAt == nil || !got.EmailVerifiedAt.Equal(first) {
+			t.Errorf("EmailVerifiedAt = %v, want %v", got.EmailVerifiedAt, first)
+		}
+		if other, _ := repo.GetByID(users[1].ID); other.EmailVerifiedAt != nil {
+			t.Errorf("MarkEmailVerified() verified another user")
+		}
+
+		if err := repo.MarkEmailVerified(users[1].ID+100, first); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("MarkEmailVerified(missing) error = %v, want %v", err, ErrUserNotFound)
+		}
+	})
+}
+
+// RunPostRepositoryContract checks the behavior every PostRepository must share
+func RunPostRepositoryContract(t *testing.T, newRepos RepositoryFactory) {
+	// createPosts stores n posts for userID, one minute apart, oldest first
+	createPosts := func(t *testing.T, repo PostRepository, userID, n int, base time.Time) []models.Post {
+		t.Helper()
+
+		posts := make([]models.Post, n)
+		for i := range posts {
+			post := models.Post{
+				UserID:    userID,
+				Content:   fmt.Sprintf("post %d by %d", i+1, userID),
+				CreatedAt: base.Add(time.Duration(i) * time.Minute),
+			}
+			if err := repo.Create(&post); err != nil {
+				t.Fatalf("Create post error = %v", err)
+			}
+			posts[i] = post
+		}
+		return posts
+	}
+
+	postIDs := func(posts []models.Post) []int {
+		ids := make([]int, len(posts))
+		for i, post := range posts {
+			ids[i] = post.ID
+		}
+		return ids
+	}
+
+	t.Run("Create and GetByID", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 1)
+
+		post := models.Post{UserID: users[0].ID, Content: "hello"}
+		if err := repos.Posts.Create(&post); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if post.ID == 0 || post.CreatedAt.IsZero() {
+			t.Errorf("Create() = %+v, want ID and CreatedAt set", post)
+		}
+
+		got, err := repos.Posts.GetByID(post.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.UserID != users[0].ID || got.Content != "hello" {
+			t.Errorf("GetByID() = %+v, want stored post", got)
+		}
+		// Cursors built from the created post must match the stored one
+		if !got.CreatedAt.Equal(post.CreatedAt) {
+			t.Errorf("GetByID() CreatedAt = %v, want %v as returned by Create()", got.CreatedAt, post.CreatedAt)
+		}
+
+		if _, err := repos.Posts.GetByID(post.ID + 100); !errors.Is(err, ErrPostNotFound) {
+			t.Errorf("GetByID(missing) error = %v, want %v", err, ErrPostNotFound)
+		}
+	})
+
+	t.Run("Update changes content and records revisions", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 2)
+		post := createPosts(t, repos.Posts, users[0].ID, 1, contractTime)[0]
+		if post.EditedAt != nil {
+			t.Errorf("Create() EditedAt = %v, want nil", post.EditedAt)
+		}
+
+		editedAt := contractTime.Add(time.Hour)
+		update := models.Post{ID: post.ID, UserID: users[1].ID, Content: "edited", EditedAt: &editedAt}
+		if err := repos.Posts.Update(&update); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repos.Posts.GetByID(post.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Content != "edited" || got.UserID != users[0].ID || !got.CreatedAt.Equal(post.CreatedAt) {
+			t.Errorf("after Update() post = %+v, want only content changed", got)
+		}
+		if got.EditedAt == nil || !got.EditedAt.Equal(editedAt) {
+			t.Errorf("after Update() EditedAt = %v, want %v", got.EditedAt, editedAt)
+		}
+
+		// Saving identical content is not a "not found"
+		editedAt = editedAt.Add(time.Hour)
+		update.EditedAt = &editedAt
+		if err := repos.Posts.Update(&update); err != nil {
+			t.Errorf("Update() with unchanged content error = %v", err)
+		}
+
+		revisions, err := repos.Posts.GetRevisions(post.ID, PageQuery{Limit: 1})
+		if err != nil {
+			t.Fatalf("GetRevisions() error = %v", err)
+		}
+		if len(revisions) != 1 || revisions[0].Content != "edited" || !revisions[0].CreatedAt.Equal(editedAt) {
+			t.Fatalf("GetRevisions() first page = %+v, want the edited content replaced last", revisions)
+		}
+		last := revisions[0]
+		revisions, err = repos.Posts.GetRevisions(post.ID, PageQuery{Limit: 1, Cursor: &Cursor{CreatedAt: last.CreatedAt, ID: last.ID}})
+		if err != nil {
+			t.Fatalf("GetRevisions() error = %v", err)
+		}
+		if len(revisions) != 1 || revisions[0].Content != post.Content || revisions[0].PostID != post.ID {
+			t.Errorf("GetRevisions() second page = %+v, want the original content", revisions)
+		}
+
+		if err := repos.Posts.Update(&models.Post{ID: post.ID + 100, Content: "x"}); !errors.Is(err, ErrPostNotFound) {
+			t.Errorf("Update(missing) error = %v, want %v", err, ErrPostNotFound)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 1)
+		posts := createPosts(t, repos.Posts, users[0].ID, 2, contractTime)
+
+		if err := repos.Posts.Delete(posts[0].ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repos.Posts.GetByID(posts[0].ID); !errors.Is(err, ErrPostNotFound) {
+			t.Errorf("GetByID() after Delete error = %v, want %v", err, ErrPostNotFound)
+		}
+		if err := repos.Posts.Delete(posts[0].ID); !errors.Is(err, ErrPostNotFound) {
+			t.Errorf("Delete() twice error = %v, want %v", err, ErrPostNotFound)
+		}
+
+		remaining, err := repos.Posts.GetByUserID(users[0].ID, PageQuery{})
+		if err != nil {
+			t.Fatalf("GetByUserID() error = %v", err)
+		}
+		if len(remaining) != 1 || remaining[0].ID != posts[1].ID {
+			t.Errorf("GetByUserID() after Delete = %v, want only post %d", postIDs(remaining), posts[1].ID)
+		}
+		if found, err := repos.Posts.GetByIDs(postIDs(posts)); err != nil || len(found) != 1 {
+			t.Errorf("GetByIDs() after Delete = (%v, %v), want only post %d", postIDs(found), err, posts[1].ID)
+		}
+		if n, err := repos.Posts.CountByUserID(users[0].ID); err != nil || n != 1 {
+			t.Errorf("CountByUserID() after Delete = (%d, %v), want 1", n, err)
+		}
+		if reader, ok := repos.Posts.(PostWithUserReader); ok {
+			found, err := reader.GetWithUsersByIDs(postIDs(posts))
+			if err != nil || len(found) != 1 {
+				t.Errorf("GetWithUsersByIDs() after Delete = (%v, %v), want only post %d", found, err, posts[1].ID)
+			}
+		}
+	})
+
+	t.Run("Restore brings back a deleted post", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 1)
+		post := createPosts(t, repos.Posts, users[0].ID, 1, contractTime)[0]
+
+		if _, err := repos.Posts.GetDeletedByID(post.ID); !errors.Is(err, ErrPostNotFound) {
+			t.Errorf("GetDeletedByID() of a live post error = %v, want %v", err, ErrPostNotFound)
+		}
+		if err := repos.Posts.Restore(post.ID); !errors.Is(err, ErrPostNotFound) {
+			t.Errorf("Restore() of a live post error = %v, want %v", err, ErrPostNotFound)
+		}
+
+		if err := repos.Posts.Delete(post.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		deleted, err := repos.Posts.GetDeletedByID(post.ID)
+		if err != nil {
+			t.Fatalf("GetDeletedByID() error = %v", err)
+		}
+		if !deleted.DeletedAt.Valid || deleted.Content != post.Content {
+			t.Errorf("GetDeletedByID() = %+v, want the post with DeletedAt set", deleted)
+		}
+
+		if err := repos.Posts.Restore(post.ID); err != nil {
+			t.Fatalf("Restore() error = %v", err)
+		}
+		got, err := repos.Posts.GetByID(post.ID)
+		if err != nil || got.DeletedAt.Valid {
+			t.Errorf("GetByID() after Restore = (%+v, %v), want the live post", got, err)
+		}
+		if page, err := repos.Posts.GetByUserID(users[0].ID, PageQuery{}); err != nil || len(page) != 1 {
+			t.Errorf("GetByUserID() after Restore = (%v, %v), want the post", postIDs(page), err)
+		}
+	})
+
+	t.Run("GetByIDs skips missing posts", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 1)
+		posts := createPosts(t, repos.Posts, users[0].ID, 2, contractTime)
+
+		got, err := repos.Posts.GetByIDs([]int{posts[0].ID, posts[1].ID, posts[1].ID + 100})
+		if err != nil {
+			t.Fatalf("GetByIDs() error = %v", err)
+		}
+		if len(got) != 2 {
+			t.Errorf("GetByIDs() returned %d posts, want 2", len(got))
+		}
+
+		empty, err := repos.Posts.GetByIDs(nil)
+		if err != nil || len(empty) != 0 {
+			t.Errorf("GetByIDs(nil) = (%v, %v), want empty", empty, err)
+		}
+	})
+
+	t.Run("GetByUserID pages newest first", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 2)
+		posts := createPosts(t, repos.Posts, users[0].ID, 5, contractTime)
+		createPosts(t, repos.Posts, users[1].ID, 2, contractTime)
+
+		var got []int
+		query := PageQuery{Limit: 2}
+		for {
+			page, err := repos.Posts.GetByUserID(users[0].ID, query)
+			if err != nil {
+				t.Fatalf("GetByUserID() error = %v", err)
+			}
+			if len(page) == 0 {
+				break
+			}
+			got = append(got, postIDs(page)...)
+			last := page[len(page)-1]
+			query.Cursor = &Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+		}
+
+		want := []int{posts[4].ID, posts[3].ID, posts[2].ID, posts[1].ID, posts[0].ID}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("GetByUserID() pages = %v, want %v", got, want)
+		}
+
+		none, err := repos.Posts.GetByUserID(users[1].ID+100, PageQuery{})
+		if err != nil || len(none) != 0 {
+			t.Errorf("GetByUserID(no posts) = (%v, %v), want empty", none, err)
+		}
+	})
+
+	t.Run("GetByUserIDs breaks timestamp ties by ID", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 3)
+		a := createPosts(t, repos.Posts, users[0].ID, 2, contractTime)
+		b := createPosts(t, repos.Posts, users[1].ID, 2, contractTime)
+		createPosts(t, repos.Posts, users[2].ID, 2, contractTime)
+
+		page, err := repos.Posts.GetByUserIDs([]int{users[0].ID, users[1].ID}, PageQuery{Limit: 3})
+		if err != nil {
+			t.Fatalf("GetByUserIDs() error = %v", err)
+		}
+		want := []int{b[1].ID, a[1].ID, b[0].ID}
+		if fmt.Sprint(postIDs(page)) != fmt.Sprint(want) {
+			t.Errorf("GetByUserIDs() = %v, want %v", postIDs(page), want)
+		}
+
+		last := page[len(page)-1]
+		rest, err := repos.Posts.GetByUserIDs([]int{users[0].ID, users[1].ID}, PageQuery{Cursor: &Cursor{CreatedAt: last.CreatedAt, ID: last.ID}})
+		if err != nil {
+			t.Fatalf("GetByUserIDs() error = %v", err)
+		}
+		if fmt.Sprint(postIDs(rest)) != fmt.Sprint([]int{a[0].ID}) {
+			t.Errorf("GetByUserIDs() after cursor = %v, want [%d]", postIDs(rest), a[0].ID)
+		}
+	})
+
+	t.Run("List pages every user's posts newest first and skips deleted posts", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 2)
+		a := createPosts(t, repos.Posts, users[0].ID, 2, contractTime)
+		b := createPosts(t, repos.Posts, users[1].ID, 2, contractTime.Add(30*time.Second))
+		if err := repos.Posts.Delete(a[1].ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		var got []int
+		query := PageQuery{Limit: 2}
+		for {
+			page, err := repos.Posts.List(query)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if len(page) == 0 {
+				break
+			}
+			got = append(got, postIDs(page)...)
+			last := page[len(page)-1]
+			query.Cursor = &Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+		}
+
+		want := []int{b[1].ID, b[0].ID, a[0].ID}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("List() pages = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Hashtags and mentions are saved, replaced on update and hidden while deleted", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 3)
+		entities := func(tags []string, mentioned ...models.User) models.PostEntities {
+			var e models.PostEntities
+			for _, tag := range tags {
+				e.Hashtags = append(e.Hashtags, models.HashtagEntity{Tag: tag})
+			}
+			for _, user := range mentioned {
+				e.Mentions = append(e.Mentions, models.MentionEntity{UserID: user.ID, Name: user.Name})
+			}
+			return e
+		}
+
+		// Repeated tags and mentions are stored once
+		first := models.Post{UserID: users[0].ID, Content: "first", CreatedAt: contractTime,
+			Entities: entities([]string{"go", "go", "한글"}, users[1], users[1])}
+		second := models.Post{UserID: users[1].ID, Content: "second", CreatedAt: contractTime.Add(time.Minute),
+			Entities: entities([]string{"go"}, users[2])}
+		for _, post := range []*models.Post{&first, &second} {
+			if err := repos.Posts.Create(post); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+
+		byHashtag := func(tag string) []int {
+			t.Helper()
+			posts, err := repos.Posts.GetByHashtag(tag, PageQuery{})
+			if err != nil {
+				t.Fatalf("GetByHashtag() error = %v", err)
+			}
+			return postIDs(posts)
+		}
+		byMention := func(userID int) []int {
+			t.Helper()
+			posts, err := repos.Posts.GetByMention(userID, PageQuery{})
+			if err != nil {
+				t.Fatalf("GetByMention() error = %v", err)
+			}
+			return postIDs(posts)
+		}
+
+		if got := byHashtag("go"); fmt.Sprint(got) != fmt.Sprint([]int{second.ID, first.ID}) {
+			t.Errorf("GetByHashtag(go) = %v, want [%d %d]", got, second.ID, first.ID)
+		}
+		if got := byHashtag("한글"); fmt.Sprint(got) != fmt.Sprint([]int{first.ID}) {
+			t.Errorf("GetByHashtag(한글) = %v, want [%d]", got, first.ID)
+		}
+		if got := byMention(users[1].ID); fmt.Sprint(got) != fmt.Sprint([]int{first.ID}) {
+			t.Errorf("GetByMention() = %v, want [%d]", got, first.ID)
+		}
+		page, err := repos.Posts.GetByHashtag("go", PageQuery{Limit: 1})
+		if err != nil || fmt.Sprint(postIDs(page)) != fmt.Sprint([]int{second.ID}) {
+			t.Errorf("GetByHashtag(go, limit 1) = (%v, %v), want [%d]", postIDs(page), err, second.ID)
+		}
+
+		mentions, err := repos.Posts.GetMentionsByPostIDs([]int{first.ID, second.ID, second.ID + 100})
+		if err != nil {
+			t.Fatalf("GetMentionsByPostIDs() error = %v", err)
+		}
+		if len(mentions) != 2 || len(mentions[first.ID]) != 1 || mentions[first.ID][0].UserID != users[1].ID || mentions[first.ID][0].Name != "user2" {
+			t.Errorf("GetMentionsByPostIDs() = %v, want one mention of user2 on the first post", mentions)
+		}
+
+		// Update replaces the entities
+		first.Content = "edited"
+		first.Entities = entities([]string{"rust"}, users[2])
+		if err := repos.Posts.Update(&first); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if got := byHashtag("go"); fmt.Sprint(got) != fmt.Sprint([]int{second.ID}) {
+			t.Errorf("after Update() GetByHashtag(go) = %v, want [%d]", got, second.ID)
+		}
+		if got := byHashtag("rust"); fmt.Sprint(got) != fmt.Sprint([]int{first.ID}) {
+			t.Errorf("after Update() GetByHashtag(rust) = %v, want [%d]", got, first.ID)
+		}
+		if got := byMention(users[1].ID); len(got) != 0 {
+			t.Errorf("after Update() GetByMention() = %v, want none", got)
+		}
+
+		// Deleted posts are hidden until restored
+		if err := repos.Posts.Delete(second.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if got := byMention(users[2].ID); fmt.Sprint(got) != fmt.Sprint([]int{first.ID}) {
+			t.Errorf("after Delete() GetByMention() = %v, want [%d]", got, first.ID)
+		}
+		if err := repos.Posts.Restore(second.ID); err != nil {
+			t.Fatalf("Restore() error = %v", err)
+		}
+		if got := byMention(users[2].ID); fmt.Sprint(got) != fmt.Sprint([]int{second.ID, first.ID}) {
+			t.Errorf("after Restore() GetByMention() = %v, want [%d %d]", got, second.ID, first.ID)
+		}
+
+		// Entities are not loaded by reads
+		if got, _ := repos.Posts.GetByID(first.ID); len(got.Entities.Hashtags) != 0 {
+			t.Errorf("GetByID() Entities = %+v, want none", got.Entities)
+		}
+	})
+
+	t.Run("CountByUserID", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 2)
+		createPosts(t, repos.Posts, users[0].ID, 3, contractTime)
+
+		for userID, want := range map[int]int{users[0].ID: 3, users[1].ID: 0} {
+			got, err := repos.Posts.CountByUserID(userID)
+			if err != nil || got != want {
+				t.Errorf("CountByUserID(%d) = (%d, %v), want %d", userID, got, err, want)
+			}
+		}
+	})
+
+	t.Run("PostWithUserReader", func(t *testing.T) {
+		repos := newRepos(t)
+		reader, ok := repos.Posts.(PostWithUserReader)
+		if !ok {
+			t.Skip("repository does not implement PostWithUserReader")
+		}
+
+		users := createUsers(t, repos.Users, 2)
+		a := createPosts(t, repos.Posts, users[0].ID, 1, contractTime)
+		b := createPosts(t, repos.Posts, users[1].ID, 1, contractTime.Add(time.Second))
+
+		byID, err := reader.GetWithUsersByIDs([]int{a[0].ID})
+		if err != nil {
+			t.Fatalf("GetWithUsersByIDs() error = %v", err)
+		}
+		if len(byID) != 1 || byID[0].UserName != "user1" || byID[0].Content != a[0].Content {
+			t.Errorf("GetWithUsersByIDs() = %+v, want post by user1", byID)
+		}
+
+		byUser, err := reader.GetWithUsersByUserIDs([]int{users[0].ID, users[1].ID}, PageQuery{Limit: 1})
+		if err != nil {
+			t.Fatalf("GetWithUsersByUserIDs() error = %v", err)
+		}
+		if len(byUser) != 1 || byUser[0].ID != b[0].ID || byUser[0].UserName != "user2" {
+			t.Errorf("GetWithUsersByUserIDs() = %+v, want newest post by user2", byUser)
+		}
+	})
+
+	t.Run("GetReplies, CountReplies and Delete keeps replies", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 2)
+		parent := createPosts(t, repos.Posts, users[0].ID, 1, contractTime)[0]
+
+		replies := make([]models.Post, 3)
+		for i := range replies {
+			replies[i] = models.Post{
+				UserID:          users[1].ID,
+				Content:         fmt.Sprintf("reply %d", i+1),
+				InReplyToPostID: &parent.ID,
+				CreatedAt:       contractTime.Add(time.Duration(i+1) * time.Minute),
+			}
+			if err := repos.Posts.Create(&replies[i]); err != nil {
+				t.Fatalf("Create reply error = %v", err)
+			}
+		}
+		nested := models.Post{UserID: users[0].ID, Content: "nested", InReplyToPostID: &replies[0].ID, CreatedAt: contractTime.Add(time.Hour)}
+		if err := repos.Posts.Create(&nested); err != nil {
+			t.Fatalf("Create nested reply error = %v", err)
+		}
+
+		page, err := repos.Posts.GetReplies(parent.ID, PageQuery{Limit: 2})
+		if err != nil {
+			t.Fatalf("GetReplies() error = %v", err)
+		}
+		if len(page) != 2 || page[0].ID != replies[2].ID || page[1].ID != replies[1].ID {
+			t.Fatalf("GetReplies() first page = %v, want replies 3, 2", postIDs(page))
+		}
+		if page[0].InReplyToPostID == nil || *page[0].InReplyToPostID != parent.ID {
+			t.Errorf("GetReplies() InReplyToPostID = %v, want %d", page[0].InReplyToPostID, parent.ID)
+		}
+
+		counts, err := repos.Posts.CountReplies([]int{parent.ID, replies[0].ID, replies[1].ID})
+		if err != nil {
+			t.Fatalf("CountReplies() error = %v", err)
+		}
+		want := map[int]int{parent.ID: 3, replies[0].ID: 1}
+		if fmt.Sprint(counts) != fmt.Sprint(want) {
+			t.Errorf("CountReplies() = %v, want %v", counts, want)
+		}
+
+		// Deleting a reply removes it from its parent's replies
+		if err := repos.Posts.Delete(replies[2].ID); err != nil {
+			t.Fatalf("Delete() reply error = %v", err)
+		}
+		rest, err := repos.Posts.GetReplies(parent.ID, PageQuery{})
+		if err != nil || len(rest) != 2 {
+			t.Errorf("GetReplies() after deleting a reply = (%v, %v), want 2 replies", postIDs(rest), err)
+		}
+
+		// Deleting the parent keeps its replies, still referring to it
+		if err := repos.Posts.Delete(parent.ID); err != nil {
+			t.Fatalf("Delete() parent error = %v", err)
+		}
+		kept, err := repos.Posts.GetByID(replies[0].ID)
+		if err != nil {
+			t.Fatalf("GetByID() reply error = %v", err)
+		}
+		if kept.InReplyToPostID == nil || *kept.InReplyToPostID != parent.ID {
+			t.Errorf("InReplyToPostID = %v after deleting the parent, want %d", kept.InReplyToPostID, parent.ID)
+		}
+		if nested, err := repos.Posts.GetByID(nested.ID); err != nil || nested.InReplyToPostID == nil || *nested.InReplyToPostID != replies[0].ID {
+			t.Errorf("GetByID() nested reply = (%+v, %v), want it still attached to reply 1", nested, err)
+		}
+	})
+
+	t.Run("Reposts are unique per user and deleted and restored with the original", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 2)
+		original := createPosts(t, repos.Posts, users[0].ID, 1, contractTime)[0]
+		if original.Kind != models.PostKindPost {
+			t.Errorf("Create() Kind = %q, want %q", original.Kind, models.PostKindPost)
+		}
+
+		share := func(kind models.PostKind, content string) (models.Post, error) {
+			post := models.Post{UserID: users[1].ID, Kind: kind, Content: content, OriginalPostID: &original.ID, CreatedAt: contractTime.Add(time.Minute)}
+			err := repos.Posts.Create(&post)
+			return post, err
+		}
+
+		repost, err := share(models.PostKindRepost, "")
+		if err != nil {
+			t.Fatalf("Create repost error = %v", err)
+		}
+		if _, err := share(models.PostKindRepost, ""); !errors.Is(err, ErrRepostExists) {
+			t.Errorf("Create() second repost error = %v, want %v", err, ErrRepostExists)
+		}
+		quotes := make([]models.Post, 2)
+		for i := range quotes {
+			if quotes[i], err = share(models.PostKindQuote, fmt.Sprintf("quote %d", i+1)); err != nil {
+				t.Fatalf("Create quote error = %v", err)
+			}
+		}
+
+		found, err := repos.Posts.GetRepost(users[1].ID, original.ID)
+		if err != nil || found.ID != repost.ID {
+			t.Errorf("GetRepost() = (%d, %v), want %d", found.ID, err, repost.ID)
+		}
+		if _, err := repos.Posts.GetRepost(users[0].ID, original.ID); !errors.Is(err, ErrPostNotFound) {
+			t.Errorf("GetRepost() without a repost error = %v, want %v", err, ErrPostNotFound)
+		}
+		reposts, err := repos.Posts.GetReposts(original.ID)
+		if err != nil || len(reposts) != 1 || reposts[0].ID != repost.ID {
+			t.Errorf("GetReposts() = (%v, %v), want only the repost", postIDs(reposts), err)
+		}
+		byUsers, err := repos.Posts.GetRepostsByUserIDs([]int{original.ID, quotes[0].ID}, []int{users[1].ID})
+		if err != nil || len(byUsers) != 1 || byUsers[0].ID != repost.ID {
+			t.Errorf("GetRepostsByUserIDs() = (%v, %v), want only the repost", postIDs(byUsers), err)
+		}
+		if byOthers, err := repos.Posts.GetRepostsByUserIDs([]int{original.ID}, []int{users[0].ID}); err != nil || len(byOthers) != 0 {
+			t.Errorf("GetRepostsByUserIDs() by another user = (%v, %v), want none", postIDs(byOthers), err)
+		}
+
+		if err := repos.Posts.Delete(original.ID); err != nil {
+			t.Fatalf("Delete() original error = %v", err)
+		}
+		if _, err := repos.Posts.GetByID(repost.ID); !errors.Is(err, ErrPostNotFound) {
+			t.Errorf("GetByID() repost after deleting the original error = %v, want %v", err, ErrPostNotFound)
+		}
+		kept, err := repos.Posts.GetByID(quotes[0].ID)
+		if err != nil {
+			t.Fatalf("GetByID() quote error = %v", err)
+		}
+		if kept.OriginalPostID == nil || *kept.OriginalPostID != original.ID || kept.Content != "quote 1" {
+			t.Errorf("GetByID() quote after deleting the original = %+v, want it kept", kept)
+		}
+		if n, err := repos.Posts.CountByUserID(users[1].ID); err != nil || n != 2 {
+			t.Errorf("CountByUserID() = (%d, %v), want the 2 quotes", n, err)
+		}
+
+		// Restoring the original restores the reposts deleted with it
+		if err := repos.Posts.Restore(original.ID); err != nil {
+			t.Fatalf("Restore() original error = %v", err)
+		}
+		if found, err := repos.Posts.GetRepost(users[1].ID, original.ID); err != nil || found.ID != repost.ID {
+			t.Errorf("GetRepost() after Restore = (%d, %v), want %d", found.ID, err, repost.ID)
+		}
+
+		// An undone repost no longer blocks reposting again
+		if err := repos.Posts.Delete(repost.ID); err != nil {
+			t.Fatalf("Delete() repost error = %v", err)
+		}
+		if byUsers, err := repos.Posts.GetRepostsByUserIDs([]int{original.ID}, []int{users[1].ID}); err != nil || len(byUsers) != 0 {
+			t.Errorf("GetRepostsByUserIDs() after undoing the repost = (%v, %v), want none", postIDs(byUsers), err)
+		}
+		if _, err := share(models.PostKindRepost, ""); err != nil {
+			t.Errorf("Create() repost after undoing one error = %v", err)
+		}
+	})
+
+	t.Run("Restore keeps a repost deleted when it was reposted again", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 2)
+		original := createPosts(t, repos.Posts, users[0].ID, 1, contractTime)[0]
+		repost := func() models.Post {
+			t.Helper()
+			post := models.Post{UserID: users[1].ID, Kind: models.PostKindRepost, OriginalPostID: &original.ID, CreatedAt: contractTime.Add(time.Minute)}
+			if err := repos.Posts.Create(&post); err != nil {
+				t.Fatalf("Create repost error = %v", err)
+			}
+			return post
+		}
+
+		// A repost that raced the delete replaces the one deleted with the original
+		first := repost()
+		if err := repos.Posts.Delete(original.ID); err != nil {
+			t.Fatalf("Delete() original error = %v", err)
+		}
+		second := repost()
+
+		if err := repos.Posts.Restore(original.ID); err != nil {
+			t.Fatalf("Restore() original error = %v", err)
+		}
+		if reposts, err := repos.Posts.GetReposts(original.ID); err != nil || len(reposts) != 1 || reposts[0].ID != second.ID {
+			t.Errorf("GetReposts() after Restore = (%v, %v), want only %d", postIDs(reposts), err, second.ID)
+		}
+		if _, err := repos.Posts.GetDeletedByID(first.ID); err != nil {
+			t.Errorf("GetDeletedByID() replaced repost error = %v, want it still deleted", err)
+		}
+	})
+}
+
+// RunFollowRepositoryContract checks the behavior every FollowRepository must share
+func RunFollowRepositoryContract(t *testing.T, newRepos RepositoryFactory) {
+	follow := func(t *testing.T, repo FollowRepository, userID, followUserID int, at time.Time) {
+		t.Helper()
+		if err := repo.Create(models.Follow{UserID: userID, FollowUserID: followUserID, CreatedAt: at}); err != nil {
+			t.Fatalf("Create follow error = %v", err)
+		}
+	}
+
+	t.Run("Create, Exists and Delete", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 2)
+
+		follow(t, repos.Follows, users[0].ID, users[1].ID, contractTime)
+		if !repos.Follows.Exists(users[0].ID, users[1].ID) {
+			t.Error("Exists() = false after Create")
+		}
+		if repos.Follows.Exists(users[1].ID, users[0].ID) {
+			t.Error("Exists() is not directional")
+		}
+
+		err := repos.Follows.Create(models.Follow{UserID: users[0].ID, FollowUserID: users[1].ID, CreatedAt: contractTime})
+		if !errors.Is(err, ErrFollowExists) {
+			t.Errorf("Create() duplicate error = %v, want %v", err, ErrFollowExists)
+		}
+
+		if err := repos.Follows.Delete(users[0].ID, users[1].ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if repos.Follows.Exists(users[0].ID, users[1].ID) {
+			t.Error("Exists() = true after Delete")
+		}
+		if err := repos.Follows.Delete(users[0].ID, users[1].ID); !errors.Is(err, ErrFollowNotFound) {
+			t.Errorf("Delete() twice error = %v, want %v", err, ErrFollowNotFound)
+		}
+	})
+
+	t.Run("GetFollowers and GetFollowing page newest first", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 4)
+		target := users[0].ID
+
+		// users 2 and 3 follow at the same instant; user 4 follows later
+		follow(t, repos.Follows, users[1].ID, target, contractTime)
+		follow(t, repos.Follows, users[2].ID, target, contractTime)
+		follow(t, repos.Follows, users[3].ID, target, contractTime.Add(time.Minute))
+		follow(t, repos.Follows, target, users[1].ID, contractTime)
+		follow(t, repos.Follows, target, users[3].ID, contractTime.Add(time.Minute))
+
+		followers, err := repos.Follows.GetFollowers(target, PageQuery{Limit: 2})
+		if err != nil {
+			t.Fatalf("GetFollowers() error = %v", err)
+		}
+		if len(followers) != 2 || followers[0].UserID != users[3].ID || followers[1].UserID != users[2].ID {
+			t.Fatalf("GetFollowers() first page = %+v, want users 4, 3", followers)
+		}
+
+		last := followers[len(followers)-1]
+		rest, err := repos.Follows.GetFollowers(target, PageQuery{Limit: 2, Cursor: &Cursor{CreatedAt: last.CreatedAt, ID: last.UserID}})
+		if err != nil {
+			t.Fatalf("GetFollowers() error = %v", err)
+		}
+		if len(rest) != 1 || rest[0].UserID != users[1].ID {
+			t.Errorf("GetFollowers() second page = %+v, want user 2", rest)
+		}
+
+		following, err := repos.Follows.GetFollowing(target, PageQuery{})
+		if err != nil {
+			t.Fatalf("GetFollowing() error = %v", err)
+		}
+		if len(following) != 2 || following[0].FollowUserID != users[3].ID || following[1].FollowUserID != users[1].ID {
+			t.Errorf("GetFollowing() = %+v, want users 4, 2", following)
+		}
+
+		none, err := repos.Follows.GetFollowers(users[2].ID, PageQuery{})
+		if err != nil || len(none) != 0 {
+			t.Errorf("GetFollowers(no followers) = (%v, %v), want empty", none, err)
+		}
+	})
+
+	t.Run("CountFollowers and CountFollowing", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 3)
+
+		follow(t, repos.Follows, users[1].ID, users[0].ID, contractTime)
+		follow(t, repos.Follows, users[2].ID, users[0].ID, contractTime)
+		follow(t, repos.Follows, users[0].ID, users[1].ID, contractTime)
+
+		counts, err := repos.Follows.CountFollowers([]int{users[0].ID, users[1].ID, users[2].ID})
+		if err != nil {
+			t.Fatalf("CountFollowers() error = %v", err)
+		}
+		want := map[int]int{users[0].ID: 2, users[1].ID: 1}
+		if fmt.Sprint(counts) != fmt.Sprint(want) {
+			t.Errorf("CountFollowers() = %v, want %v", counts, want)
+		}
+
+		empty, err := repos.Follows.CountFollowers(nil)
+		if err != nil || len(empty) != 0 {
+			t.Errorf("CountFollowers(nil) = (%v, %v), want empty", empty, err)
+		}
+
+		for userID, want := range map[int]int{users[0].ID: 1, users[1].ID: 1, users[2].ID: 1} {
+			got, err := repos.Follows.CountFollowing(userID)
+			if err != nil || got != want {
+				t.Errorf("CountFollowing(%d) = (%d, %v), want %d", userID, got, err, want)
+			}
+		}
+	})
+}
+
+// RunLikeRepositoryContract checks the behavior every LikeRepository must share
+func RunLikeRepositoryContract(t *testing.T, newRepos RepositoryFactory) {
+	createPost := func(t *testing.T, repo PostRepository, userID int) models.Post {
+		t.Helper()
+		post := models.Post{UserID: userID, Content: "post", CreatedAt: contractTime}
+		if err := repo.Create(&post); err != nil {
+			t.Fatalf("Create post error = %v", err)
+		}
+		return post
+	}
+	like := func(t *testing.T, repo LikeRepository, userID, postID int, at time.Time) {
+		t.Helper()
+		if err := repo.Create(models.Like{UserID: userID, PostID: postID, CreatedAt: at}); err != nil {
+			t.Fatalf("Create like error = %v", err)
+		}
+	}
+
+	t.Run("Create and Delete", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 1)
+		post := createPost(t, repos.Posts, users[0].ID)
+
+		like(t, repos.Likes, users[0].ID, post.ID, contractTime)
+		err := repos.Likes.Create(models.Like{UserID: users[0].ID, PostID: post.ID, CreatedAt: contractTime})
+		if !errors.Is(err, ErrLikeExists) {
+			t.Errorf("Create() duplicate error = %v, want %v", err, ErrLikeExists)
+		}
+
+		if err := repos.Likes.Delete(users[0].ID, post.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if err := repos.Likes.Delete(users[0].ID, post.ID); !errors.Is(err, ErrLikeNotFound) {
+			t.Errorf("Delete() twice error = %v, want %v", err, ErrLikeNotFound)
+		}
+	})
+
+	t.Run("GetByPostID pages newest first", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 3)
+		post := createPost(t, repos.Posts, users[0].ID)
+		other := createPost(t, repos.Posts, users[0].ID)
+
+		// users 1 and 2 like at the same instant; user 3 likes later
+		like(t, repos.Likes, users[0].ID, post.ID, contractTime)
+		like(t, repos.Likes, users[1].ID, post.ID, contractTime)
+		like(t, repos.Likes, users[2].ID, post.ID, contractTime.Add(time.Minute))
+		like(t, repos.Likes, users[2].ID, other.ID, contractTime)
+
+		likes, err := repos.Likes.GetByPostID(post.ID, PageQuery{Limit: 2})
+		if err != nil {
+			t.Fatalf("GetByPostID() error = %v", err)
+		}
+		if len(likes) != 2 || likes[0].UserID != users[2].ID || likes[1].UserID != users[1].ID {
+			t.Fatalf("GetByPostID() first page = %+v, want users 3, 2", likes)
+		}
+
+		last := likes[len(likes)-1]
+		rest, err := repos.Likes.GetByPostID(post.ID, PageQuery{Limit: 2, Cursor: &Cursor{CreatedAt: last.CreatedAt, ID: last.UserID}})
+		if err != nil {
+			t.Fatalf("GetByPostID() error = %v", err)
+		}
+		if len(rest) != 1 || rest[0].UserID != users[0].ID || rest[0].PostID != post.ID {
+			t.Errorf("GetByPostID() second page = %+v, want user 1", rest)
+		}
+	})
+
+	t.Run("CountByPostIDs and LikedPostIDs", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 2)
+		posts := []models.Post{
+			createPost(t, repos.Posts, users[0].ID),
+			createPost(t, repos.Posts, users[0].ID),
+			createPost(t, repos.Posts, users[1].ID),
+		}
+
+		like(t, repos.Likes, users[0].ID, posts[0].ID, contractTime)
+		like(t, repos.Likes, users[1].ID, posts[0].ID, contractTime)
+		like(t, repos.Likes, users[1].ID, posts[1].ID, contractTime)
+
+		ids := []int{posts[0].ID, posts[1].ID, posts[2].ID}
+		counts, err := repos.Likes.CountByPostIDs(ids)
+		if err != nil {
+			t.Fatalf("CountByPostIDs() error = %v", err)
+		}
+		want := map[int]int{posts[0].ID: 2, posts[1].ID: 1}
+		if fmt.Sprint(counts) != fmt.Sprint(want) {
+			t.Errorf("CountByPostIDs() = %v, want %v", counts, want)
+		}
+
+		liked, err := repos.Likes.LikedPostIDs(users[0].ID, ids)
+		if err != nil {
+			t.Fatalf("LikedPostIDs() error = %v", err)
+		}
+		if fmt.Sprint(liked) != fmt.Sprint(map[int]bool{posts[0].ID: true}) {
+			t.Errorf("LikedPostIDs() = %v, want only post %d", liked, posts[0].ID)
+		}
+
+		empty, err := repos.Likes.CountByPostIDs(nil)
+		if err != nil || len(empty) != 0 {
+			t.Errorf("CountByPostIDs(nil) = (%v, %v), want empty", empty, err)
+		}
+		none, err := repos.Likes.LikedPostIDs(users[0].ID, nil)
+		if err != nil || len(none) != 0 {
+			t.Errorf("LikedPostIDs(nil) = (%v, %v), want empty", none, err)
+		}
+	})
+}
+
+// RunNotificationRepositoryContract checks the behavior every NotificationRepository must share
+func RunNotificationRepositoryContract(t *testing.T, newRepos RepositoryFactory) {
+	notify := func(t *testing.T, repo NotificationRepository, n models.Notification) models.Notification {
+		t.Helper()
+		if err := repo.Create(&n); err != nil {
+			t.Fatalf("Create notification error = %v", err)
+		}
+		if n.ID == 0 {
+			t.Fatal("Create() did not assign an ID")
+		}
+		return n
+	}
+	ids := func(notifications []models.Notification) []int {
+		ids := make([]int, len(notifications))
+		for i, n := range notifications {
+			ids[i] = n.ID
+		}
+		return ids
+	}
+
+	t.Run("GetByUserID pages newest first", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 3)
+		post := models.Post{UserID: users[0].ID, Content: "post", CreatedAt: contractTime}
+		if err := repos.Posts.Create(&post); err != nil {
+			t.Fatalf("Create post error = %v", err)
+		}
+
+		// Two notifications at the same instant, one later, and one for another user
+		follow := notify(t, repos.Notifications, models.Notification{UserID: users[0].ID, ActorID: users[1].ID, Type: models.NotificationFollow, CreatedAt: contractTime})
+		like := notify(t, repos.Notifications, models.Notification{UserID: users[0].ID, ActorID: users[1].ID, Type: models.NotificationLike, PostID: &post.ID, CreatedAt: contractTime})
+		later := notify(t, repos.Notifications, models.Notification{UserID: users[0].ID, ActorID: users[2].ID, Type: models.NotificationLike, PostID: &post.ID, CreatedAt: contractTime.Add(time.Minute)})
+		notify(t, repos.Notifications, models.Notification{UserID: users[1].ID, ActorID: users[0].ID, Type: models.NotificationFollow, CreatedAt: contractTime})
+
+		page, err := repos.Notifications.GetByUserID(users[0].ID, PageQuery{Limit: 2})
+		if err != nil {
+			t.Fatalf("GetByUserID() error = %v", err)
+		}
+		if fmt.Sprint(ids(page)) != fmt.Sprint([]int{later.ID, like.ID}) {
+			t.Fatalf("GetByUserID() first page = %v, want [%d %d]", ids(page), later.ID, like.ID)
+		}
+		if got := page[1]; got.ActorID != users[1].ID || got.Type != models.NotificationLike || got.PostID == nil || *got.PostID != post.ID || got.ReadAt != nil {
+			t.Errorf("GetByUserID() like = %+v", got)
+		}
+
+		last := page[len(page)-1]
+		rest, err := repos.Notifications.GetByUserID(users[0].ID, PageQuery{Limit: 2, Cursor: &Cursor{CreatedAt: last.CreatedAt, ID: last.ID}})
+		if err != nil {
+			t.Fatalf("GetByUserID() error = %v", err)
+		}
+		if len(rest) != 1 || rest[0].ID != follow.ID || rest[0].PostID != nil {
+			t.Errorf("GetByUserID() second page = %+v, want the follow", rest)
+		}
+	})
+
+	t.Run("MarkRead and CountUnread", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 2)
+
+		var mine []models.Notification
+		for i := 0; i < 3; i++ {
+			mine = append(mine, notify(t, repos.Notifications, models.Notification{UserID: users[0].ID, ActorID: users[1].ID, Type: models.NotificationFollow, CreatedAt: contractTime}))
+		}
+		theirs := notify(t, repos.Notifications, models.Notification{UserID: users[1].ID, ActorID: users[0].ID, Type: models.NotificationFollow, CreatedAt: contractTime})
+
+		if n, err := repos.Notifications.CountUnread(users[0].ID); err != nil || n != 3 {
+			t.Fatalf("CountUnread() = (%d, %v), want 3", n, err)
+		}
+
+		// Another user's notification is ignored
+		marked, err := repos.Notifications.MarkRead(users[0].ID, []int{mine[0].ID, theirs.ID}, contractTime)
+		if err != nil || marked != 1 {
+			t.Fatalf("MarkRead() = (%d, %v), want 1", marked, err)
+		}
+		if n, _ := repos.Notifications.CountUnread(users[1].ID); n != 1 {
+			t.Errorf("CountUnread() of the other user = %d, want 1", n)
+		}
+
+		// Marking all skips notifications already read
+		marked, err = repos.Notifications.MarkRead(users[0].ID, nil, contractTime.Add(time.Minute))
+		if err != nil || marked != 2 {
+			t.Fatalf("MarkRead(all) = (%d, %v), want 2", marked, err)
+		}
+		if n, err := repos.Notifications.CountUnread(users[0].ID); err != nil || n != 0 {
+			t.Errorf("CountUnread() after MarkRead = (%d, %v), want 0", n, err)
+		}
+
+		page, err := repos.Notifications.GetByUserID(users[0].ID, PageQuery{Limit: 10})
+		if err != nil {
+			t.Fatalf("GetByUserID() error = %v", err)
+		}
+		for _, n := range page {
+			want := contractTime.Add(time.Minute)
+			if n.ID == mine[0].ID {
+				want = contractTime
+			}
+			if n.ReadAt == nil || !n.ReadAt.Equal(want) {
+				t.Errorf("notification %d read_at = %v, want %v", n.ID, n.ReadAt, want)
+			}
+		}
+	})
+
+	t.Run("Delete and DeleteByPostID", func(t *testing.T) {
+		repos := newRepos(t)
+		users := createUsers(t, repos.Users, 2)
+		posts := make([]models.Post, 2)
+		for i := range posts {
+			posts[i] = models.Post{UserID: users[0].ID, Content: "post", CreatedAt: contractTime}
+			if err := repos.Posts.Create(&posts[i]); err != nil {
+				t.Fatalf("Create post error = %v", err)
+			}
+		}
+
+		follow := notify(t, repos.Notifications, models.Notification{UserID: users[0].ID, ActorID: users[1].ID, Type: models.NotificationFollow, CreatedAt: contractTime})
+		notify(t, repos.Notifications, models.Notification{UserID: users[0].ID, ActorID: users[1].ID, Type: models.NotificationLike, PostID: &posts[0].ID, CreatedAt: contractTime})
+		notify(t, repos.Notifications, models.Notification{UserID: users[0].ID, ActorID: users[1].ID, Type: models.NotificationMention, PostID: &posts[0].ID, CreatedAt: contractTime})
+		kept := notify(t, repos.Notifications, models.Notification{UserID: users[0].ID, ActorID: users[1].ID, Type: models.NotificationLike, PostID: &posts[1].ID, CreatedAt: contractTime})
+
+		// Delete matches the post exactly; 0 matches only notifications without one
+		if err := repos.Notifications.Delete(users[0].ID, users[1].ID, models.NotificationLike, 0); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if err := repos.Notifications.Delete(users[0].ID, users[1].ID, models.NotificationFollow, 0); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if err := repos.Notifications.DeleteByPostID(posts[0].ID); err != nil {
+			t.Fatalf("DeleteByPostID() error = %v", err)
+		}
+
+		page, err := repos.Notifications.GetByUserID(users[0].ID, PageQuery{Limit: 10})
+		if err != nil {
+			t.Fatalf("GetByUserID() error = %v", err)
+		}
+		if fmt.Sprint(ids(page)) != fmt.Sprint([]int{kept.ID}) {
+			t.Errorf("GetByUserID() after deletes = %v, want only %d (follow was %d)", ids(page), kept.ID, follow.ID)
+		}
+	})
+}
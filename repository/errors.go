@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is wrapped by every "not found" error returned by repositories
+var ErrNotFound = errors.New("not found")
+
+var (
+	ErrUserNotFound   = fmt.Errorf("user %w", ErrNotFound)
+	ErrPostNotFound   = fmt.Errorf("post %w", ErrNotFound)
+	ErrFollowNotFound = fmt.Errorf("follow relationship %w", ErrNotFound)
+	ErrLikeNotFound   = fmt.Errorf("like %w", ErrNotFound)
+
+	ErrSessionNotFound      = fmt.Errorf("session %w", ErrNotFound)
+	ErrRefreshTokenNotFound = fmt.Errorf("refresh token %w", ErrNotFound)
+	ErrResetTokenNotFound   = fmt.Errorf("password reset token %w", ErrNotFound)
+)
+
+// ErrAlreadyExists is wrapped by every uniqueness violation returned by repositories
+var ErrAlreadyExists = errors.New("already exists")
+
+var (
+	ErrEmailTaken   = fmt.Errorf("email %w", ErrAlreadyExists)
+	ErrFollowExists = fmt.Errorf("follow relationship %w", ErrAlreadyExists)
+	ErrLikeExists   = fmt.Errorf("like %w", ErrAlreadyExists)
+	ErrRepostExists = fmt.Errorf("repost %w", ErrAlreadyExists)
+)
+
+// ErrRefreshTokenUsed is returned when a refresh token has already been exchanged
+var ErrRefreshTokenUsed = errors.New("refresh token already used")
+
+// ErrResetTokenUsed is returned when a password reset token has already been used
+var ErrResetTokenUsed = errors.New("password reset token already used")
@@ -1,8 +1,10 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"gorm.io/gorm"
 	"python-backend-with-go/models"
@@ -13,8 +15,10 @@ type FollowRepository interface {
 	Create(follow models.Follow) error
 	Delete(userID, followUserID int) error
 	Exists(userID, followUserID int) bool
-	GetFollowers(userID int) ([]int, error)
-	GetFollowing(userID int) ([]int, error)
+	GetFollowers(userID int, page PageQuery) ([]models.Follow, error)
+	GetFollowing(userID int, page PageQuery) ([]models.Follow, error)
+	CountFollowers(userIDs []int) (map[int]int, error)
+	CountFollowing(userID int) (int, error)
 }
 
 // GormFollowRepository implements FollowRepository using GORM
@@ -29,7 +33,11 @@ func NewGormFollowRepository(db *gorm.DB) *GormFollowRepository {
 
 // Create adds a new follow relationship
 func (r *GormFollowRepository) Create(follow models.Follow) error {
-	return r.db.Create(&follow).Error
+	err := r.db.Create(&follow).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrFollowExists
+	}
+	return err
 }
 
 // Delete removes a follow relationship
@@ -39,7 +47,7 @@ func (r *GormFollowRepository) Delete(userID, followUserID int) error {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("follow relationship not found")
+		return ErrFollowNotFound
 	}
 	return nil
 }
@@ -51,34 +59,62 @@ func (r *GormFollowRepository) Exists(userID, followUserID int) bool {
 	return count > 0
 }
 
-// GetFollowers returns the list of follower IDs for a user
-func (r *GormFollowRepository) GetFollowers(userID int) ([]int, error) {
+// GetFollowers returns a page of follow relationships pointing at a user,
+// ordered by (created_at, user_id) descending
+func (r *GormFollowRepository) GetFollowers(userID int, page PageQuery) ([]models.Follow, error) {
 	var follows []models.Follow
-	err := r.db.Where("follow_user_id = ?", userID).Find(&follows).Error
+	err := keysetPage(r.db.Where("follow_user_id = ?", userID), "created_at", "user_id", page).Find(&follows).Error
 	if err != nil {
 		return nil, err
 	}
+	return follows, nil
+}
 
-	followerIDs := make([]int, len(follows))
-	for i, follow := range follows {
-		followerIDs[i] = follow.UserID
+// GetFollowing returns a page of follow relationships created by a user,
+// ordered by (created_at, follow_user_id) descending
+func (r *GormFollowRepository) GetFollowing(userID int, page PageQuery) ([]models.Follow, error) {
+	var follows []models.Follow
+	err := keysetPage(r.db.Where("user_id = ?", userID), "created_at", "follow_user_id", page).Find(&follows).Error
+	if err != nil {
+		return nil, err
 	}
-	return followerIDs, nil
+	return follows, nil
 }
 
-// GetFollowing returns the list of following IDs for a user
-func (r *GormFollowRepository) GetFollowing(userID int) ([]int, error) {
-	var follows []models.Follow
-	err := r.db.Where("user_id = ?", userID).Find(&follows).Error
+// CountFollowers returns the number of followers of each given user in a single query.
+// Users without followers are omitted from the result.
+func (r *GormFollowRepository) CountFollowers(userIDs []int) (map[int]int, error) {
+	counts := make(map[int]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		FollowUserID int
+		Count        int
+	}
+	err := r.db.Model(&models.Follow{}).
+		Select("follow_user_id, COUNT(*) AS count").
+		Where("follow_user_id IN ?", userIDs).
+		Group("follow_user_id").
+		Scan(&rows).Error
 	if err != nil {
 		return nil, err
 	}
 
-	followingIDs := make([]int, len(follows))
-	for i, follow := range follows {
-		followingIDs[i] = follow.FollowUserID
+	for _, row := range rows {
+		counts[row.FollowUserID] = row.Count
 	}
-	return followingIDs, nil
+	return counts, nil
+}
+
+// CountFollowing returns the number of users a user follows
+func (r *GormFollowRepository) CountFollowing(userID int) (int, error) {
+	var count int64
+	if err := r.db.Model(&models.Follow{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
 }
 
 // InMemoryFollowRepository implements FollowRepository using in-memory storage
@@ -104,6 +140,13 @@ func (r *InMemoryFollowRepository) Create(follow models.Follow) error {
 	defer r.mu.Unlock()
 
 	followKey := fmt.Sprintf("%d:%d", follow.UserID, follow.FollowUserID)
+	if _, exists := r.follows[followKey]; exists {
+		return ErrFollowExists
+	}
+
+	if follow.CreatedAt.IsZero() {
+		follow.CreatedAt = time.Now()
+	}
 	r.follows[followKey] = follow
 
 	// Update indexes
@@ -127,7 +170,7 @@ func (r *InMemoryFollowRepository) Delete(userID, followUserID int) error {
 
 	followKey := fmt.Sprintf("%d:%d", userID, followUserID)
 	if _, exists := r.follows[followKey]; !exists {
-		return fmt.Errorf("follow relationship not found")
+		return ErrFollowNotFound
 	}
 
 	delete(r.follows, followKey)
@@ -153,28 +196,59 @@ func (r *InMemoryFollowRepository) Exists(userID, followUserID int) bool {
 	return exists
 }
 
-// GetFollowers returns the list of follower IDs for a user
-func (r *InMemoryFollowRepository) GetFollowers(userID int) ([]int, error) {
+// GetFollowers returns a page of follow relationships pointing at a user,
+// ordered by (created_at, user_id) descending
+func (r *InMemoryFollowRepository) GetFollowers(userID int, page PageQuery) ([]models.Follow, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	followerIDs := r.userFollowers[userID]
-	result := make([]int, 0, len(followerIDs))
+	follows := make([]models.Follow, 0, len(followerIDs))
 	for id := range followerIDs {
-		result = append(result, id)
+		follows = append(follows, r.follows[fmt.Sprintf("%d:%d", id, userID)])
 	}
-	return result, nil
+
+	return applyPage(follows, page, func(f models.Follow) (time.Time, int) {
+		return f.CreatedAt, f.UserID
+	}), nil
 }
 
-// GetFollowing returns the list of following IDs for a user
-func (r *InMemoryFollowRepository) GetFollowing(userID int) ([]int, error) {
+// GetFollowing returns a page of follow relationships created by a user,
+// ordered by (created_at, follow_user_id) descending
+func (r *InMemoryFollowRepository) GetFollowing(userID int, page PageQuery) ([]models.Follow, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	followingIDs := r.userFollowing[userID]
-	result := make([]int, 0, len(followingIDs))
+	follows := make([]models.Follow, 0, len(followingIDs))
 	for id := range followingIDs {
-		result = append(result, id)
+		follows = append(follows, r.follows[fmt.Sprintf("%d:%d", userID, id)])
+	}
+
+	return applyPage(follows, page, func(f models.Follow) (time.Time, int) {
+		return f.CreatedAt, f.FollowUserID
+	}), nil
+}
+
+// CountFollowers returns the number of followers of each given user.
+// Users without followers are omitted from the result.
+func (r *InMemoryFollowRepository) CountFollowers(userIDs []int) (map[int]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[int]int, len(userIDs))
+	for _, id := range userIDs {
+		if n := len(r.userFollowers[id]); n > 0 {
+			counts[id] = n
+		}
 	}
-	return result, nil
+	return counts, nil
+}
+
+// CountFollowing returns the number of users a user follows
+func (r *InMemoryFollowRepository) CountFollowing(userID int) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.userFollowing[userID]), nil
 }
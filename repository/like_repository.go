@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"python-backend-with-go/models"
+)
+
+// LikeRepository defines the interface for like data operations
+type LikeRepository interface {
+	Create(like models.Like) error
+	Delete(userID, postID int) error
+	GetByPostID(postID int, page PageQuery) ([]models.Like, error)
+	CountByPostIDs(postIDs []int) (map[int]int, error)
+	LikedPostIDs(userID int, postIDs []int) (map[int]bool, error)
+}
+
+// GormLikeRepository implements LikeRepository using GORM
+type GormLikeRepository struct {
+	db *gorm.DB
+}
+
+// NewGormLikeRepository creates a new GORM like repository
+func NewGormLikeRepository(db *gorm.DB) *GormLikeRepository {
+	return &GormLikeRepository{db: db}
+}
+
+// Create adds a new like
+func (r *GormLikeRepository) Create(like models.Like) error {
+	err := r.db.Create(&like).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrLikeExists
+	}
+	return err
+}
+
+// Delete removes a like
+func (r *GormLikeRepository) Delete(userID, postID int) error {
+	result := r.db.Where("user_id = ? AND tweet_id = ?", userID, postID).Delete(&models.Like{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrLikeNotFound
+	}
+	return nil
+}
+
+// GetByPostID returns a page of likes on a post, ordered by (created_at, user_id) descending
+func (r *GormLikeRepository) GetByPostID(postID int, page PageQuery) ([]models.Like, error) {
+	var likes []models.Like
+	err := keysetPage(r.db.Where("tweet_id = ?", postID), "created_at", "user_id", page).Find(&likes).Error
+	if err != nil {
+		return nil, err
+	}
+	return likes, nil
+}
+
+// CountByPostIDs returns the number of likes on each given post in a single query.
+// Posts without likes are omitted from the result.
+func (r *GormLikeRepository) CountByPostIDs(postIDs []int) (map[int]int, error) {
+	counts := make(map[int]int, len(postIDs))
+	if len(postIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		TweetID int
+		Count   int
+	}
+	err := r.db.Model(&models.Like{}).
+		Select("tweet_id, COUNT(*) AS count").
+		Where("tweet_id IN ?", postIDs).
+		Group("tweet_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.TweetID] = row.Count
+	}
+	return counts, nil
+}
+
+// LikedPostIDs returns which of the given posts a user has liked, in a single query.
+// Posts the user has not liked are omitted from the result.
+func (r *GormLikeRepository) LikedPostIDs(userID int, postIDs []int) (map[int]bool, error) {
+	liked := make(map[int]bool, len(postIDs))
+	if len(postIDs) == 0 {
+		return liked, nil
+	}
+
+	var ids []int
+	err := r.db.Model(&models.Like{}).
+		Where("user_id = ? AND tweet_id IN ?", userID, postIDs).
+		Pluck("tweet_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		liked[id] = true
+	}
+	return liked, nil
+}
+
+// InMemoryLikeRepository implements LikeRepository using in-memory storage
+type InMemoryLikeRepository struct {
+	likes     map[string]models.Like // key: "userID:postID"
+	postLikes map[int]map[int]bool   // key: postID, value: set of user IDs
+	mu        sync.RWMutex
+}
+
+// NewInMemoryLikeRepository creates a new in-memory like repository
+func NewInMemoryLikeRepository() *InMemoryLikeRepository {
+	return &InMemoryLikeRepository{
+		likes:     make(map[string]models.Like),
+		postLikes: make(map[int]map[int]bool),
+	}
+}
+
+// Create adds a new like
+func (r *InMemoryLikeRepository) Create(like models.Like) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	likeKey := fmt.Sprintf("%d:%d", like.UserID, like.PostID)
+	if _, exists := r.likes[likeKey]; exists {
+		return ErrLikeExists
+	}
+
+	if like.CreatedAt.IsZero() {
+		like.CreatedAt = time.Now()
+	}
+	r.likes[likeKey] = like
+
+	// Update index
+	if r.postLikes[like.PostID] == nil {
+		r.postLikes[like.PostID] = make(map[int]bool)
+	}
+	r.postLikes[like.PostID][like.UserID] = true
+
+	return nil
+}
+
+// Delete removes a like
+func (r *InMemoryLikeRepository) Delete(userID, postID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	likeKey := fmt.Sprintf("%d:%d", userID, postID)
+	if _, exists := r.likes[likeKey]; !exists {
+		return ErrLikeNotFound
+	}
+
+	delete(r.likes, likeKey)
+	if r.postLikes[postID] != nil {
+		delete(r.postLikes[postID], userID)
+	}
+
+	return nil
+}
+
+// GetByPostID returns a page of likes on a post, ordered by (created_at, user_id) descending
+func (r *InMemoryLikeRepository) GetByPostID(postID int, page PageQuery) ([]models.Like, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	userIDs := r.postLikes[postID]
+	likes := make([]models.Like, 0, len(userIDs))
+	for id := range userIDs {
+		likes = append(likes, r.likes[fmt.Sprintf("%d:%d", id, postID)])
+	}
+
+	return applyPage(likes, page, func(l models.Like) (time.Time, int) {
+		return l.CreatedAt, l.UserID
+	}), nil
+}
+
+// CountByPostIDs returns the number of likes on each given post.
+// Posts without likes are omitted from the result.
+func (r *InMemoryLikeRepository) CountByPostIDs(postIDs []int) (map[int]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[int]int, len(postIDs))
+	for _, id := range postIDs {
+		if n := len(r.postLikes[id]); n > 0 {
+			counts[id] = n
+		}
+	}
+	return counts, nil
+}
+
+// LikedPostIDs returns which of the given posts a user has liked.
+// Posts the user has not liked are omitted from the result.
+func (r *InMemoryLikeRepository) LikedPostIDs(userID int, postIDs []int) (map[int]bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	liked := make(map[int]bool, len(postIDs))
+	for _, id := range postIDs {
+		if r.postLikes[id][userID] {
+			liked[id] = true
+		}
+	}
+	return liked, nil
+}
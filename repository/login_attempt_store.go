@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"sync"
+	"time"
+)
+
+// LoginAttempts tracks recent failed logins for one key (an account or a client IP)
+type LoginAttempts struct {
+	Failures    int
+	LastFailure time.Time
+	LockedUntil time.Time
+}
+
+// LoginAttemptStore records failed login attempts. The operations are atomic per key
+// so a shared store (e.g. Redis) can replace the in-process one.
+type LoginAttemptStore interface {
+	// Get returns the attempts recorded for key (zero value if none)
+	Get(key string) (LoginAttempts, error)
+	// RecordFailure counts a failed attempt at the given time. Failures older than
+	// window are forgotten first. It returns the updated attempts.
+	RecordFailure(key string, at time.Time, window time.Duration) (LoginAttempts, error)
+	// Lock blocks logins for key until the given time
+	Lock(key string, until time.Time) error
+	// Reset forgets every failure and lock for key
+	Reset(key string) error
+}
+
+// loginAttemptSweepInterval is how often stale attempts are dropped from memory
+const loginAttemptSweepInterval = time.Minute
+
+// InMemoryLoginAttemptStore implements LoginAttemptStore using in-memory storage
+type InMemoryLoginAttemptStore struct {
+	attempts  map[string]LoginAttempts
+	lastSweep time.Time
+	mu        sync.Mutex
+}
+
+// NewInMemoryLoginAttemptStore creates a new in-memory login attempt store
+func NewInMemoryLoginAttemptStore() *InMemoryLoginAttemptStore {
+	return &InMemoryLoginAttemptStore{
+		attempts: make(map[string]LoginAttempts),
+	}
+}
+
+// Get returns the attempts recorded for key
+func (s *InMemoryLoginAttemptStore) Get(key string) (LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.attempts[key], nil
+}
+
+// RecordFailure counts a failed attempt, forgetting failures older than window
+func (s *InMemoryLoginAttemptStore) RecordFailure(key string, at time.Time, window time.Duration) (LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(at, window)
+
+	attempts := s.attempts[key]
+	if at.Sub(attempts.LastFailure) > window {
+		attempts.Failures = 0
+	}
+	attempts.Failures++
+	attempts.LastFailure = at
+	s.attempts[key] = attempts
+	return attempts, nil
+}
+
+// sweep drops attempts whose failures have all left the window and whose lock
+// has expired, since they are indistinguishable from no attempts; callers must
+// hold the lock
+func (s *InMemoryLoginAttemptStore) sweep(now time.Time, window time.Duration) {
+	if now.Sub(s.lastSweep) < loginAttemptSweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, attempts := range s.attempts {
+		if now.Sub(attempts.LastFailure) > window && !now.Before(attempts.LockedUntil) {
+			delete(s.attempts, key)
+		}
+	}
+}
+
+// Lock blocks logins for key until the given time
+func (s *InMemoryLoginAttemptStore) Lock(key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempts := s.attempts[key]
+	attempts.LockedUntil = until
+	s.attempts[key] = attempts
+	return nil
+}
+
+// Reset forgets every failure and lock for key
+func (s *InMemoryLoginAttemptStore) Reset(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+	return nil
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInMemoryLoginAttemptStore_SweepsStaleAttempts(t *testing.T) {
+	store := NewInMemoryLoginAttemptStore()
+	window := 15 * time.Minute
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store.RecordFailure("stale", now, window)
+	store.RecordFailure("locked", now, window)
+	store.Lock("locked", now.Add(time.Hour))
+	store.RecordFailure("recent", now.Add(window), window)
+	store.RecordFailure("new", now.Add(2*window), window)
+
+	if _, exists := store.attempts["stale"]; exists {
+		t.Error("attempts outside the window were not swept")
+	}
+	for _, key := range []string{"locked", "recent", "new"} {
+		if _, exists := store.attempts[key]; !exists {
+			t.Errorf("attempts for %q were swept", key)
+		}
+	}
+
+	// A lock that has expired no longer keeps its attempts
+	store.RecordFailure("new", now.Add(2*time.Hour), window)
+	if _, exists := store.attempts["locked"]; exists {
+		t.Error("attempts with an expired lock were not swept")
+	}
+}
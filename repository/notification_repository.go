@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"python-backend-with-go/models"
+)
+
+// NotificationRepository defines the interface for notification data operations.
+// postID 0 stands for no post, as on follow notifications.
+type NotificationRepository interface {
+	Create(notification *models.Notification) error
+	Delete(userID, actorID int, kind models.NotificationType, postID int) error
+	DeleteByPostID(postID int) error
+	GetByUserID(userID int, page PageQuery) ([]models.Notification, error)
+	CountUnread(userID int) (int, error)
+	MarkRead(userID int, ids []int, at time.Time) (int, error)
+}
+
+// GormNotificationRepository implements NotificationRepository using GORM
+type GormNotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewGormNotificationRepository creates a new GORM notification repository
+func NewGormNotificationRepository(db *gorm.DB) *GormNotificationRepository {
+	return &GormNotificationRepository{db: db}
+}
+
+// Create adds a new notification
+func (r *GormNotificationRepository) Create(notification *models.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+// Delete removes the notifications of a type that actorID caused userID about
+// a post. Deleting notifications that do not exist is not an error.
+func (r *GormNotificationRepository) Delete(userID, actorID int, kind models.NotificationType, postID int) error {
+	query := r.db.Where("user_id = ? AND actor_id = ? AND type = ?", userID, actorID, kind)
+	if postID == 0 {
+		query = query.Where("tweet_id IS NULL")
+	} else {
+		query = query.Where("tweet_id = ?", postID)
+	}
+	return query.Delete(&models.Notification{}).Error
+}
+
+// DeleteByPostID removes every notification about a post
+func (r *GormNotificationRepository) DeleteByPostID(postID int) error {
+	return r.db.Where("tweet_id = ?", postID).Delete(&models.Notification{}).Error
+}
+
+// GetByUserID returns a page of a user's notifications, ordered by (created_at, id) descending
+func (r *GormNotificationRepository) GetByUserID(userID int, page PageQuery) ([]models.Notification, error) {
+	var notifications []models.Notification
+	err := keysetPage(r.db.Where("user_id = ?", userID), "created_at", "id", page).Find(&notifications).Error
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// CountUnread returns the number of a user's unread notifications
+func (r *GormNotificationRepository) CountUnread(userID int) (int, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return int(count), err
+}
+
+// MarkRead marks the given unread notifications of a user read at a time, or
+// all of them if ids is empty, and returns how many it marked. IDs of other
+// users' notifications are ignored.
+func (r *GormNotificationRepository) MarkRead(userID int, ids []int, at time.Time) (int, error) {
+	query := r.db.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+	result := query.Update("read_at", at)
+	return int(result.RowsAffected), result.Error
+}
+
+// InMemoryNotificationRepository implements NotificationRepository using in-memory storage
+type InMemoryNotificationRepository struct {
+	notifications map[int]models.Notification // key: notification ID
+	userIndex     map[int]map[int]bool        // key: recipient user ID, value: set of notification IDs
+	nextID        int
+	mu            sync.RWMutex
+}
+
+// NewInMemoryNotificationRepository creates a new in-memory notification repository
+func NewInMemoryNotificationRepository() *InMemoryNotificationRepository {
+	return &InMemoryNotificationRepository{
+		notifications: make(map[int]models.Notification),
+		userIndex:     make(map[int]map[int]bool),
+		nextID:        1,
+	}
+}
+
+// Create adds a new notification
+func (r *InMemoryNotificationRepository) Create(notification *models.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	notification.ID = r.nextID
+	r.nextID++
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+
+	r.notifications[notification.ID] = *notification
+	addToSet(r.userIndex, notification.UserID, notification.ID)
+	return nil
+}
+
+// Delete removes the notifications of a type that actorID caused userID about
+// a post. Deleting notifications that do not exist is not an error.
+func (r *InMemoryNotificationRepository) Delete(userID, actorID int, kind models.NotificationType, postID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id := range r.userIndex[userID] {
+		n := r.notifications[id]
+		if n.ActorID == actorID && n.Type == kind && notificationPostID(n) == postID {
+			r.deleteLocked(n)
+		}
+	}
+	return nil
+}
+
+// DeleteByPostID removes every notification about a post
+func (r *InMemoryNotificationRepository) DeleteByPostID(postID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, n := range r.notifications {
+		if n.PostID != nil && *n.PostID == postID {
+			r.deleteLocked(n)
+		}
+	}
+	return nil
+}
+
+// deleteLocked removes a notification; callers must hold the write lock
+func (r *InMemoryNotificationRepository) deleteLocked(n models.Notification) {
+	delete(r.notifications, n.ID)
+	delete(r.userIndex[n.UserID], n.ID)
+}
+
+// GetByUserID returns a page of a user's notifications, ordered by (created_at, id) descending
+func (r *InMemoryNotificationRepository) GetByUserID(userID int, page PageQuery) ([]models.Notification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	notifications := make([]models.Notification, 0, len(r.userIndex[userID]))
+	for id := range r.userIndex[userID] {
+		notifications = append(notifications, r.notifications[id])
+	}
+
+	return applyPage(notifications, page, func(n models.Notification) (time.Time, int) {
+		return n.CreatedAt, n.ID
+	}), nil
+}
+
+// CountUnread returns the number of a user's unread notifications
+func (r *InMemoryNotificationRepository) CountUnread(userID int) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for id := range r.userIndex[userID] {
+		if r.notifications[id].ReadAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// MarkRead marks the given unread notifications of a user read at a time, or
+// all of them if ids is empty, and returns how many it marked. IDs of other
+// users' notifications are ignored.
+func (r *InMemoryNotificationRepository) MarkRead(userID int, ids []int, at time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(ids) == 0 {
+		for id := range r.userIndex[userID] {
+			ids = append(ids, id)
+		}
+	}
+
+	marked := 0
+	for _, id := range ids {
+		n, exists := r.notifications[id]
+		if !exists || n.UserID != userID || n.ReadAt != nil {
+			continue
+		}
+		readAt := at
+		n.ReadAt = &readAt
+		r.notifications[id] = n
+		marked++
+	}
+	return marked, nil
+}
+
+// notificationPostID returns the post a notification is about, or 0 for none
+func notificationPostID(n models.Notification) int {
+	if n.PostID == nil {
+		return 0
+	}
+	return *n.PostID
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TimestampPrecision is the precision of the created_at columns: MySQL
+// TIMESTAMP keeps whole seconds. Times are cut to it before they are saved so
+// that copies kept in process, such as timeline cache entries, and cursors
+// built from them compare equal to what the database returns.
+const TimestampPrecision = time.Second
+
+// Cursor marks a position in a list ordered by (created_at, id) descending
+type Cursor struct {
+	CreatedAt time.Time
+	ID        int
+}
+
+// PageQuery describes a keyset page: at most Limit items strictly after Cursor,
+// newest first. A zero Limit means no limit and a nil Cursor starts from the top.
+type PageQuery struct {
+	Limit  int
+	Cursor *Cursor
+}
+
+// Encode returns the opaque string form of the cursor
+func (c Cursor) Encode() string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + ":" + strconv.Itoa(c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor produced by Cursor.Encode
+func DecodeCursor(s string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid cursor")
+	}
+
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return Cursor{}, fmt.Errorf("invalid cursor")
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid cursor")
+	}
+	id, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid cursor")
+	}
+
+	return Cursor{CreatedAt: time.Unix(0, nanos), ID: id}, nil
+}
+
+// before reports whether the item at (createdAt, id) comes after the cursor
+// in (created_at, id) descending order
+func (c *Cursor) before(createdAt time.Time, id int) bool {
+	if c == nil {
+		return true
+	}
+	if createdAt.Equal(c.CreatedAt) {
+		return id < c.ID
+	}
+	return createdAt.Before(c.CreatedAt)
+}
+
+// newerFirst orders two items by (created_at, id) descending
+func newerFirst(aCreatedAt time.Time, aID int, bCreatedAt time.Time, bID int) bool {
+	if aCreatedAt.Equal(bCreatedAt) {
+		return aID > bID
+	}
+	return aCreatedAt.After(bCreatedAt)
+}
+
+// keysetPage applies (createdAtColumn, idColumn) descending keyset pagination to a GORM query
+func keysetPage(db *gorm.DB, createdAtColumn, idColumn string, page PageQuery) *gorm.DB {
+	if page.Cursor != nil {
+		db = db.Where("("+createdAtColumn+" < ? OR ("+createdAtColumn+" = ? AND "+idColumn+" < ?))",
+			page.Cursor.CreatedAt, page.Cursor.CreatedAt, page.Cursor.ID)
+	}
+	db = db.Order(createdAtColumn + " DESC").Order(idColumn + " DESC")
+	if page.Limit > 0 {
+		db = db.Limit(page.Limit)
+	}
+	return db
+}
+
+// applyPage sorts items newest first and returns the slice selected by page
+func applyPage[T any](items []T, page PageQuery, key func(T) (time.Time, int)) []T {
+	sort.Slice(items, func(i, j int) bool {
+		aCreatedAt, aID := key(items[i])
+		bCreatedAt, bID := key(items[j])
+		return newerFirst(aCreatedAt, aID, bCreatedAt, bID)
+	})
+
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		createdAt, id := key(item)
+		if !page.Cursor.before(createdAt, id) {
+			continue
+		}
+		result = append(result, item)
+		if page.Limit > 0 && len(result) == page.Limit {
+			break
+		}
+	}
+	return result
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"python-backend-with-go/models"
+)
+
+// PasswordResetRepository defines the interface for password reset token operations
+type PasswordResetRepository interface {
+	Create(token *models.PasswordResetToken) error
+	GetByHash(tokenHash string) (models.PasswordResetToken, error)
+	MarkUsed(id int, at time.Time) error
+}
+
+// GormPasswordResetRepository implements PasswordResetRepository using GORM
+type GormPasswordResetRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPasswordResetRepository creates a new GORM password reset repository
+func NewGormPasswordResetRepository(db *gorm.DB) *GormPasswordResetRepository {
+	return &GormPasswordResetRepository{db: db}
+}
+
+// Create adds a new password reset token to the database
+func (r *GormPasswordResetRepository) Create(token *models.PasswordResetToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetByHash retrieves a password reset token by its hash
+func (r *GormPasswordResetRepository) GetByHash(tokenHash string) (models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.PasswordResetToken{}, ErrResetTokenNotFound
+		}
+		return models.PasswordResetToken{}, err
+	}
+	return token, nil
+}
+
+// MarkUsed atomically marks a password reset token as used.
+// It returns ErrResetTokenUsed if the token was already used.
+func (r *GormPasswordResetRepository) MarkUsed(id int, at time.Time) error {
+	result := r.db.Model(&models.PasswordResetToken{}).Where("id = ? AND used_at IS NULL", id).Update("used_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		var count int64
+		if err := r.db.Model(&models.PasswordResetToken{}).Where("id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrResetTokenNotFound
+		}
+		return ErrResetTokenUsed
+	}
+	return nil
+}
+
+// InMemoryPasswordResetRepository implements PasswordResetRepository using in-memory storage
+type InMemoryPasswordResetRepository struct {
+	tokens      map[int]models.PasswordResetToken
+	tokenHashes map[string]int // key: token hash, value: token ID
+	nextTokenID int
+	mu          sync.RWMutex
+}
+
+// NewInMemoryPasswordResetRepository creates a new in-memory password reset repository
+func NewInMemoryPasswordResetRepository() *InMemoryPasswordResetRepository {
+	return &InMemoryPasswordResetRepository{
+		tokens:      make(map[int]models.PasswordResetToken),
+		tokenHashes: make(map[string]int),
+		nextTokenID: 1,
+	}
+}
+
+// Create adds a new password reset token to the repository
+func (r *InMemoryPasswordResetRepository) Create(token *models.PasswordResetToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token.ID = r.nextTokenID
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now()
+	}
+	r.tokens[token.ID] = *token
+	r.tokenHashes[token.TokenHash] = token.ID
+	r.nextTokenID++
+	return nil
+}
+
+// GetByHash retrieves a password reset token by its hash
+func (r *InMemoryPasswordResetRepository) GetByHash(tokenHash string) (models.PasswordResetToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, exists := r.tokenHashes[tokenHash]
+	if !exists {
+		return models.PasswordResetToken{}, ErrResetTokenNotFound
+	}
+	return r.tokens[id], nil
+}
+
+// MarkUsed atomically marks a password reset token as used.
+// It returns ErrResetTokenUsed if the token was already used.
+func (r *InMemoryPasswordResetRepository) MarkUsed(id int, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, exists := r.tokens[id]
+	if !exists {
+		return ErrResetTokenNotFound
+	}
+	if token.UsedAt != nil {
+		return ErrResetTokenUsed
+	}
+	token.UsedAt = &at
+	r.tokens[id] = token
+	return nil
+}
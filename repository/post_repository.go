@@ -1,9 +1,10 @@
 package repository
 
 import (
-	"fmt"
-	"sort"
+	"errors"
+	"slices"
 	"sync"
+	"time"
 
 	"gorm.io/gorm"
 	"python-backend-with-go/models"
@@ -15,8 +16,29 @@ type PostRepository interface {
 	Update(post *models.Post) error
 	Delete(postID int) error
 	GetByID(postID int) (models.Post, error)
-	GetByUserID(userID int) ([]models.Post, error)
-	GetByUserIDs(userIDs []int) ([]models.Post, error)
+	GetByIDs(postIDs []int) ([]models.Post, error)
+	List(page PageQuery) ([]models.Post, error)
+	GetByUserID(userID int, page PageQuery) ([]models.Post, error)
+	GetByUserIDs(userIDs []int, page PageQuery) ([]models.Post, error)
+	CountByUserID(userID int) (int, error)
+	GetReplies(postID int, page PageQuery) ([]models.Post, error)
+	CountReplies(postIDs []int) (map[int]int, error)
+	GetRepost(userID, originalPostID int) (models.Post, error)
+	GetReposts(originalPostID int) ([]models.Post, error)
+	GetRepostsByUserIDs(originalPostIDs, userIDs []int) ([]models.Post, error)
+	GetDeletedByID(postID int) (models.Post, error)
+	Restore(postID int) error
+	GetRevisions(postID int, page PageQuery) ([]models.PostRevision, error)
+	GetByHashtag(tag string, page PageQuery) ([]models.Post, error)
+	GetByMention(userID int, page PageQuery) ([]models.Post, error)
+	GetMentionsByPostIDs(postIDs []int) (map[int][]models.PostMention, error)
+}
+
+// PostWithUserReader is implemented by post repositories that can attach author
+// information in the same query instead of one user lookup per post
+type PostWithUserReader interface {
+	GetWithUsersByIDs(postIDs []int) ([]models.PostWithUser, error)
+	GetWithUsersByUserIDs(userIDs []int, page PageQuery) ([]models.PostWithUser, error)
 }
 
 // GormPostRepository implements PostRepository using GORM
@@ -29,33 +51,193 @@ func NewGormPostRepository(db *gorm.DB) *GormPostRepository {
 	return &GormPostRepository{db: db}
 }
 
-// Create adds a new post to the database
+// Create adds a new post to the database together with its hashtags and mentions
 func (r *GormPostRepository) Create(post *models.Post) error {
-	return r.db.Create(post).Error
+	if post.Kind == "" {
+		post.Kind = models.PostKindPost
+	}
+	if post.CreatedAt.IsZero() {
+		post.CreatedAt = time.Now()
+	}
+	post.CreatedAt = post.CreatedAt.Truncate(TimestampPrecision)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(post).Error; err != nil {
+			return err
+		}
+		return saveEntities(tx, *post)
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrRepostExists
+	}
+	return err
 }
 
-// Update updates an existing post in the database
+// Update updates the content of an existing post in the database, replaces its
+// hashtags and mentions, marks it edited at post.EditedAt (or now) and records
+// its previous content as a revision
 func (r *GormPostRepository) Update(post *models.Post) error {
-	result := r.db.Model(post).Where("id = ?", post.ID).Update("tweet", post.Content)
-	if result.Error != nil {
-		return result.Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current models.Post
+		if err := tx.First(&current, post.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrPostNotFound
+			}
+			return err
+		}
+
+		editedAt := time.Now()
+		if post.EditedAt != nil {
+			editedAt = *post.EditedAt
+		}
+		revision := models.PostRevision{PostID: post.ID, Content: current.Content, CreatedAt: editedAt}
+		if err := tx.Create(&revision).Error; err != nil {
+			return err
+		}
+
+		post.EditedAt = &editedAt
+		err := tx.Model(&models.Post{}).Where("id = ?", post.ID).
+			Updates(map[string]interface{}{"tweet": post.Content, "edited_at": editedAt}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("tweet_id = ?", post.ID).Delete(&models.PostHashtag{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("tweet_id = ?", post.ID).Delete(&models.PostMention{}).Error; err != nil {
+			return err
+		}
+		return saveEntities(tx, *post)
+	})
+}
+
+// saveEntities stores the hashtags and mentions of a post inside a transaction
+func saveEntities(tx *gorm.DB, post models.Post) error {
+	hashtags, mentions := entityRows(post)
+	if len(hashtags) > 0 {
+		if err := tx.Create(&hashtags).Error; err != nil {
+			return err
+		}
 	}
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("post not found")
+	if len(mentions) > 0 {
+		if err := tx.Create(&mentions).Error; err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-// Delete removes a post from the database
+// Delete soft-deletes a post together with its reposts, which share its
+// deleted_at so that Restore can bring them back. Replies and quote posts are
+// kept and still refer to it.
 func (r *GormPostRepository) Delete(postID int) error {
-	result := r.db.Delete(&models.Post{}, postID)
-	if result.Error != nil {
-		return result.Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		deletedAt := time.Now()
+		result := tx.Model(&models.Post{}).Where("id = ?", postID).Update("deleted_at", deletedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPostNotFound
+		}
+
+		return tx.Model(&models.Post{}).
+			Where("original_post_id = ? AND kind = ?", postID, models.PostKindRepost).
+			Update("deleted_at", deletedAt).Error
+	})
+}
+
+// GetDeletedByID retrieves a deleted post by ID
+func (r *GormPostRepository) GetDeletedByID(postID int) (models.Post, error) {
+	var post models.Post
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&post, postID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Post{}, ErrPostNotFound
+		}
+		return models.Post{}, err
+	}
+	return post, nil
+}
+
+// Restore brings back a deleted post together with the reposts deleted with
+// it, except those whose author has since reposted the post again
+func (r *GormPostRepository) Restore(postID int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var post models.Post
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&post, postID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrPostNotFound
+			}
+			return err
+		}
+
+		var reposted []int
+		err := tx.Model(&models.Post{}).
+			Where("original_post_id = ? AND kind = ?", postID, models.PostKindRepost).
+			Pluck("user_id", &reposted).Error
+		if err != nil {
+			return err
+		}
+
+		query := tx.Unscoped().Model(&models.Post{}).
+			Where("original_post_id = ? AND kind = ? AND deleted_at = ?", postID, models.PostKindRepost, post.DeletedAt.Time)
+		if len(reposted) > 0 {
+			query = query.Where("user_id NOT IN ?", reposted)
+		}
+		if err := query.Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&models.Post{}).Where("id = ?", postID).Update("deleted_at", nil).Error
+	})
+}
+
+// GetRevisions retrieves a page of a post's earlier versions, newest first
+func (r *GormPostRepository) GetRevisions(postID int, page PageQuery) ([]models.PostRevision, error) {
+	var revisions []models.PostRevision
+	err := keysetPage(r.db.Where("tweet_id = ?", postID), "created_at", "id", page).Find(&revisions).Error
+	if err != nil {
+		return nil, err
 	}
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("post not found")
+	return revisions, nil
+}
+
+// GetByHashtag retrieves a page of the posts tagged with a lowercased hashtag
+func (r *GormPostRepository) GetByHashtag(tag string, page PageQuery) ([]models.Post, error) {
+	var posts []models.Post
+	query := r.db.Joins("JOIN post_hashtags ON post_hashtags.tweet_id = tweets.id").Where("post_hashtags.tag = ?", tag)
+	if err := keysetPage(query, "tweets.created_at", "tweets.id", page).Find(&posts).Error; err != nil {
+		return nil, err
 	}
-	return nil
+	return posts, nil
+}
+
+// GetByMention retrieves a page of the posts mentioning a user
+func (r *GormPostRepository) GetByMention(userID int, page PageQuery) ([]models.Post, error) {
+	var posts []models.Post
+	query := r.db.Joins("JOIN post_mentions ON post_mentions.tweet_id = tweets.id").Where("post_mentions.user_id = ?", userID)
+	if err := keysetPage(query, "tweets.created_at", "tweets.id", page).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// GetMentionsByPostIDs retrieves the mentions of each given post in a single query.
+// Posts without mentions are omitted from the result.
+func (r *GormPostRepository) GetMentionsByPostIDs(postIDs []int) (map[int][]models.PostMention, error) {
+	mentions := make(map[int][]models.PostMention, len(postIDs))
+	if len(postIDs) == 0 {
+		return mentions, nil
+	}
+
+	var rows []models.PostMention
+	if err := r.db.Where("tweet_id IN ?", postIDs).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		mentions[row.PostID] = append(mentions[row.PostID], row)
+	}
+	return mentions, nil
 }
 
 // GetByID retrieves a post by ID
@@ -63,88 +245,390 @@ func (r *GormPostRepository) GetByID(postID int) (models.Post, error) {
 	var post models.Post
 	err := r.db.First(&post, postID).Error
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return models.Post{}, fmt.Errorf("post not found")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Post{}, ErrPostNotFound
 		}
 		return models.Post{}, err
 	}
 	return post, nil
 }
 
-// GetByUserID retrieves all posts by a specific user
-func (r *GormPostRepository) GetByUserID(userID int) ([]models.Post, error) {
+// GetByIDs retrieves the posts with the given IDs, skipping IDs that do not exist.
+// The result is not ordered.
+func (r *GormPostRepository) GetByIDs(postIDs []int) ([]models.Post, error) {
+	posts := make([]models.Post, 0, len(postIDs))
+	if len(postIDs) == 0 {
+		return posts, nil
+	}
+	if err := r.db.Where("id IN ?", postIDs).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// List retrieves a page of all posts, newest first
+func (r *GormPostRepository) List(page PageQuery) ([]models.Post, error) {
 	var posts []models.Post
-	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&posts).Error
+	if err := keysetPage(r.db, "created_at", "id", page).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// GetByUserID retrieves a page of posts by a specific user
+func (r *GormPostRepository) GetByUserID(userID int, page PageQuery) ([]models.Post, error) {
+	var posts []models.Post
+	err := keysetPage(r.db.Where("user_id = ?", userID), "created_at", "id", page).Find(&posts).Error
 	if err != nil {
 		return nil, err
 	}
 	return posts, nil
 }
 
-// GetByUserIDs retrieves all posts by multiple users
-func (r *GormPostRepository) GetByUserIDs(userIDs []int) ([]models.Post, error) {
+// GetByUserIDs retrieves a page of posts by multiple users
+func (r *GormPostRepository) GetByUserIDs(userIDs []int, page PageQuery) ([]models.Post, error) {
 	var posts []models.Post
-	err := r.db.Where("user_id IN ?", userIDs).Order("created_at DESC").Find(&posts).Error
+	err := keysetPage(r.db.Where("user_id IN ?", userIDs), "created_at", "id", page).Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// CountByUserID returns the number of posts by a user
+func (r *GormPostRepository) CountByUserID(userID int) (int, error) {
+	var count int64
+	if err := r.db.Model(&models.Post{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// GetReplies retrieves a page of the direct replies to a post
+func (r *GormPostRepository) GetReplies(postID int, page PageQuery) ([]models.Post, error) {
+	var posts []models.Post
+	err := keysetPage(r.db.Where("in_reply_to_post_id = ?", postID), "created_at", "id", page).Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// CountReplies returns the number of direct replies to each given post in a single query.
+// Posts without replies are omitted from the result.
+func (r *GormPostRepository) CountReplies(postIDs []int) (map[int]int, error) {
+	counts := make(map[int]int, len(postIDs))
+	if len(postIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		InReplyToPostID int
+		Count           int
+	}
+	err := r.db.Model(&models.Post{}).
+		Select("in_reply_to_post_id, COUNT(*) AS count").
+		Where("in_reply_to_post_id IN ?", postIDs).
+		Group("in_reply_to_post_id").
+		Scan(&rows).Error
 	if err != nil {
 		return nil, err
 	}
+
+	for _, row := range rows {
+		counts[row.InReplyToPostID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetRepost retrieves a user's repost of a post
+func (r *GormPostRepository) GetRepost(userID, originalPostID int) (models.Post, error) {
+	var post models.Post
+	err := r.db.Where("user_id = ? AND original_post_id = ? AND kind = ?", userID, originalPostID, models.PostKindRepost).First(&post).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Post{}, ErrPostNotFound
+		}
+		return models.Post{}, err
+	}
+	return post, nil
+}
+
+// GetReposts retrieves every repost of a post
+func (r *GormPostRepository) GetReposts(originalPostID int) ([]models.Post, error) {
+	var posts []models.Post
+	if err := r.db.Where("original_post_id = ? AND kind = ?", originalPostID, models.PostKindRepost).Find(&posts).Error; err != nil {
+		return nil, err
+	}
 	return posts, nil
 }
 
-// InMemoryPostRepository implements PostRepository using in-memory storage
+// GetRepostsByUserIDs retrieves the reposts of any of the given posts by any
+// of the given users in a single query
+func (r *GormPostRepository) GetRepostsByUserIDs(originalPostIDs, userIDs []int) ([]models.Post, error) {
+	posts := make([]models.Post, 0)
+	if len(originalPostIDs) == 0 || len(userIDs) == 0 {
+		return posts, nil
+	}
+
+	err := r.db.Where("original_post_id IN ? AND user_id IN ? AND kind = ?", originalPostIDs, userIDs, models.PostKindRepost).
+		Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// GetWithUsersByIDs retrieves the posts with the given IDs joined with their authors.
+// The result is not ordered.
+func (r *GormPostRepository) GetWithUsersByIDs(postIDs []int) ([]models.PostWithUser, error) {
+	posts := make([]models.PostWithUser, 0, len(postIDs))
+	if len(postIDs) == 0 {
+		return posts, nil
+	}
+	if err := r.withUsers().Where("tweets.id IN ?", postIDs).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// GetWithUsersByUserIDs retrieves a page of posts by multiple users joined with their authors
+func (r *GormPostRepository) GetWithUsersByUserIDs(userIDs []int, page PageQuery) ([]models.PostWithUser, error) {
+	var posts []models.PostWithUser
+	query := r.withUsers().Where("tweets.user_id IN ?", userIDs)
+	if err := keysetPage(query, "tweets.created_at", "tweets.id", page).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// withUsers starts a query over live tweets joined with their authors, selecting
+// PostWithUser columns. PostWithUser has no DeletedAt, so GORM does not filter
+// deleted posts by itself.
+func (r *GormPostRepository) withUsers() *gorm.DB {
+	return r.db.Table("tweets").
+		Select("tweets.id, tweets.user_id, users.name AS user_name, tweets.kind, tweets.tweet AS content, tweets.in_reply_to_post_id, tweets.original_post_id, tweets.created_at, tweets.edited_at").
+		Joins("JOIN users ON users.id = tweets.user_id").
+		Where("tweets.deleted_at IS NULL")
+}
+
+// InMemoryPostRepository implements PostRepository using in-memory storage.
+// Deleted posts are moved out of posts and its indexes into deleted.
 type InMemoryPostRepository struct {
-	posts      map[int]models.Post
-	userPosts  map[int][]int // key: userID, value: list of post IDs
-	nextPostID int
-	mu         sync.RWMutex
+	posts          map[int]models.Post
+	deleted        map[int]models.Post
+	userPosts      map[int][]int        // key: userID, value: list of post IDs
+	postReplies    map[int]map[int]bool // key: postID, value: set of reply IDs
+	postShares     map[int]map[int]bool // key: postID, value: set of repost and quote post IDs
+	revisions      map[int][]models.PostRevision
+	postHashtags   map[int][]string             // key: postID, value: its hashtags
+	hashtagPosts   map[string]map[int]bool      // key: hashtag, value: set of post IDs
+	postMentions   map[int][]models.PostMention // key: postID, value: its mentions
+	mentionPosts   map[int]map[int]bool         // key: userID, value: set of post IDs mentioning them
+	nextPostID     int
+	nextRevisionID int
+	mu             sync.RWMutex
 }
 
 // NewInMemoryPostRepository creates a new in-memory post repository
 func NewInMemoryPostRepository() *InMemoryPostRepository {
 	return &InMemoryPostRepository{
-		posts:      make(map[int]models.Post),
-		userPosts:  make(map[int][]int),
-		nextPostID: 1,
+		posts:          make(map[int]models.Post),
+		deleted:        make(map[int]models.Post),
+		userPosts:      make(map[int][]int),
+		postReplies:    make(map[int]map[int]bool),
+		postShares:     make(map[int]map[int]bool),
+		revisions:      make(map[int][]models.PostRevision),
+		postHashtags:   make(map[int][]string),
+		hashtagPosts:   make(map[string]map[int]bool),
+		postMentions:   make(map[int][]models.PostMention),
+		mentionPosts:   make(map[int]map[int]bool),
+		nextPostID:     1,
+		nextRevisionID: 1,
 	}
 }
 
-// Create adds a new post to the repository
+// Create adds a new post to the repository together with its hashtags and mentions
 func (r *InMemoryPostRepository) Create(post *models.Post) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if post.Kind == "" {
+		post.Kind = models.PostKindPost
+	}
+	if post.Kind == models.PostKindRepost && post.OriginalPostID != nil {
+		if _, err := r.getRepostLocked(post.UserID, *post.OriginalPostID); err == nil {
+			return ErrRepostExists
+		}
+	}
+
 	post.ID = r.nextPostID
-	r.posts[post.ID] = *post
-	r.userPosts[post.UserID] = append(r.userPosts[post.UserID], post.ID)
+	if post.CreatedAt.IsZero() {
+		post.CreatedAt = time.Now()
+	}
+	r.setEntitiesLocked(*post)
+	stored := *post
+	stored.Entities = models.PostEntities{} // Entities are not loaded by reads
+	r.addLocked(stored)
 	r.nextPostID++
 	return nil
 }
 
-// Update updates an existing post in the repository
+// Update updates the content of an existing post in the repository, replaces its
+// hashtags and mentions, marks it edited at post.EditedAt (or now) and records
+// its previous content as a revision
 func (r *InMemoryPostRepository) Update(post *models.Post) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.posts[post.ID]; !exists {
-		return fmt.Errorf("post not found")
+	stored, exists := r.posts[post.ID]
+	if !exists {
+		return ErrPostNotFound
 	}
 
-	r.posts[post.ID] = *post
+	editedAt := time.Now()
+	if post.EditedAt != nil {
+		editedAt = *post.EditedAt
+	}
+	r.revisions[post.ID] = append(r.revisions[post.ID], models.PostRevision{
+		ID:        r.nextRevisionID,
+		PostID:    post.ID,
+		Content:   stored.Content,
+		CreatedAt: editedAt,
+	})
+	r.nextRevisionID++
+
+	post.EditedAt = &editedAt
+	stored.Content = post.Content
+	stored.EditedAt = &editedAt
+	r.posts[post.ID] = stored
+	r.setEntitiesLocked(*post)
 	return nil
 }
 
-// Delete removes a post from the repository
+// setEntitiesLocked replaces the hashtags and mentions of a post; the caller
+// holds the write lock. They are kept while the post is deleted, which hides
+// it from GetByHashtag and GetByMention.
+func (r *InMemoryPostRepository) setEntitiesLocked(post models.Post) {
+	for _, tag := range r.postHashtags[post.ID] {
+		delete(r.hashtagPosts[tag], post.ID)
+	}
+	for _, mention := range r.postMentions[post.ID] {
+		delete(r.mentionPosts[mention.UserID], post.ID)
+	}
+	delete(r.postHashtags, post.ID)
+	delete(r.postMentions, post.ID)
+
+	hashtags, mentions := entityRows(post)
+	for _, hashtag := range hashtags {
+		r.postHashtags[post.ID] = append(r.postHashtags[post.ID], hashtag.Tag)
+		addToSet(r.hashtagPosts, hashtag.Tag, post.ID)
+	}
+	for _, mention := range mentions {
+		r.postMentions[post.ID] = append(r.postMentions[post.ID], mention)
+		addToSet(r.mentionPosts, mention.UserID, post.ID)
+	}
+}
+
+// Delete soft-deletes a post together with its reposts, which share its
+// deleted time so that Restore can bring them back. Replies and quote posts
+// are kept and still refer to it.
 func (r *InMemoryPostRepository) Delete(postID int) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	post, exists := r.posts[postID]
 	if !exists {
-		return fmt.Errorf("post not found")
+		return ErrPostNotFound
+	}
+
+	deletedAt := gorm.DeletedAt{Time: time.Now(), Valid: true}
+	r.removeLocked(post, deletedAt)
+	for shareID := range r.postShares[postID] {
+		if share := r.posts[shareID]; share.Kind == models.PostKindRepost {
+			r.removeLocked(share, deletedAt)
+		}
+	}
+	return nil
+}
+
+// GetDeletedByID retrieves a deleted post by ID
+func (r *InMemoryPostRepository) GetDeletedByID(postID int) (models.Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	post, exists := r.deleted[postID]
+	if !exists {
+		return models.Post{}, ErrPostNotFound
+	}
+	return post, nil
+}
+
+// Restore brings back a deleted post together with the reposts deleted with
+// it, except those whose author has since reposted the post again
+func (r *InMemoryPostRepository) Restore(postID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post, exists := r.deleted[postID]
+	if !exists {
+		return ErrPostNotFound
+	}
+
+	for _, share := range r.deleted {
+		if share.Kind == models.PostKindRepost && share.OriginalPostID != nil && *share.OriginalPostID == postID &&
+			share.DeletedAt.Time.Equal(post.DeletedAt.Time) {
+			if _, err := r.getRepostLocked(share.UserID, postID); err == nil {
+				continue
+			}
+			r.restoreLocked(share)
+		}
 	}
+	r.restoreLocked(post)
+	return nil
+}
+
+// GetRevisions retrieves a page of a post's earlier versions, newest first
+func (r *InMemoryPostRepository) GetRevisions(postID int, page PageQuery) ([]models.PostRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	revisions := slices.Clone(r.revisions[postID])
+
+	// Sort by (created_at, id) descending (newest first) and cut the page
+	return applyPage(revisions, page, func(revision models.PostRevision) (time.Time, int) {
+		return revision.CreatedAt, revision.ID
+	}), nil
+}
+
+// addLocked stores a live post and adds it to the indexes; the caller holds the write lock
+func (r *InMemoryPostRepository) addLocked(post models.Post) {
+	r.posts[post.ID] = post
+	r.userPosts[post.UserID] = append(r.userPosts[post.UserID], post.ID)
+	if parentID := post.InReplyToPostID; parentID != nil {
+		addToSet(r.postReplies, *parentID, post.ID)
+	}
+	if originalID := post.OriginalPostID; originalID != nil {
+		addToSet(r.postShares, *originalID, post.ID)
+	}
+}
 
-	// Remove from posts map
+// restoreLocked moves a deleted post back to the live posts; the caller holds the write lock
+func (r *InMemoryPostRepository) restoreLocked(post models.Post) {
+	delete(r.deleted, post.ID)
+	post.DeletedAt = gorm.DeletedAt{}
+	r.addLocked(post)
+}
+
+// removeLocked moves a live post and its own index entries to the deleted
+// posts; the caller holds the write lock
+func (r *InMemoryPostRepository) removeLocked(post models.Post, deletedAt gorm.DeletedAt) {
+	postID := post.ID
+
+	// Move from posts map to deleted
 	delete(r.posts, postID)
+	post.DeletedAt = deletedAt
+	r.deleted[postID] = post
 
 	// Remove from userPosts index
 	userPostIDs := r.userPosts[post.UserID]
@@ -155,7 +639,57 @@ func (r *InMemoryPostRepository) Delete(postID int) error {
 		}
 	}
 
-	return nil
+	// Remove from the parent's replies and the original's shares
+	if parentID := post.InReplyToPostID; parentID != nil {
+		delete(r.postReplies[*parentID], postID)
+	}
+	if originalID := post.OriginalPostID; originalID != nil {
+		delete(r.postShares[*originalID], postID)
+	}
+}
+
+// GetByHashtag retrieves a page of the posts tagged with a lowercased hashtag
+func (r *InMemoryPostRepository) GetByHashtag(tag string, page PageQuery) ([]models.Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.livePostsLocked(r.hashtagPosts[tag], page), nil
+}
+
+// GetByMention retrieves a page of the posts mentioning a user
+func (r *InMemoryPostRepository) GetByMention(userID int, page PageQuery) ([]models.Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.livePostsLocked(r.mentionPosts[userID], page), nil
+}
+
+// livePostsLocked returns a page of the live posts among postIDs; the caller holds the lock
+func (r *InMemoryPostRepository) livePostsLocked(postIDs map[int]bool, page PageQuery) []models.Post {
+	posts := make([]models.Post, 0, len(postIDs))
+	for id := range postIDs {
+		if post, exists := r.posts[id]; exists {
+			posts = append(posts, post)
+		}
+	}
+
+	// Sort by (created_at, id) descending (newest first) and cut the page
+	return applyPage(posts, page, postKey)
+}
+
+// GetMentionsByPostIDs retrieves the mentions of each given post.
+// Posts without mentions are omitted from the result.
+func (r *InMemoryPostRepository) GetMentionsByPostIDs(postIDs []int) (map[int][]models.PostMention, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	mentions := make(map[int][]models.PostMention, len(postIDs))
+	for _, id := range postIDs {
+		if rows := r.postMentions[id]; len(rows) > 0 {
+			mentions[id] = slices.Clone(rows)
+		}
+	}
+	return mentions, nil
 }
 
 // GetByID retrieves a post by ID
@@ -165,13 +699,42 @@ func (r *InMemoryPostRepository) GetByID(postID int) (models.Post, error) {
 
 	post, exists := r.posts[postID]
 	if !exists {
-		return models.Post{}, fmt.Errorf("post not found")
+		return models.Post{}, ErrPostNotFound
 	}
 	return post, nil
 }
 
-// GetByUserID retrieves all posts by a specific user
-func (r *InMemoryPostRepository) GetByUserID(userID int) ([]models.Post, error) {
+// GetByIDs retrieves the posts with the given IDs, skipping IDs that do not exist.
+// The result is not ordered.
+func (r *InMemoryPostRepository) GetByIDs(postIDs []int) ([]models.Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	posts := make([]models.Post, 0, len(postIDs))
+	for _, postID := range postIDs {
+		if post, exists := r.posts[postID]; exists {
+			posts = append(posts, post)
+		}
+	}
+	return posts, nil
+}
+
+// List retrieves a page of all posts, newest first
+func (r *InMemoryPostRepository) List(page PageQuery) ([]models.Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	posts := make([]models.Post, 0, len(r.posts))
+	for _, post := range r.posts {
+		posts = append(posts, post)
+	}
+
+	// Sort by (created_at, id) descending (newest first) and cut the page
+	return applyPage(posts, page, postKey), nil
+}
+
+// GetByUserID retrieves a page of posts by a specific user
+func (r *InMemoryPostRepository) GetByUserID(userID int, page PageQuery) ([]models.Post, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -184,16 +747,12 @@ func (r *InMemoryPostRepository) GetByUserID(userID int) ([]models.Post, error)
 		}
 	}
 
-	// Sort by created_at descending (newest first)
-	sort.Slice(posts, func(i, j int) bool {
-		return posts[i].CreatedAt.After(posts[j].CreatedAt)
-	})
-
-	return posts, nil
+	// Sort by (created_at, id) descending (newest first) and cut the page
+	return applyPage(posts, page, postKey), nil
 }
 
-// GetByUserIDs retrieves all posts by multiple users
-func (r *InMemoryPostRepository) GetByUserIDs(userIDs []int) ([]models.Post, error) {
+// GetByUserIDs retrieves a page of posts by multiple users
+func (r *InMemoryPostRepository) GetByUserIDs(userIDs []int, page PageQuery) ([]models.Post, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -208,14 +767,143 @@ func (r *InMemoryPostRepository) GetByUserIDs(userIDs []int) ([]models.Post, err
 		}
 	}
 
-	// Sort by created_at descending (newest first)
-	sort.Slice(posts, func(i, j int) bool {
-		return posts[i].CreatedAt.After(posts[j].CreatedAt)
-	})
+	// Sort by (created_at, id) descending (newest first) and cut the page
+	return applyPage(posts, page, postKey), nil
+}
+
+// CountByUserID returns the number of posts by a user
+func (r *InMemoryPostRepository) CountByUserID(userID int) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.userPosts[userID]), nil
+}
+
+// GetReplies retrieves a page of the direct replies to a post
+func (r *InMemoryPostRepository) GetReplies(postID int, page PageQuery) ([]models.Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	replyIDs := r.postReplies[postID]
+	posts := make([]models.Post, 0, len(replyIDs))
+	for id := range replyIDs {
+		posts = append(posts, r.posts[id])
+	}
+
+	// Sort by (created_at, id) descending (newest first) and cut the page
+	return applyPage(posts, page, postKey), nil
+}
+
+// CountReplies returns the number of direct replies to each given post.
+// Posts without replies are omitted from the result.
+func (r *InMemoryPostRepository) CountReplies(postIDs []int) (map[int]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[int]int, len(postIDs))
+	for _, id := range postIDs {
+		if n := len(r.postReplies[id]); n > 0 {
+			counts[id] = n
+		}
+	}
+	return counts, nil
+}
+
+// GetRepost retrieves a user's repost of a post
+func (r *InMemoryPostRepository) GetRepost(userID, originalPostID int) (models.Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
+	return r.getRepostLocked(userID, originalPostID)
+}
+
+// getRepostLocked implements GetRepost; the caller holds the lock
+func (r *InMemoryPostRepository) getRepostLocked(userID, originalPostID int) (models.Post, error) {
+	for shareID := range r.postShares[originalPostID] {
+		if share := r.posts[shareID]; share.Kind == models.PostKindRepost && share.UserID == userID {
+			return share, nil
+		}
+	}
+	return models.Post{}, ErrPostNotFound
+}
+
+// GetReposts retrieves every repost of a post
+func (r *InMemoryPostRepository) GetReposts(originalPostID int) ([]models.Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	posts := make([]models.Post, 0)
+	for shareID := range r.postShares[originalPostID] {
+		if share := r.posts[shareID]; share.Kind == models.PostKindRepost {
+			posts = append(posts, share)
+		}
+	}
 	return posts, nil
 }
 
+// GetRepostsByUserIDs retrieves the reposts of any of the given posts by any
+// of the given users
+func (r *InMemoryPostRepository) GetRepostsByUserIDs(originalPostIDs, userIDs []int) ([]models.Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make(map[int]bool, len(userIDs))
+	for _, id := range userIDs {
+		users[id] = true
+	}
+
+	posts := make([]models.Post, 0)
+	seen := make(map[int]bool, len(originalPostIDs))
+	for _, originalID := range originalPostIDs {
+		if seen[originalID] {
+			continue
+		}
+		seen[originalID] = true
+		for shareID := range r.postShares[originalID] {
+			if share := r.posts[shareID]; share.Kind == models.PostKindRepost && users[share.UserID] {
+				posts = append(posts, share)
+			}
+		}
+	}
+	return posts, nil
+}
+
+// addToSet adds id to the set stored under key
+func addToSet[K comparable](sets map[K]map[int]bool, key K, id int) {
+	if sets[key] == nil {
+		sets[key] = make(map[int]bool)
+	}
+	sets[key][id] = true
+}
+
+// entityRows returns the rows storing a post's entities: one per distinct
+// hashtag and one per distinct mentioned user
+func entityRows(post models.Post) ([]models.PostHashtag, []models.PostMention) {
+	var hashtags []models.PostHashtag
+	seenTags := make(map[string]bool)
+	for _, hashtag := range post.Entities.Hashtags {
+		if !seenTags[hashtag.Tag] {
+			seenTags[hashtag.Tag] = true
+			hashtags = append(hashtags, models.PostHashtag{PostID: post.ID, Tag: hashtag.Tag})
+		}
+	}
+
+	var mentions []models.PostMention
+	seenUsers := make(map[int]bool)
+	for _, mention := range post.Entities.Mentions {
+		if !seenUsers[mention.UserID] {
+			seenUsers[mention.UserID] = true
+			mentions = append(mentions, models.PostMention{PostID: post.ID, UserID: mention.UserID, Name: mention.Name})
+		}
+	}
+	return hashtags, mentions
+}
+
+// postKey returns the pagination key of a post
+func postKey(post models.Post) (time.Time, int) {
+	return post.CreatedAt, post.ID
+}
+
 // GetNextPostID returns the next available post ID
 func (r *InMemoryPostRepository) GetNextPostID() int {
 	r.mu.RLock()
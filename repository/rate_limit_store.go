@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimit configures a token bucket that holds up to Requests tokens and
+// refills completely over Per
+type RateLimit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// rate returns the refill rate in tokens per second
+func (l RateLimit) rate() float64 {
+	return float64(l.Requests) / l.Per.Seconds()
+}
+
+// RateLimitResult is the outcome of taking a token from a bucket
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int           // whole tokens left after this request
+	RetryAfter time.Duration // time until the next token, when not allowed
+	ResetAfter time.Duration // time until the bucket is full again
+}
+
+// RateLimitStore holds token buckets keyed by client. Take is atomic per key so a
+// shared store (e.g. Redis) can replace the in-process one.
+type RateLimitStore interface {
+	// Take refills the bucket for key up to now and removes one token if available
+	Take(key string, limit RateLimit, now time.Time) (RateLimitResult, error)
+}
+
+// tokenBucket is the stored state of one bucket
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time // when the bucket will be full again
+}
+
+// rateLimitSweepInterval is how often idle buckets are dropped from memory
+const rateLimitSweepInterval = time.Minute
+
+// InMemoryRateLimitStore implements RateLimitStore using in-memory storage
+type InMemoryRateLimitStore struct {
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	mu        sync.Mutex
+}
+
+// NewInMemoryRateLimitStore creates a new in-memory rate limit store
+func NewInMemoryRateLimitStore() *InMemoryRateLimitStore {
+	return &InMemoryRateLimitStore{
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// Take refills the bucket for key up to now and removes one token if available
+func (s *InMemoryRateLimitStore) Take(key string, limit RateLimit, now time.Time) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	capacity := float64(limit.Requests)
+	rate := limit.rate()
+
+	bucket, exists := s.buckets[key]
+	if !exists {
+		bucket = &tokenBucket{tokens: capacity, updated: now}
+		s.buckets[key] = bucket
+	}
+
+	// Refill for the time elapsed since the last request
+	if elapsed := now.Sub(bucket.updated).Seconds(); elapsed > 0 {
+		bucket.tokens = math.Min(capacity, bucket.tokens+elapsed*rate)
+		bucket.updated = now
+	}
+
+	result := RateLimitResult{}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - bucket.tokens) / rate)
+	}
+
+	result.Remaining = int(bucket.tokens)
+	result.ResetAfter = secondsToDuration((capacity - bucket.tokens) / rate)
+	bucket.full = now.Add(result.ResetAfter)
+	return result, nil
+}
+
+// sweep drops buckets that have refilled completely, since they are
+// indistinguishable from new ones; callers must hold the lock
+func (s *InMemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, bucket := range s.buckets {
+		if !now.Before(bucket.full) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// secondsToDuration converts fractional seconds to a duration
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
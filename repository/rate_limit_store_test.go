@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInMemoryRateLimitStore_Take(t *testing.T) {
+	store := NewInMemoryRateLimitStore()
+	limit := RateLimit{Requests: 3, Per: 3 * time.Second} // one token per second
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// A new bucket starts full
+	for i := 2; i >= 0; i-- {
+		result, err := store.Take("client", limit, now)
+		if err != nil {
+			t.Fatalf("Take() error = %v", err)
+		}
+		if !result.Allowed || result.Remaining != i {
+			t.Fatalf("Take() = %+v, want allowed with %d remaining", result, i)
+		}
+	}
+
+	result, _ := store.Take("client", limit, now)
+	if result.Allowed {
+		t.Fatal("Take() allowed a request from an empty bucket")
+	}
+	if result.RetryAfter != time.Second {
+		t.Errorf("RetryAfter = %v, want 1s", result.RetryAfter)
+	}
+	if result.ResetAfter != 3*time.Second {
+		t.Errorf("ResetAfter = %v, want 3s", result.ResetAfter)
+	}
+
+	// Other keys have their own bucket
+	if result, _ := store.Take("other", limit, now); !result.Allowed {
+		t.Error("Take() for another key was not allowed")
+	}
+
+	// Tokens refill over time
+	result, _ = store.Take("client", limit, now.Add(1500*time.Millisecond))
+	if !result.Allowed || result.Remaining != 0 {
+		t.Errorf("Take() after refill = %+v, want allowed with 0 remaining", result)
+	}
+
+	// Refill is capped at the bucket size
+	result, _ = store.Take("client", limit, now.Add(time.Hour))
+	if !result.Allowed || result.Remaining != 2 {
+		t.Errorf("Take() after long idle = %+v, want allowed with 2 remaining", result)
+	}
+}
+
+func TestInMemoryRateLimitStore_SweepsFullBuckets(t *testing.T) {
+	store := NewInMemoryRateLimitStore()
+	limit := RateLimit{Requests: 1, Per: time.Second}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store.Take("a", limit, now)
+	store.Take("b", limit, now.Add(2*rateLimitSweepInterval))
+
+	if _, exists := store.buckets["a"]; exists {
+		t.Error("idle full bucket was not swept")
+	}
+	if _, exists := store.buckets["b"]; !exists {
+		t.Error("active bucket was swept")
+	}
+}
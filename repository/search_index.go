@@ -0,0 +1,335 @@
+package repository
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// SearchRecencyScale is the age at which a document's recency bonus has
+// halved. The bonus falls from one point for a new document towards none, so
+// it orders equally relevant matches newest first without outweighing relevance.
+const SearchRecencyScale = 7 * 24 * time.Hour
+
+// SearchDocument is the searchable text of a post or user
+type SearchDocument struct {
+	ID        int
+	Text      string
+	CreatedAt time.Time
+}
+
+// SearchHit is a document matching a search query
+type SearchHit struct {
+	ID    int
+	Score float64
+}
+
+// SearchCursor marks a position in search results ordered by (score, id)
+// descending, scored at Now
+type SearchCursor struct {
+	Score float64
+	ID    int
+	Now   time.Time
+}
+
+// SearchQuery describes a page of search results: at most Limit hits strictly
+// after Cursor, best first. A zero Limit means no limit and a nil Cursor starts
+// from the top. Recency is measured from Now, or the current time if it is
+// zero; later pages pass the Now of the first so that scores stay comparable.
+type SearchQuery struct {
+	Text   string
+	Limit  int
+	Cursor *SearchCursor
+	Now    time.Time
+}
+
+// SearchIndex is a full-text index over one kind of document. A document
+// matches when it contains every term of the query; matches are ranked by
+// relevance and recency. A document's score depends only on the document, the
+// query and its Now, so cursors stay valid while other documents are indexed.
+type SearchIndex interface {
+	// Index adds a document, replacing any earlier version with the same ID
+	Index(doc SearchDocument) error
+	// Remove deletes a document; removing a document that is not indexed is not an error
+	Remove(id int) error
+	// Search returns a page of the documents matching the query
+	Search(query SearchQuery) ([]SearchHit, error)
+}
+
+// Encode returns the opaque string form of the cursor
+func (c SearchCursor) Encode() string {
+	raw := strconv.FormatFloat(c.Score, 'g', -1, 64) + ":" + strconv.Itoa(c.ID) + ":" + strconv.FormatInt(c.Now.UnixNano(), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeSearchCursor parses a cursor produced by SearchCursor.Encode
+func DecodeSearchCursor(s string) (SearchCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return SearchCursor{}, fmt.Errorf("invalid cursor")
+	}
+
+	parts := strings.SplitN(string(raw), ":", 3)
+	if len(parts) != 3 {
+		return SearchCursor{}, fmt.Errorf("invalid cursor")
+	}
+
+	score, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return SearchCursor{}, fmt.Errorf("invalid cursor")
+	}
+	id, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return SearchCursor{}, fmt.Errorf("invalid cursor")
+	}
+	now, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return SearchCursor{}, fmt.Errorf("invalid cursor")
+	}
+
+	return SearchCursor{Score: score, ID: id, Now: time.Unix(0, now)}, nil
+}
+
+// before reports whether hit comes after the cursor in (score, id) descending order
+func (c *SearchCursor) before(hit SearchHit) bool {
+	if c == nil {
+		return true
+	}
+	if hit.Score == c.Score {
+		return hit.ID < c.ID
+	}
+	return hit.Score < c.Score
+}
+
+// indexedDocument is a document as stored by InMemorySearchIndex
+type indexedDocument struct {
+	terms     map[string]int // term frequencies
+	text      string         // normalized text, for phrase matches
+	createdAt time.Time
+}
+
+// InMemorySearchIndex implements SearchIndex with an in-process inverted index
+type InMemorySearchIndex struct {
+	postings map[string]map[int]bool // key: term, value: set of document IDs
+	docs     map[int]indexedDocument
+	mu       sync.RWMutex
+}
+
+// NewInMemorySearchIndex creates a new in-memory search index
+func NewInMemorySearchIndex() *InMemorySearchIndex {
+	return &InMemorySearchIndex{
+		postings: make(map[string]map[int]bool),
+		docs:     make(map[int]indexedDocument),
+	}
+}
+
+// Index adds a document, replacing any earlier version with the same ID
+func (s *InMemorySearchIndex) Index(doc SearchDocument) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeLocked(doc.ID)
+
+	terms := make(map[string]int)
+	for _, term := range indexTerms(doc.Text) {
+		terms[term]++
+	}
+	for term := range terms {
+		addToSet(s.postings, term, doc.ID)
+	}
+	s.docs[doc.ID] = indexedDocument{terms: terms, text: normalizeText(doc.Text), createdAt: doc.CreatedAt}
+	return nil
+}
+
+// Remove deletes a document from the index
+func (s *InMemorySearchIndex) Remove(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeLocked(id)
+	return nil
+}
+
+// removeLocked implements Remove; the caller holds the write lock
+func (s *InMemorySearchIndex) removeLocked(id int) {
+	doc, exists := s.docs[id]
+	if !exists {
+		return
+	}
+	for term := range doc.terms {
+		delete(s.postings[term], id)
+		if len(s.postings[term]) == 0 {
+			delete(s.postings, term)
+		}
+	}
+	delete(s.docs, id)
+}
+
+// Search returns a page of the documents containing every term of the query
+func (s *InMemorySearchIndex) Search(query SearchQuery) ([]SearchHit, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	terms := queryTerms(query.Text)
+	if len(terms) == 0 {
+		return []SearchHit{}, nil
+	}
+
+	// Only documents containing the rarest term can match
+	rarest := terms[0]
+	for _, term := range terms[1:] {
+		if len(s.postings[term]) < len(s.postings[rarest]) {
+			rarest = term
+		}
+	}
+
+	// Ages are measured on the wall clock, as a Now decoded from a cursor has
+	// no monotonic reading
+	now := query.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	now = now.Round(0)
+
+	phrase := normalizeText(query.Text)
+	hits := make([]SearchHit, 0)
+	for id := range s.postings[rarest] {
+		doc := s.docs[id]
+		hit, ok := scoreDocument(doc, terms, phrase, now)
+		if !ok {
+			continue
+		}
+		hit.ID = id
+		if query.Cursor.before(hit) {
+			hits = append(hits, hit)
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score == hits[j].Score {
+			return hits[i].ID > hits[j].ID
+		}
+		return hits[i].Score > hits[j].Score
+	})
+	if query.Limit > 0 && len(hits) > query.Limit {
+		hits = hits[:query.Limit]
+	}
+	return hits, nil
+}
+
+// scoreDocument scores a document against the query terms at now, reporting
+// false if it lacks any of them. Relevance is the average term frequency, with
+// diminishing returns, plus a point for containing the query as a phrase;
+// recency adds 1/(1+age/SearchRecencyScale), which is at most a point.
+func scoreDocument(doc indexedDocument, terms []string, phrase string, now time.Time) (SearchHit, bool) {
+	relevance := 0.0
+	for _, term := range terms {
+		tf := doc.terms[term]
+		if tf == 0 {
+			return SearchHit{}, false
+		}
+		relevance += float64(tf) / float64(tf+1)
+	}
+	relevance /= float64(len(terms))
+	if strings.Contains(doc.text, phrase) {
+		relevance++
+	}
+
+	age := max(now.Sub(doc.createdAt), 0)
+	recency := 1 / (1 + age.Seconds()/SearchRecencyScale.Seconds())
+	return SearchHit{Score: relevance + recency}, true
+}
+
+// indexTerms splits text into the terms stored for a document: lowercased
+// words, and for Korean (and other CJK) text, whose words carry attached
+// particles and endings, every syllable and every pair of adjacent syllables
+func indexTerms(text string) []string {
+	var terms []string
+	for _, run := range splitRuns(text) {
+		if !run.cjk {
+			terms = append(terms, string(run.runes))
+			continue
+		}
+		for i := range run.runes {
+			terms = append(terms, string(run.runes[i]))
+			if i+1 < len(run.runes) {
+				terms = append(terms, string(run.runes[i:i+2]))
+			}
+		}
+	}
+	return terms
+}
+
+// queryTerms splits a query into the distinct terms a document must contain:
+// lowercased words, and pairs of adjacent syllables for Korean text, so that
+// "게시글" matches "게시글을" (a single syllable is kept as is)
+func queryTerms(text string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	add := func(term string) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+
+	for _, run := range splitRuns(text) {
+		if !run.cjk || len(run.runes) == 1 {
+			add(string(run.runes))
+			continue
+		}
+		for i := 0; i+1 < len(run.runes); i++ {
+			add(string(run.runes[i : i+2]))
+		}
+	}
+	return terms
+}
+
+// textRun is a maximal run of letters and digits of one script class
+type textRun struct {
+	runes []rune
+	cjk   bool
+}
+
+// splitRuns splits lowercased text into runs of letters and digits, breaking
+// at anything else and wherever CJK text meets other scripts
+func splitRuns(text string) []textRun {
+	var runs []textRun
+	var current textRun
+	flush := func() {
+		if len(current.runes) > 0 {
+			runs = append(runs, current)
+		}
+		current = textRun{}
+	}
+
+	for _, r := range strings.ToLower(text) {
+		if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+			flush()
+			continue
+		}
+		cjk := isCJK(r)
+		if len(current.runes) > 0 && current.cjk != cjk {
+			flush()
+		}
+		current.cjk = cjk
+		current.runes = append(current.runes, r)
+	}
+	flush()
+	return runs
+}
+
+// isCJK reports whether r is Korean, Chinese or Japanese
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Hangul, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// normalizeText lowercases text and collapses whitespace, for phrase matches
+func normalizeText(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
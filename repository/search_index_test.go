@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQueryTerms(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Hello, World!", []string{"hello", "world"}},
+		{"게시글", []string{"게시", "시글"}},
+		{"글", []string{"글"}},
+		{"go언어 go", []string{"go", "언어"}},
+		{"  ...  ", nil},
+	}
+
+	for _, tt := range tests {
+		if got := queryTerms(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("queryTerms(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestInMemorySearchIndex_Search(t *testing.T) {
+	index := NewInMemorySearchIndex()
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	docs := []SearchDocument{
+		{ID: 1, Text: "새 게시글을 올렸습니다", CreatedAt: day},
+		{ID: 2, Text: "Go 게시글 작성법", CreatedAt: day.Add(time.Hour)},
+		{ID: 3, Text: "게시판 공지", CreatedAt: day.Add(2 * time.Hour)},
+		{ID: 4, Text: "Learning Go, go, go", CreatedAt: day},
+		{ID: 5, Text: "go to sleep", CreatedAt: day.Add(SearchRecencyScale)},
+	}
+	now := day.Add(2 * SearchRecencyScale)
+	for _, doc := range docs {
+		if err := index.Index(doc); err != nil {
+			t.Fatalf("Index() error = %v", err)
+		}
+	}
+
+	ids := func(hits []SearchHit) []int {
+		result := make([]int, len(hits))
+		for i, hit := range hits {
+			result[i] = hit.ID
+		}
+		return result
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []int
+	}{
+		{"korean word with particle", "게시글", []int{2, 1}},
+		{"every term must match", "go 게시글", []int{2}},
+		{"case insensitive, frequency outranks a week of recency", "GO", []int{4, 5, 2}},
+		{"single syllable", "판", []int{3}},
+		{"no match", "없는말", []int{}},
+		{"blank query", " ", []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := index.Search(SearchQuery{Text: tt.query, Now: now})
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if got := ids(hits); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("pagination", func(t *testing.T) {
+		first, _ := index.Search(SearchQuery{Text: "go", Limit: 2, Now: now})
+		if got := ids(first); !reflect.DeepEqual(got, []int{4, 5}) {
+			t.Fatalf("first page = %v, want [4 5]", got)
+		}
+
+		cursor, err := DecodeSearchCursor(SearchCursor{Score: first[1].Score, ID: first[1].ID, Now: now}.Encode())
+		if err != nil {
+			t.Fatalf("DecodeSearchCursor() error = %v", err)
+		}
+		if !cursor.Now.Equal(now) {
+			t.Errorf("DecodeSearchCursor() Now = %v, want %v", cursor.Now, now)
+		}
+		// Indexing a new document does not shift later pages
+		index.Index(SearchDocument{ID: 6, Text: "go", CreatedAt: day.Add(2 * SearchRecencyScale)})
+
+		second, _ := index.Search(SearchQuery{Text: "go", Limit: 2, Cursor: &cursor, Now: cursor.Now})
+		if got := ids(second); !reflect.DeepEqual(got, []int{2}) {
+			t.Errorf("second page = %v, want [2]", got)
+		}
+	})
+
+	t.Run("reindex and remove", func(t *testing.T) {
+		index.Index(SearchDocument{ID: 3, Text: "공지 없음", CreatedAt: day})
+		index.Remove(1)
+		index.Remove(99)
+
+		hits, _ := index.Search(SearchQuery{Text: "게시", Now: now})
+		if got := ids(hits); !reflect.DeepEqual(got, []int{2}) {
+			t.Errorf("Search() = %v, want [2]", got)
+		}
+	})
+}
+
+func TestInMemorySearchIndex_RelevanceOutranksRecency(t *testing.T) {
+	index := NewInMemorySearchIndex()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	index.Index(SearchDocument{ID: 1, Text: "go tutorial", CreatedAt: now.AddDate(-1, 0, 0)})
+	index.Index(SearchDocument{ID: 2, Text: "a tutorial for go", CreatedAt: now})
+	index.Index(SearchDocument{ID: 3, Text: "tutorial: go", CreatedAt: now.Add(-time.Hour)})
+
+	// A year-old phrase match outranks new matches of the separate words,
+	// which are ordered newest first
+	hits, err := index.Search(SearchQuery{Text: "go tutorial", Now: now})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	got := make([]int, len(hits))
+	for i, hit := range hits {
+		got[i] = hit.ID
+	}
+	if !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("Search() = %v, want [1 2 3]", got)
+	}
+}
+
+func TestDecodeSearchCursor_Invalid(t *testing.T) {
+	for _, s := range []string{"", "!!!", "bm9jb2xvbg", "eDox", "MToy"} { // "", bad base64, "nocolon", "x:1", "1:2"
+		if _, err := DecodeSearchCursor(s); err == nil {
+			t.Errorf("DecodeSearchCursor(%q) succeeded, want error", s)
+		}
+	}
+}
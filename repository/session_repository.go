@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"python-backend-with-go/models"
+)
+
+// SessionRepository defines the interface for login session and refresh token operations
+type SessionRepository interface {
+	CreateSession(session *models.Session) error
+	GetSession(id string) (models.Session, error)
+	RevokeSession(id string, at time.Time) error
+	RevokeUserSessions(userID int, at time.Time) error
+	CreateRefreshToken(token *models.RefreshToken) error
+	GetRefreshTokenByHash(tokenHash string) (models.RefreshToken, error)
+	MarkRefreshTokenUsed(id int, at time.Time) error
+}
+
+// GormSessionRepository implements SessionRepository using GORM
+type GormSessionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormSessionRepository creates a new GORM session repository
+func NewGormSessionRepository(db *gorm.DB) *GormSessionRepository {
+	return &GormSessionRepository{db: db}
+}
+
+// CreateSession adds a new session to the database
+func (r *GormSessionRepository) CreateSession(session *models.Session) error {
+	return r.db.Create(session).Error
+}
+
+// GetSession retrieves a session by ID
+func (r *GormSessionRepository) GetSession(id string) (models.Session, error) {
+	var session models.Session
+	err := r.db.Where("id = ?", id).First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Session{}, ErrSessionNotFound
+		}
+		return models.Session{}, err
+	}
+	return session, nil
+}
+
+// RevokeSession marks a session as revoked. Revoking an already revoked session is a no-op.
+func (r *GormSessionRepository) RevokeSession(id string, at time.Time) error {
+	result := r.db.Model(&models.Session{}).Where("id = ?", id).Where("revoked_at IS NULL").Update("revoked_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		// Distinguish "already revoked" from "does not exist"
+		if _, err := r.GetSession(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RevokeUserSessions marks every active session of a user as revoked
+func (r *GormSessionRepository) RevokeUserSessions(userID int, at time.Time) error {
+	return r.db.Model(&models.Session{}).Where("user_id = ? AND revoked_at IS NULL", userID).Update("revoked_at", at).Error
+}
+
+// CreateRefreshToken adds a new refresh token to the database
+func (r *GormSessionRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetRefreshTokenByHash retrieves a refresh token by its hash
+func (r *GormSessionRepository) GetRefreshTokenByHash(tokenHash string) (models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.RefreshToken{}, ErrRefreshTokenNotFound
+		}
+		return models.RefreshToken{}, err
+	}
+	return token, nil
+}
+
+// MarkRefreshTokenUsed atomically marks a refresh token as exchanged.
+// It returns ErrRefreshTokenUsed if the token was already used.
+func (r *GormSessionRepository) MarkRefreshTokenUsed(id int, at time.Time) error {
+	result := r.db.Model(&models.RefreshToken{}).Where("id = ? AND used_at IS NULL", id).Update("used_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		var count int64
+		if err := r.db.Model(&models.RefreshToken{}).Where("id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrRefreshTokenNotFound
+		}
+		return ErrRefreshTokenUsed
+	}
+	return nil
+}
+
+// InMemorySessionRepository implements SessionRepository using in-memory storage
+type InMemorySessionRepository struct {
+	sessions      map[string]models.Session
+	refreshTokens map[int]models.RefreshToken
+	tokenHashes   map[string]int // key: token hash, value: refresh token ID
+	nextTokenID   int
+	mu            sync.RWMutex
+}
+
+// NewInMemorySessionRepository creates a new in-memory session repository
+func NewInMemorySessionRepository() *InMemorySessionRepository {
+	return &InMemorySessionRepository{
+		sessions:      make(map[string]models.Session),
+		refreshTokens: make(map[int]models.RefreshToken),
+		tokenHashes:   make(map[string]int),
+		nextTokenID:   1,
+	}
+}
+
+// CreateSession adds a new session to the repository
+func (r *InMemorySessionRepository) CreateSession(session *models.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if session.CreatedAt.IsZero() {
+		session.CreatedAt = time.Now()
+	}
+	r.sessions[session.ID] = *session
+	return nil
+}
+
+// GetSession retrieves a session by ID
+func (r *InMemorySessionRepository) GetSession(id string) (models.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, exists := r.sessions[id]
+	if !exists {
+		return models.Session{}, ErrSessionNotFound
+	}
+	return session, nil
+}
+
+// RevokeSession marks a session as revoked. Revoking an already revoked session is a no-op.
+func (r *InMemorySessionRepository) RevokeSession(id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, exists := r.sessions[id]
+	if !exists {
+		return ErrSessionNotFound
+	}
+	if session.RevokedAt == nil {
+		session.RevokedAt = &at
+		r.sessions[id] = session
+	}
+	return nil
+}
+
+// RevokeUserSessions marks every active session of a user as revoked
+func (r *InMemorySessionRepository) RevokeUserSessions(userID int, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, session := range r.sessions {
+		if session.UserID == userID && session.RevokedAt == nil {
+			session.RevokedAt = &at
+			r.sessions[id] = session
+		}
+	}
+	return nil
+}
+
+// CreateRefreshToken adds a new refresh token to the repository
+func (r *InMemorySessionRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token.ID = r.nextTokenID
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now()
+	}
+	r.refreshTokens[token.ID] = *token
+	r.tokenHashes[token.TokenHash] = token.ID
+	r.nextTokenID++
+	return nil
+}
+
+// GetRefreshTokenByHash retrieves a refresh token by its hash
+func (r *InMemorySessionRepository) GetRefreshTokenByHash(tokenHash string) (models.RefreshToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, exists := r.tokenHashes[tokenHash]
+	if !exists {
+		return models.RefreshToken{}, ErrRefreshTokenNotFound
+	}
+	return r.refreshTokens[id], nil
+}
+
+// MarkRefreshTokenUsed atomically marks a refresh token as exchanged.
+// It returns ErrRefreshTokenUsed if the token was already used.
+func (r *InMemorySessionRepository) MarkRefreshTokenUsed(id int, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, exists := r.refreshTokens[id]
+	if !exists {
+		return ErrRefreshTokenNotFound
+	}
+	if token.UsedAt != nil {
+		return ErrRefreshTokenUsed
+	}
+	token.UsedAt = &at
+	r.refreshTokens[id] = token
+	return nil
+}
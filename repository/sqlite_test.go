@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"python-backend-with-go/db"
+	"python-backend-with-go/models"
+)
+
+// newSQLiteDB opens a private in-memory SQLite database migrated to the latest schema
+func newSQLiteDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	conn, err := db.Open(db.DriverSQLite, db.SQLiteMemory, &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := conn.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	migrator, err := db.NewMigrator(conn)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if err := migrator.Up(); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return conn
+}
+
+func TestSQLiteMigrations_DownAndUp(t *testing.T) {
+	conn := newSQLiteDB(t)
+
+	migrator, err := db.NewMigrator(conn)
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+	if err := migrator.To(0); err != nil {
+		t.Fatalf("To(0) error = %v", err)
+	}
+	if conn.Migrator().HasTable("users") {
+		t.Error("users table still exists after rolling back every migration")
+	}
+
+	if err := migrator.Up(); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	statuses, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			t.Errorf("migration %d_%s not applied after Up()", status.Version, status.Name)
+		}
+	}
+}
+
+func TestGormPostRepository_CreateCutsCreatedAtToColumnPrecision(t *testing.T) {
+	conn := newSQLiteDB(t)
+	users := createUsers(t, NewGormUserRepository(conn), 1)
+	repo := NewGormPostRepository(conn)
+
+	post := models.Post{UserID: users[0].ID, Content: "hello", CreatedAt: contractTime.Add(1500 * time.Millisecond)}
+	if err := repo.Create(&post); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if want := contractTime.Add(time.Second); !post.CreatedAt.Equal(want) {
+		t.Errorf("Create() CreatedAt = %v, want %v", post.CreatedAt, want)
+	}
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// TimelineEntry is a reference to a post in a user's materialized home timeline
+type TimelineEntry struct {
+	PostID    int
+	AuthorID  int
+	CreatedAt time.Time
+}
+
+// TimelineStore holds precomputed home timelines, one ordered list of entries per user.
+// The operations map onto a sorted set per user so a Redis-backed store can replace
+// the in-process one.
+type TimelineStore interface {
+	// Exists reports whether the user's timeline has been materialized
+	Exists(userID int) (bool, error)
+	// Replace materializes the user's timeline with exactly the given entries
+	Replace(userID int, entries []TimelineEntry) error
+	// Add inserts entries into a materialized timeline; it is a no-op for timelines
+	// that have not been materialized yet
+	Add(userID int, entries ...TimelineEntry) error
+	// Remove deletes a post from a user's timeline
+	Remove(userID, postID int) error
+	// RemoveAuthor deletes every post by authorID from a user's timeline
+	RemoveAuthor(userID, authorID int) error
+	// Range returns a page of a user's timeline ordered by (created_at, post_id) descending
+	Range(userID int, page PageQuery) ([]TimelineEntry, error)
+	// Len returns the number of entries in a user's timeline
+	Len(userID int) (int, error)
+	// Trim keeps only the newest max entries of a user's timeline
+	Trim(userID, max int) error
+}
+
+// InMemoryTimelineStore implements TimelineStore using in-memory storage
+type InMemoryTimelineStore struct {
+	timelines map[int][]TimelineEntry // key: userID, value: entries sorted newest first
+	mu        sync.RWMutex
+}
+
+// NewInMemoryTimelineStore creates a new in-memory timeline store
+func NewInMemoryTimelineStore() *InMemoryTimelineStore {
+	return &InMemoryTimelineStore{
+		timelines: make(map[int][]TimelineEntry),
+	}
+}
+
+// Exists reports whether the user's timeline has been materialized
+func (s *InMemoryTimelineStore) Exists(userID int) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.timelines[userID]
+	return exists, nil
+}
+
+// Replace materializes the user's timeline with exactly the given entries
+func (s *InMemoryTimelineStore) Replace(userID int, entries []TimelineEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	timeline := make([]TimelineEntry, 0, len(entries))
+	s.timelines[userID] = mergeEntries(timeline, entries)
+	return nil
+}
+
+// Add inserts entries into a materialized timeline
+func (s *InMemoryTimelineStore) Add(userID int, entries ...TimelineEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	timeline, exists := s.timelines[userID]
+	if !exists {
+		return nil
+	}
+	s.timelines[userID] = mergeEntries(timeline, entries)
+	return nil
+}
+
+// Remove deletes a post from a user's timeline
+func (s *InMemoryTimelineStore) Remove(userID, postID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeWhere(userID, func(e TimelineEntry) bool { return e.PostID == postID })
+	return nil
+}
+
+// RemoveAuthor deletes every post by authorID from a user's timeline
+func (s *InMemoryTimelineStore) RemoveAuthor(userID, authorID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeWhere(userID, func(e TimelineEntry) bool { return e.AuthorID == authorID })
+	return nil
+}
+
+// Range returns a page of a user's timeline ordered by (created_at, post_id) descending
+func (s *InMemoryTimelineStore) Range(userID int, page PageQuery) ([]TimelineEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]TimelineEntry, 0)
+	for _, entry := range s.timelines[userID] {
+		if !page.Cursor.before(entry.CreatedAt, entry.PostID) {
+			continue
+		}
+		result = append(result, entry)
+		if page.Limit > 0 && len(result) == page.Limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// Len returns the number of entries in a user's timeline
+func (s *InMemoryTimelineStore) Len(userID int) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.timelines[userID]), nil
+}
+
+// Trim keeps only the newest max entries of a user's timeline
+func (s *InMemoryTimelineStore) Trim(userID, max int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if timeline := s.timelines[userID]; len(timeline) > max {
+		s.timelines[userID] = timeline[:max]
+	}
+	return nil
+}
+
+// removeWhere drops matching entries from a timeline; callers must hold the write lock
+func (s *InMemoryTimelineStore) removeWhere(userID int, match func(TimelineEntry) bool) {
+	timeline, exists := s.timelines[userID]
+	if !exists {
+		return
+	}
+
+	kept := timeline[:0]
+	for _, entry := range timeline {
+		if !match(entry) {
+			kept = append(kept, entry)
+		}
+	}
+	s.timelines[userID] = kept
+}
+
+// mergeEntries adds entries to a sorted timeline, skipping posts it already holds
+func mergeEntries(timeline []TimelineEntry, entries []TimelineEntry) []TimelineEntry {
+	seen := make(map[int]bool, len(timeline))
+	for _, entry := range timeline {
+		seen[entry.PostID] = true
+	}
+	for _, entry := range entries {
+		if !seen[entry.PostID] {
+			seen[entry.PostID] = true
+			timeline = append(timeline, entry)
+		}
+	}
+
+	sort.Slice(timeline, func(i, j int) bool {
+		return newerFirst(timeline[i].CreatedAt, timeline[i].PostID, timeline[j].CreatedAt, timeline[j].PostID)
+	})
+	return timeline
+}
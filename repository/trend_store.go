@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"sync"
+	"time"
+)
+
+// TrendBucket is the resolution of hashtag usage counts: uses are counted per
+// bucket of this length, so window boundaries are rounded down to it
+const TrendBucket = time.Minute
+
+// TrendStore counts hashtag uses over time. The operations map onto one hash
+// per time bucket so a Redis-backed store can replace the in-process one.
+type TrendStore interface {
+	// Add counts one use of each hashtag at a time
+	Add(tags []string, at time.Time) error
+	// Remove takes back one use of each hashtag counted at a time; counts
+	// never go below zero
+	Remove(tags []string, at time.Time) error
+	// Counts returns the uses of each hashtag in the buckets from the one
+	// containing since through the one containing until
+	Counts(since, until time.Time) (map[string]int, error)
+	// Prune forgets the buckets before a time
+	Prune(before time.Time) error
+}
+
+// InMemoryTrendStore implements TrendStore using in-memory storage
+type InMemoryTrendStore struct {
+	buckets map[int64]map[string]int // key: bucket start in Unix seconds, value: uses per hashtag
+	mu      sync.RWMutex
+}
+
+// NewInMemoryTrendStore creates a new in-memory trend store
+func NewInMemoryTrendStore() *InMemoryTrendStore {
+	return &InMemoryTrendStore{
+		buckets: make(map[int64]map[string]int),
+	}
+}
+
+// Add counts one use of each hashtag at a time
+func (s *InMemoryTrendStore) Add(tags []string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := trendBucketKey(at)
+	bucket := s.buckets[key]
+	if bucket == nil {
+		bucket = make(map[string]int)
+		s.buckets[key] = bucket
+	}
+	for _, tag := range tags {
+		bucket[tag]++
+	}
+	return nil
+}
+
+// Remove takes back one use of each hashtag counted at a time
+func (s *InMemoryTrendStore) Remove(tags []string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := trendBucketKey(at)
+	bucket := s.buckets[key]
+	for _, tag := range tags {
+		if bucket[tag] <= 1 {
+			delete(bucket, tag)
+			continue
+		}
+		bucket[tag]--
+	}
+	if bucket != nil && len(bucket) == 0 {
+		delete(s.buckets, key)
+	}
+	return nil
+}
+
+// Counts returns the uses of each hashtag in the buckets from the one
+// containing since through the one containing until
+func (s *InMemoryTrendStore) Counts(since, until time.Time) (map[string]int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	from, to := trendBucketKey(since), trendBucketKey(until)
+	counts := make(map[string]int)
+	for key, bucket := range s.buckets {
+		if key < from || key > to {
+			continue
+		}
+		for tag, n := range bucket {
+			counts[tag] += n
+		}
+	}
+	return counts, nil
+}
+
+// Prune forgets the buckets before a time
+func (s *InMemoryTrendStore) Prune(before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := trendBucketKey(before)
+	for key := range s.buckets {
+		if key < cutoff {
+			delete(s.buckets, key)
+		}
+	}
+	return nil
+}
+
+// trendBucketKey returns the key of the bucket containing t
+func trendBucketKey(t time.Time) int64 {
+	return t.Truncate(TrendBucket).Unix()
+}
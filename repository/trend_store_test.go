@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInMemoryTrendStore(t *testing.T) {
+	store := NewInMemoryTrendStore()
+	now := time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC)
+
+	store.Add([]string{"go", "rust"}, now)
+	store.Add([]string{"go"}, now.Add(-TrendBucket))
+	store.Add([]string{"go"}, now.Add(-time.Hour))
+
+	tests := []struct {
+		name         string
+		since, until time.Time
+		want         map[string]int
+	}{
+		{"current bucket", now, now, map[string]int{"go": 1, "rust": 1}},
+		{"bounds are rounded to buckets", now.Add(-TrendBucket + time.Second), now.Add(-time.Second), map[string]int{"go": 2, "rust": 1}},
+		{"whole range", now.Add(-time.Hour), now, map[string]int{"go": 3, "rust": 1}},
+		{"empty range", now.Add(time.Hour), now.Add(2 * time.Hour), map[string]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.Counts(tt.since, tt.until)
+			if err != nil {
+				t.Fatalf("Counts() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Counts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if err := store.Prune(now.Add(-TrendBucket)); err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	got, _ := store.Counts(now.Add(-time.Hour), now)
+	if want := map[string]int{"go": 2, "rust": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Counts() after Prune() = %v, want %v", got, want)
+	}
+}
+
+func TestInMemoryTrendStore_Remove(t *testing.T) {
+	store := NewInMemoryTrendStore()
+	now := time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC)
+
+	store.Add([]string{"go", "rust"}, now)
+	store.Add([]string{"go"}, now)
+	store.Add([]string{"go"}, now.Add(-time.Hour))
+
+	// Uses are taken back from the bucket they were counted in, never below zero
+	if err := store.Remove([]string{"go", "rust"}, now.Add(time.Second)); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	store.Remove([]string{"rust", "zig"}, now)
+
+	got, _ := store.Counts(now.Add(-time.Hour), now)
+	if want := map[string]int{"go": 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Counts() after Remove() = %v, want %v", got, want)
+	}
+}
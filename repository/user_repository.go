@@ -1,8 +1,10 @@
 package repository
 
 import (
-	"fmt"
+	"errors"
+	"slices"
 	"sync"
+	"time"
 
 	"gorm.io/gorm"
 	"python-backend-with-go/models"
@@ -12,8 +14,14 @@ import (
 type UserRepository interface {
 	Create(user *models.User) error
 	GetByID(id int) (models.User, error)
+	GetByIDs(ids []int) (map[int]models.User, error)
+	GetByNames(names []string) ([]models.User, error)
+	List(page PageQuery) ([]models.User, error)
 	GetByEmail(email string) (models.User, error)
 	EmailExists(email string) bool
+	Update(user *models.User) error
+	UpdatePassword(userID int, hashedPassword string) error
+	MarkEmailVerified(userID int, at time.Time) error
 }
 
 // GormUserRepository implements UserRepository using GORM
@@ -28,7 +36,11 @@ func NewGormUserRepository(db *gorm.DB) *GormUserRepository {
 
 // Create adds a new user to the database
 func (r *GormUserRepository) Create(user *models.User) error {
-	return r.db.Create(user).Error
+	err := r.db.Create(user).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrEmailTaken
+	}
+	return err
 }
 
 // GetByID retrieves a user by ID
@@ -36,21 +48,69 @@ func (r *GormUserRepository) GetByID(id int) (models.User, error) {
 	var user models.User
 	err := r.db.First(&user, id).Error
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return models.User{}, fmt.Errorf("user not found")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.User{}, ErrUserNotFound
 		}
 		return models.User{}, err
 	}
 	return user, nil
 }
 
+// List retrieves a page of all users, newest first
+func (r *GormUserRepository) List(page PageQuery) ([]models.User, error) {
+	var users []models.User
+	if err := keysetPage(r.db, "created_at", "id", page).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// GetByIDs retrieves multiple users in a single query, keyed by ID.
+// IDs that do not exist are omitted from the result.
+func (r *GormUserRepository) GetByIDs(ids []int) (map[int]models.User, error) {
+	users := make(map[int]models.User, len(ids))
+	if len(ids) == 0 {
+		return users, nil
+	}
+
+	var rows []models.User
+	if err := r.db.Where("id IN ?", ids).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, user := range rows {
+		users[user.ID] = user
+	}
+	return users, nil
+}
+
+// GetByNames retrieves the users whose name is exactly one of names, in a
+// single query. The result is not ordered.
+func (r *GormUserRepository) GetByNames(names []string) ([]models.User, error) {
+	users := make([]models.User, 0)
+	if len(names) == 0 {
+		return users, nil
+	}
+
+	var rows []models.User
+	if err := r.db.Where("name IN ?", names).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	// MySQL compares names case- and accent-insensitively; keep exact matches only
+	for _, user := range rows {
+		if slices.Contains(names, user.Name) {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
 // GetByEmail retrieves a user by email
 func (r *GormUserRepository) GetByEmail(email string) (models.User, error) {
 	var user models.User
 	err := r.db.Where("email = ?", email).First(&user).Error
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return models.User{}, fmt.Errorf("user not found")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.User{}, ErrUserNotFound
 		}
 		return models.User{}, err
 	}
@@ -64,6 +124,56 @@ func (r *GormUserRepository) EmailExists(email string) bool {
 	return count > 0
 }
 
+// Update saves the user's profile fields (name and profile) and sets UpdatedAt
+func (r *GormUserRepository) Update(user *models.User) error {
+	user.UpdatedAt = time.Now()
+	result := r.db.Model(&models.User{}).Where("id = ?", user.ID).Updates(map[string]any{
+		"name":       user.Name,
+		"profile":    user.Profile,
+		"updated_at": user.UpdatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// UpdatePassword replaces a user's password hash
+func (r *GormUserRepository) UpdatePassword(userID int, hashedPassword string) error {
+	result := r.db.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]any{
+		"hashed_password": hashedPassword,
+		"updated_at":      time.Now(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// MarkEmailVerified records when the user's email address was verified.
+// An address that is already verified keeps its original timestamp.
+func (r *GormUserRepository) MarkEmailVerified(userID int, at time.Time) error {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND email_verified_at IS NULL", userID).
+		Update("email_verified_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		// Either already verified or no such user
+		if _, err := r.GetByID(userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // InMemoryUserRepository implements UserRepository using in-memory storage
 type InMemoryUserRepository struct {
 	users      map[int]models.User
@@ -84,7 +194,16 @@ func (r *InMemoryUserRepository) Create(user *models.User) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	for _, existing := range r.users {
+		if existing.Email == user.Email {
+			return ErrEmailTaken
+		}
+	}
+
 	user.ID = r.nextUserID
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = time.Now()
+	}
 	r.users[user.ID] = *user
 	r.nextUserID++
 	return nil
@@ -97,11 +216,57 @@ func (r *InMemoryUserRepository) GetByID(id int) (models.User, error) {
 
 	user, exists := r.users[id]
 	if !exists {
-		return models.User{}, fmt.Errorf("user not found")
+		return models.User{}, ErrUserNotFound
 	}
 	return user, nil
 }
 
+// GetByIDs retrieves multiple users keyed by ID.
+// IDs that do not exist are omitted from the result.
+func (r *InMemoryUserRepository) GetByIDs(ids []int) (map[int]models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make(map[int]models.User, len(ids))
+	for _, id := range ids {
+		if user, exists := r.users[id]; exists {
+			users[id] = user
+		}
+	}
+	return users, nil
+}
+
+// List retrieves a page of all users, newest first
+func (r *InMemoryUserRepository) List(page PageQuery) ([]models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]models.User, 0, len(r.users))
+	for _, user := range r.users {
+		users = append(users, user)
+	}
+
+	// Sort by (created_at, id) descending (newest first) and cut the page
+	return applyPage(users, page, func(user models.User) (time.Time, int) {
+		return user.CreatedAt, user.ID
+	}), nil
+}
+
+// GetByNames retrieves the users whose name is exactly one of names.
+// The result is not ordered.
+func (r *InMemoryUserRepository) GetByNames(names []string) ([]models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]models.User, 0)
+	for _, user := range r.users {
+		if slices.Contains(names, user.Name) {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
 // GetByEmail retrieves a user by email
 func (r *InMemoryUserRepository) GetByEmail(email string) (models.User, error) {
 	r.mu.RLock()
@@ -112,7 +277,7 @@ func (r *InMemoryUserRepository) GetByEmail(email string) (models.User, error) {
 			return user, nil
 		}
 	}
-	return models.User{}, fmt.Errorf("user not found")
+	return models.User{}, ErrUserNotFound
 }
 
 // EmailExists checks if an email already exists
@@ -128,6 +293,57 @@ func (r *InMemoryUserRepository) EmailExists(email string) bool {
 	return false
 }
 
+// Update saves the user's profile fields (name and profile) and sets UpdatedAt
+func (r *InMemoryUserRepository) Update(user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.users[user.ID]
+	if !exists {
+		return ErrUserNotFound
+	}
+
+	user.UpdatedAt = time.Now()
+	stored.Name = user.Name
+	stored.Profile = user.Profile
+	stored.UpdatedAt = user.UpdatedAt
+	r.users[user.ID] = stored
+	return nil
+}
+
+// UpdatePassword replaces a user's password hash
+func (r *InMemoryUserRepository) UpdatePassword(userID int, hashedPassword string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, exists := r.users[userID]
+	if !exists {
+		return ErrUserNotFound
+	}
+
+	user.HashedPassword = hashedPassword
+	user.UpdatedAt = time.Now()
+	r.users[userID] = user
+	return nil
+}
+
+// MarkEmailVerified records when the user's email address was verified
+func (r *InMemoryUserRepository) MarkEmailVerified(userID int, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, exists := r.users[userID]
+	if !exists {
+		return ErrUserNotFound
+	}
+
+	if user.EmailVerifiedAt == nil {
+		user.EmailVerifiedAt = &at
+		r.users[userID] = user
+	}
+	return nil
+}
+
 // GetNextUserID returns the next available user ID
 func (r *InMemoryUserRepository) GetNextUserID() int {
 	r.mu.RLock()
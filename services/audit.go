@@ -0,0 +1,49 @@
+package services
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Audit event types
+const (
+	AuditAccountLocked   = "account_locked"
+	AuditIPLocked        = "ip_locked"
+	AuditAccountUnlocked = "account_unlocked"
+)
+
+// AuditEvent is a security-relevant event worth keeping a record of
+type AuditEvent struct {
+	Type   string
+	UserID int // 0 when the account is unknown
+	Email  string
+	IP     string
+	At     time.Time
+	Until  time.Time // end of a lockout, if any
+}
+
+// AuditLogger records audit events
+type AuditLogger interface {
+	Record(event AuditEvent)
+}
+
+// SlogAuditLogger writes audit events to the structured log
+type SlogAuditLogger struct{}
+
+// Record writes the event as a warning-level log entry
+func (SlogAuditLogger) Record(event AuditEvent) {
+	attrs := []any{"event", event.Type, "at", event.At}
+	if event.UserID != 0 {
+		attrs = append(attrs, "user_id", event.UserID)
+	}
+	if event.Email != "" {
+		attrs = append(attrs, "email", event.Email)
+	}
+	if event.IP != "" {
+		attrs = append(attrs, "ip", event.IP)
+	}
+	if !event.Until.IsZero() {
+		attrs = append(attrs, "until", event.Until)
+	}
+	slog.Warn("Audit event", attrs...)
+}
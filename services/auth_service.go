@@ -1,61 +1,374 @@
 package services
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"log/slog"
+	"math"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 	"python-backend-with-go/models"
 	"python-backend-with-go/repository"
 )
 
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// Login throttling policy
+const (
+	// MaxLoginFailures is the number of failed logins after which an account is locked
+	MaxLoginFailures = 5
+	// MaxIPLoginFailures is the number of failed logins from one client IP after which it is locked
+	MaxIPLoginFailures = 20
+	// LoginFailureWindow is how long a failed login counts against an account or IP
+	LoginFailureWindow = 15 * time.Minute
+	// LoginLockoutDuration is how long a locked account or IP stays locked
+	LoginLockoutDuration = 15 * time.Minute
+	// LoginDelayAfter is the number of failures after which every retry must wait
+	// a delay that doubles with each further failure, starting at LoginBaseDelay
+	LoginDelayAfter = 2
+	LoginBaseDelay  = time.Second
+)
+
 // AuthService handles authentication business logic
 type AuthService struct {
-	userRepo repository.UserRepository
+	userRepo      repository.UserRepository
+	sessionRepo   repository.SessionRepository
+	loginAttempts repository.LoginAttemptStore
+	audit         AuditLogger
+	now           func() time.Time
 }
 
 // NewAuthService creates a new auth service
-func NewAuthService(userRepo repository.UserRepository) *AuthService {
+func NewAuthService(userRepo repository.UserRepository, sessionRepo repository.SessionRepository, loginAttempts repository.LoginAttemptStore) *AuthService {
 	return &AuthService{
-		userRepo: userRepo,
+		userRepo:      userRepo,
+		sessionRepo:   sessionRepo,
+		loginAttempts: loginAttempts,
+		audit:         SlogAuditLogger{},
+		now:           time.Now,
 	}
 }
 
 // Login authenticates a user and returns a JWT token
 func (s *AuthService) Login(req models.LoginRequest) (models.LoginResponse, error) {
 	// Validate required fields
-	if req.Email == "" || req.Password == "" {
-		return models.LoginResponse{}, fmt.Errorf("email and password are required")
+	if err := validateRequest(req); err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	// Refuse locked or throttled accounts and clients before checking the password
+	now := s.now()
+	accountKey, ipKey := loginAccountKey(req.Email), loginIPKey(req.ClientIP)
+	if err := s.checkLoginAllowed(accountKey, true, now); err != nil {
+		return models.LoginResponse{}, err
+	}
+	if err := s.checkLoginAllowed(ipKey, false, now); err != nil {
+		return models.LoginResponse{}, err
 	}
 
 	// Get user by email
 	user, err := s.userRepo.GetByEmail(req.Email)
 	if err != nil {
-		return models.LoginResponse{}, fmt.Errorf("invalid email or password")
+		s.recordLoginFailure(req, 0, now)
+		return models.LoginResponse{}, NewError(ErrUnauthenticated, "invalid email or password")
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.HashedPassword), []byte(req.Password)); err != nil {
-		return models.LoginResponse{}, fmt.Errorf("invalid email or password")
+		s.recordLoginFailure(req, user.ID, now)
+		return models.LoginResponse{}, NewError(ErrUnauthenticated, "invalid email or password")
 	}
 
-	// Generate JWT token
-	token, err := s.generateToken(user.ID, user.Email)
+	// A successful login clears the account's failures (but not the client IP's)
+	if err := s.loginAttempts.Reset(accountKey); err != nil {
+		slog.Warn("Failed to reset login failures", "user_id", user.ID, "error", err)
+	}
+
+	// Unverified accounts may be barred from logging in (see EMAIL_VERIFICATION)
+	if err := requireVerifiedEmail(user, currentVerificationPolicy().blocksLogin()); err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	// Start a new session and issue its first token pair
+	tokens, err := s.startSession(user)
 	if err != nil {
-		return models.LoginResponse{}, fmt.Errorf("failed to generate token: %w", err)
+		return models.LoginResponse{}, err
 	}
 
 	return models.LoginResponse{
-		Message:     "로그인 성공",
-		AccessToken: token,
-		UserID:      user.ID,
+		Message:      "로그인 성공",
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+		UserID:       user.ID,
 	}, nil
 }
 
-// generateToken creates a JWT token for the user
-func (s *AuthService) generateToken(userID int, email string) (string, error) {
+// UnlockAccount clears the failed login attempts and lockout of an account.
+// It is called once the owner has proven control of it, e.g. by resetting the password.
+func (s *AuthService) UnlockAccount(user models.User) error {
+	attempts, err := s.loginAttempts.Get(loginAccountKey(user.Email))
+	if err != nil {
+		return fmt.Errorf("failed to read login attempts: %w", err)
+	}
+	if err := s.loginAttempts.Reset(loginAccountKey(user.Email)); err != nil {
+		return fmt.Errorf("failed to unlock account: %w", err)
+	}
+
+	if s.now().Before(attempts.LockedUntil) {
+		s.audit.Record(AuditEvent{Type: AuditAccountUnlocked, UserID: user.ID, Email: user.Email, At: s.now()})
+	}
+	return nil
+}
+
+// checkLoginAllowed rejects a login while key is locked out or, if delayed is set,
+// inside its retry delay. Client IPs are not delayed since many users may share one.
+// A store failure lets the attempt through rather than locking everyone out.
+func (s *AuthService) checkLoginAllowed(key string, delayed bool, now time.Time) error {
+	if key == "" {
+		return nil
+	}
+
+	attempts, err := s.loginAttempts.Get(key)
+	if err != nil {
+		slog.Warn("Failed to read login attempts", "key", key, "error", err)
+		return nil
+	}
+
+	if now.Before(attempts.LockedUntil) {
+		return NewError(ErrRateLimited, "too many failed login attempts, try again in %d seconds",
+			CeilSeconds(attempts.LockedUntil.Sub(now)))
+	}
+
+	if delayed && attempts.Failures > LoginDelayAfter && now.Sub(attempts.LastFailure) <= LoginFailureWindow {
+		delay := LoginBaseDelay << (attempts.Failures - LoginDelayAfter - 1)
+		if retryAt := attempts.LastFailure.Add(delay); now.Before(retryAt) {
+			return NewError(ErrRateLimited, "too many failed login attempts, try again in %d seconds",
+				CeilSeconds(retryAt.Sub(now)))
+		}
+	}
+
+	return nil
+}
+
+// recordLoginFailure counts a failed login against the account and the client IP
+// and locks whichever crosses its threshold. userID is 0 for unknown emails, which
+// are tracked the same way so responses do not reveal which accounts exist.
+func (s *AuthService) recordLoginFailure(req models.LoginRequest, userID int, now time.Time) {
+	if s.countLoginFailure(loginAccountKey(req.Email), MaxLoginFailures, now) {
+		s.audit.Record(AuditEvent{
+			Type:   AuditAccountLocked,
+			UserID: userID,
+			Email:  req.Email,
+			IP:     req.ClientIP,
+			At:     now,
+			Until:  now.Add(LoginLockoutDuration),
+		})
+	}
+
+	if ipKey := loginIPKey(req.ClientIP); ipKey != "" && s.countLoginFailure(ipKey, MaxIPLoginFailures, now) {
+		s.audit.Record(AuditEvent{
+			Type:  AuditIPLocked,
+			IP:    req.ClientIP,
+			At:    now,
+			Until: now.Add(LoginLockoutDuration),
+		})
+	}
+}
+
+// countLoginFailure records a failure for key and reports whether it just got locked
+func (s *AuthService) countLoginFailure(key string, maxFailures int, now time.Time) bool {
+	attempts, err := s.loginAttempts.RecordFailure(key, now, LoginFailureWindow)
+	if err != nil {
+		slog.Warn("Failed to record login failure", "key", key, "error", err)
+		return false
+	}
+	if attempts.Failures < maxFailures || now.Before(attempts.LockedUntil) {
+		return false
+	}
+
+	if err := s.loginAttempts.Lock(key, now.Add(LoginLockoutDuration)); err != nil {
+		slog.Warn("Failed to lock login", "key", key, "error", err)
+		return false
+	}
+	return true
+}
+
+// loginAccountKey returns the login attempt key of an account
+func loginAccountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// loginIPKey returns the login attempt key of a client IP, or "" if it is unknown
+func loginIPKey(ip string) string {
+	if ip == "" {
+		return ""
+	}
+	return "ip:" + ip
+}
+
+// CeilSeconds rounds a duration up to whole seconds, the unit in which wait
+// times are reported to clients (lockout messages, Retry-After)
+func CeilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// Refresh exchanges a refresh token for a new token pair in the same session.
+// Each refresh token is single-use: presenting one that was already exchanged
+// is treated as theft and revokes the whole session.
+func (s *AuthService) Refresh(req models.RefreshTokenRequest) (models.TokenResponse, error) {
+	// Validate required fields
+	if err := validateRequest(req); err != nil {
+		return models.TokenResponse{}, err
+	}
+
+	// Look up the stored token by hash
+	stored, err := s.sessionRepo.GetRefreshTokenByHash(hashToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return models.TokenResponse{}, NewError(ErrUnauthenticated, "invalid refresh token")
+		}
+		return models.TokenResponse{}, err
+	}
+
+	// Check the session is still active
+	session, err := s.sessionRepo.GetSession(stored.SessionID)
+	if err != nil {
+		return models.TokenResponse{}, err
+	}
+	if session.RevokedAt != nil {
+		return models.TokenResponse{}, NewError(ErrUnauthenticated, "session has been revoked")
+	}
+
+	now := time.Now()
+	if now.After(stored.ExpiresAt) {
+		return models.TokenResponse{}, NewError(ErrUnauthenticated, "refresh token expired")
+	}
+
+	// Consume the token; a second use means it leaked
+	if stored.UsedAt != nil {
+		return models.TokenResponse{}, s.revokeReusedSession(session)
+	}
+	if err := s.sessionRepo.MarkRefreshTokenUsed(stored.ID, now); err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenUsed) {
+			return models.TokenResponse{}, s.revokeReusedSession(session)
+		}
+		return models.TokenResponse{}, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(stored.UserID)
+	if err != nil {
+		return models.TokenResponse{}, err
+	}
+
+	return s.issueTokens(user, session.ID)
+}
+
+// Logout revokes a single session
+func (s *AuthService) Logout(sessionID string) error {
+	if err := s.sessionRepo.RevokeSession(sessionID, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+// LogoutAll revokes every session of a user
+func (s *AuthService) LogoutAll(userID int) error {
+	if err := s.sessionRepo.RevokeUserSessions(userID, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
+
+// revokeReusedSession revokes a session whose refresh token was presented twice
+func (s *AuthService) revokeReusedSession(session models.Session) error {
+	if err := s.sessionRepo.RevokeSession(session.ID, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return NewError(ErrUnauthenticated, "refresh token reuse detected")
+}
+
+// startSession creates a new session for the user and issues its first token pair
+func (s *AuthService) startSession(user models.User) (models.TokenResponse, error) {
+	session := models.Session{
+		ID:     uuid.New().String(),
+		UserID: user.ID,
+	}
+	if err := s.sessionRepo.CreateSession(&session); err != nil {
+		return models.TokenResponse{}, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return s.issueTokens(user, session.ID)
+}
+
+// issueTokens issues a new token pair for an existing session
+func (s *AuthService) issueTokens(user models.User, sessionID string) (models.TokenResponse, error) {
+	accessToken, err := s.generateToken(user.ID, user.Email, sessionID)
+	if err != nil {
+		return models.TokenResponse{}, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	refreshToken, err := s.createRefreshToken(user.ID, sessionID)
+	if err != nil {
+		return models.TokenResponse{}, err
+	}
+
+	return models.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+// createRefreshToken generates a random refresh token and stores its hash
+func (s *AuthService) createRefreshToken(userID int, sessionID string) (string, error) {
+	token, err := generateOpaqueToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	stored := models.RefreshToken{
+		SessionID: sessionID,
+		UserID:    userID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(RefreshTokenTTL),
+	}
+	if err := s.sessionRepo.CreateRefreshToken(&stored); err != nil {
+		return "", fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return token, nil
+}
+
+// generateOpaqueToken returns a random, URL-safe token with 256 bits of entropy
+func generateOpaqueToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashToken returns the hex-encoded SHA-256 hash of an opaque token
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateToken creates a JWT access token for the user's session
+func (s *AuthService) generateToken(userID int, email, sessionID string) (string, error) {
 	// Get JWT secret from environment variable
 	secret := os.Getenv("JWT_SECRET")
 	if secret == "" {
@@ -64,10 +377,11 @@ func (s *AuthService) generateToken(userID int, email string) (string, error) {
 
 	// Create claims
 	claims := models.Claims{
-		UserID: userID,
-		Email:  email,
+		UserID:    userID,
+		Email:     email,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)), // 24 hours
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
@@ -84,7 +398,8 @@ func (s *AuthService) generateToken(userID int, email string) (string, error) {
 	return tokenString, nil
 }
 
-// ValidateToken validates a JWT token and returns the claims
+// ValidateToken validates a JWT token and returns the claims.
+// Tokens whose session has been revoked are rejected.
 func (s *AuthService) ValidateToken(tokenString string) (*models.Claims, error) {
 	// Get JWT secret from environment variable
 	secret := os.Getenv("JWT_SECRET")
@@ -107,9 +422,18 @@ func (s *AuthService) ValidateToken(tokenString string) (*models.Claims, error)
 
 	// Extract claims
 	claims, ok := token.Claims.(*models.Claims)
-	if !ok || !token.Valid {
+	if !ok || !token.Valid || claims.SessionID == "" {
 		return nil, fmt.Errorf("invalid token claims")
 	}
 
+	// Check the session has not been revoked
+	session, err := s.sessionRepo.GetSession(claims.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token session: %w", err)
+	}
+	if session.RevokedAt != nil || session.UserID != claims.UserID {
+		return nil, fmt.Errorf("session has been revoked")
+	}
+
 	return claims, nil
 }
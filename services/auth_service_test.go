@@ -1,9 +1,11 @@
 package services
 
 import (
+	"errors"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 	"python-backend-with-go/models"
@@ -17,8 +19,8 @@ func TestAuthService_Login(t *testing.T) {
 
 	// Setup repository and services
 	userRepo := repository.NewInMemoryUserRepository()
-	userService := NewUserService(userRepo)
-	authService := NewAuthService(userRepo)
+	userService := newTestUserService(userRepo)
+	authService := NewAuthService(userRepo, repository.NewInMemorySessionRepository(), repository.NewInMemoryLoginAttemptStore())
 
 	// Create a test user
 	signupReq := models.SignupRequest{
@@ -52,7 +54,7 @@ func TestAuthService_Login(t *testing.T) {
 				Password: "password123",
 			},
 			expectError: true,
-			errorMsg:    "email and password are required",
+			errorMsg:    "email is required",
 		},
 		{
 			name: "missing password",
@@ -60,7 +62,7 @@ func TestAuthService_Login(t *testing.T) {
 				Email: "hong@test.com",
 			},
 			expectError: true,
-			errorMsg:    "email and password are required",
+			errorMsg:    "password is required",
 		},
 		{
 			name: "invalid email",
@@ -117,8 +119,8 @@ func TestAuthService_ValidateToken(t *testing.T) {
 
 	// Setup repository and services
 	userRepo := repository.NewInMemoryUserRepository()
-	userService := NewUserService(userRepo)
-	authService := NewAuthService(userRepo)
+	userService := newTestUserService(userRepo)
+	authService := NewAuthService(userRepo, repository.NewInMemorySessionRepository(), repository.NewInMemoryLoginAttemptStore())
 
 	// Create a test user and login to get a valid token
 	signupReq := models.SignupRequest{
@@ -200,8 +202,8 @@ func TestAuthService_ValidateToken_WrongSecret(t *testing.T) {
 
 	// Setup and create token
 	userRepo := repository.NewInMemoryUserRepository()
-	userService := NewUserService(userRepo)
-	authService := NewAuthService(userRepo)
+	userService := newTestUserService(userRepo)
+	authService := NewAuthService(userRepo, repository.NewInMemorySessionRepository(), repository.NewInMemoryLoginAttemptStore())
 
 	signupReq := models.SignupRequest{
 		Name:     "홍길동",
@@ -238,8 +240,8 @@ func TestAuthService_Login_PasswordHashing(t *testing.T) {
 	defer os.Unsetenv("JWT_SECRET")
 
 	userRepo := repository.NewInMemoryUserRepository()
-	userService := NewUserService(userRepo)
-	authService := NewAuthService(userRepo)
+	userService := newTestUserService(userRepo)
+	authService := NewAuthService(userRepo, repository.NewInMemorySessionRepository(), repository.NewInMemoryLoginAttemptStore())
 
 	// Create user
 	password := "mySecretPassword123"
@@ -286,8 +288,8 @@ func TestAuthService_GenerateToken_NoSecret(t *testing.T) {
 	os.Unsetenv("JWT_SECRET")
 
 	userRepo := repository.NewInMemoryUserRepository()
-	userService := NewUserService(userRepo)
-	authService := NewAuthService(userRepo)
+	userService := newTestUserService(userRepo)
+	authService := NewAuthService(userRepo, repository.NewInMemorySessionRepository(), repository.NewInMemoryLoginAttemptStore())
 
 	// Create user
 	signupReq := models.SignupRequest{
@@ -314,3 +316,356 @@ func TestAuthService_GenerateToken_NoSecret(t *testing.T) {
 		t.Errorf("Expected error message to mention JWT_SECRET, got: %s", err.Error())
 	}
 }
+
+func setupSessionTest(t *testing.T) (*AuthService, models.LoginResponse) {
+	t.Helper()
+	os.Setenv("JWT_SECRET", "test_secret_key_for_testing")
+	t.Cleanup(func() { os.Unsetenv("JWT_SECRET") })
+
+	userRepo := repository.NewInMemoryUserRepository()
+	userService := newTestUserService(userRepo)
+	authService := NewAuthService(userRepo, repository.NewInMemorySessionRepository(), repository.NewInMemoryLoginAttemptStore())
+
+	_, err := userService.Signup(models.SignupRequest{
+		Name:     "홍길동",
+		Email:    "hong@test.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+
+	loginResp, err := authService.Login(models.LoginRequest{
+		Email:    "hong@test.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to login: %v", err)
+	}
+	if loginResp.RefreshToken == "" {
+		t.Fatal("Expected refresh token, got empty string")
+	}
+
+	return authService, loginResp
+}
+
+func TestAuthService_Refresh_Rotation(t *testing.T) {
+	authService, loginResp := setupSessionTest(t)
+
+	// Exchange the refresh token
+	tokens, err := authService.Refresh(models.RefreshTokenRequest{RefreshToken: loginResp.RefreshToken})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tokens.RefreshToken == "" || tokens.RefreshToken == loginResp.RefreshToken {
+		t.Errorf("Expected a new refresh token, got '%s'", tokens.RefreshToken)
+	}
+	if tokens.ExpiresIn != int(AccessTokenTTL.Seconds()) {
+		t.Errorf("Expected expires_in %d, got %d", int(AccessTokenTTL.Seconds()), tokens.ExpiresIn)
+	}
+
+	// The new access token belongs to the same session
+	oldClaims, err := authService.ValidateToken(loginResp.AccessToken)
+	if err != nil {
+		t.Fatalf("Unexpected error validating original token: %v", err)
+	}
+	newClaims, err := authService.ValidateToken(tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("Unexpected error validating refreshed token: %v", err)
+	}
+	if oldClaims.SessionID != newClaims.SessionID {
+		t.Errorf("Expected session %s, got %s", oldClaims.SessionID, newClaims.SessionID)
+	}
+
+	// The rotated token can be exchanged again
+	if _, err := authService.Refresh(models.RefreshTokenRequest{RefreshToken: tokens.RefreshToken}); err != nil {
+		t.Errorf("Unexpected error refreshing rotated token: %v", err)
+	}
+}
+
+func TestAuthService_Refresh_ReuseRevokesSession(t *testing.T) {
+	authService, loginResp := setupSessionTest(t)
+
+	tokens, err := authService.Refresh(models.RefreshTokenRequest{RefreshToken: loginResp.RefreshToken})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Replaying the original refresh token is detected
+	_, err = authService.Refresh(models.RefreshTokenRequest{RefreshToken: loginResp.RefreshToken})
+	if err == nil {
+		t.Fatal("Expected error for reused refresh token, got none")
+	}
+	if err.Error() != "refresh token reuse detected" {
+		t.Errorf("Expected 'refresh token reuse detected', got '%s'", err.Error())
+	}
+	if !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("Expected unauthenticated error, got %v", err)
+	}
+
+	// The whole session is now revoked
+	if _, err := authService.Refresh(models.RefreshTokenRequest{RefreshToken: tokens.RefreshToken}); err == nil {
+		t.Error("Expected error refreshing in revoked session, got none")
+	}
+	if _, err := authService.ValidateToken(tokens.AccessToken); err == nil {
+		t.Error("Expected access token of revoked session to be rejected")
+	}
+}
+
+func TestAuthService_Refresh_InvalidToken(t *testing.T) {
+	authService, _ := setupSessionTest(t)
+
+	tests := []struct {
+		name     string
+		token    string
+		errorMsg string
+	}{
+		{
+			name:     "missing token",
+			token:    "",
+			errorMsg: "refresh_token is required",
+		},
+		{
+			name:     "unknown token",
+			token:    "not-a-refresh-token",
+			errorMsg: "invalid refresh token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := authService.Refresh(models.RefreshTokenRequest{RefreshToken: tt.token})
+			if err == nil {
+				t.Errorf("Expected error but got none")
+			} else if err.Error() != tt.errorMsg {
+				t.Errorf("Expected error '%s', got '%s'", tt.errorMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	authService, loginResp := setupSessionTest(t)
+
+	// A second, independent session
+	otherResp, err := authService.Login(models.LoginRequest{
+		Email:    "hong@test.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to login: %v", err)
+	}
+
+	claims, err := authService.ValidateToken(loginResp.AccessToken)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := authService.Logout(claims.SessionID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Only the logged out session is revoked
+	if _, err := authService.ValidateToken(loginResp.AccessToken); err == nil {
+		t.Error("Expected access token to be rejected after logout")
+	}
+	if _, err := authService.Refresh(models.RefreshTokenRequest{RefreshToken: loginResp.RefreshToken}); err == nil {
+		t.Error("Expected refresh token to be rejected after logout")
+	}
+	if _, err := authService.ValidateToken(otherResp.AccessToken); err != nil {
+		t.Errorf("Expected other session to remain valid, got %v", err)
+	}
+}
+
+func TestAuthService_LogoutAll(t *testing.T) {
+	authService, loginResp := setupSessionTest(t)
+
+	otherResp, err := authService.Login(models.LoginRequest{
+		Email:    "hong@test.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Failed to login: %v", err)
+	}
+
+	if err := authService.LogoutAll(loginResp.UserID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, token := range []string{loginResp.AccessToken, otherResp.AccessToken} {
+		if _, err := authService.ValidateToken(token); err == nil {
+			t.Error("Expected every session to be revoked")
+		}
+	}
+}
+
+// fakeClock is a manually advanced clock for time-dependent tests
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// recordingAuditLogger keeps audit events in memory
+type recordingAuditLogger struct {
+	events []AuditEvent
+}
+
+func (l *recordingAuditLogger) Record(event AuditEvent) { l.events = append(l.events, event) }
+
+func setupThrottleTest(t *testing.T) (*AuthService, *fakeClock, *recordingAuditLogger, models.User) {
+	t.Helper()
+	os.Setenv("JWT_SECRET", "test_secret_key_for_testing")
+	t.Cleanup(func() { os.Unsetenv("JWT_SECRET") })
+
+	userRepo := repository.NewInMemoryUserRepository()
+	authService := NewAuthService(userRepo, repository.NewInMemorySessionRepository(), repository.NewInMemoryLoginAttemptStore())
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	audit := &recordingAuditLogger{}
+	authService.now = clock.Now
+	authService.audit = audit
+
+	// A low bcrypt cost keeps the many failed logins fast
+	hashed, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	user := models.User{Name: "홍길동", Email: "hong@test.com", HashedPassword: string(hashed)}
+	if err := userRepo.Create(&user); err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+
+	return authService, clock, audit, user
+}
+
+// failLogins makes n failed logins, waiting out the retry delay before each one
+func failLogins(t *testing.T, authService *AuthService, clock *fakeClock, email, ip string, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		clock.Advance(time.Minute)
+		_, err := authService.Login(models.LoginRequest{Email: email, Password: "wrong", ClientIP: ip})
+		if !errors.Is(err, ErrUnauthenticated) {
+			t.Fatalf("failed login %d: expected ErrUnauthenticated, got %v", i+1, err)
+		}
+	}
+}
+
+func TestAuthService_Login_LocksAccount(t *testing.T) {
+	authService, clock, audit, user := setupThrottleTest(t)
+	correct := models.LoginRequest{Email: user.Email, Password: "password123", ClientIP: "10.0.0.1"}
+
+	failLogins(t, authService, clock, user.Email, "10.0.0.1", MaxLoginFailures)
+
+	if len(audit.events) != 1 || audit.events[0].Type != AuditAccountLocked || audit.events[0].UserID != user.ID {
+		t.Fatalf("Expected one account_locked audit event for user %d, got %+v", user.ID, audit.events)
+	}
+
+	// The correct password is refused while locked, from any IP
+	clock.Advance(LoginLockoutDuration / 2)
+	correct.ClientIP = "10.0.0.2"
+	if _, err := authService.Login(correct); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited while locked, got %v", err)
+	}
+
+	// The lock expires
+	clock.Advance(LoginLockoutDuration)
+	if _, err := authService.Login(correct); err != nil {
+		t.Fatalf("Expected login after lockout expired, got %v", err)
+	}
+	if len(audit.events) != 1 {
+		t.Errorf("Expected no further audit events, got %+v", audit.events)
+	}
+}
+
+func TestAuthService_Login_ProgressiveDelay(t *testing.T) {
+	authService, clock, _, user := setupThrottleTest(t)
+	correct := models.LoginRequest{Email: user.Email, Password: "password123"}
+
+	// No delay up to LoginDelayAfter failures
+	failLogins(t, authService, clock, user.Email, "", LoginDelayAfter)
+	if _, err := authService.Login(models.LoginRequest{Email: user.Email, Password: "wrong"}); !errors.Is(err, ErrUnauthenticated) {
+		t.Fatalf("Expected ErrUnauthenticated without delay, got %v", err)
+	}
+
+	// The next attempt must wait LoginBaseDelay
+	clock.Advance(LoginBaseDelay - time.Millisecond)
+	if _, err := authService.Login(correct); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited inside delay, got %v", err)
+	}
+	clock.Advance(time.Millisecond)
+	if _, err := authService.Login(models.LoginRequest{Email: user.Email, Password: "wrong"}); !errors.Is(err, ErrUnauthenticated) {
+		t.Fatalf("Expected ErrUnauthenticated after delay, got %v", err)
+	}
+
+	// The delay doubles after each further failure
+	clock.Advance(2*LoginBaseDelay - time.Millisecond)
+	if _, err := authService.Login(correct); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited inside doubled delay, got %v", err)
+	}
+
+	// A successful login clears the failures
+	clock.Advance(time.Millisecond)
+	if _, err := authService.Login(correct); err != nil {
+		t.Fatalf("Expected successful login, got %v", err)
+	}
+	if _, err := authService.Login(models.LoginRequest{Email: user.Email, Password: "wrong"}); !errors.Is(err, ErrUnauthenticated) {
+		t.Fatalf("Expected ErrUnauthenticated, got %v", err)
+	}
+	if _, err := authService.Login(correct); err != nil {
+		t.Errorf("Expected failures to be cleared after success, got %v", err)
+	}
+}
+
+func TestAuthService_Login_FailuresExpire(t *testing.T) {
+	authService, clock, audit, user := setupThrottleTest(t)
+
+	failLogins(t, authService, clock, user.Email, "", MaxLoginFailures-1)
+	clock.Advance(LoginFailureWindow + time.Second)
+	failLogins(t, authService, clock, user.Email, "", MaxLoginFailures-1)
+
+	if len(audit.events) != 0 {
+		t.Errorf("Expected no lockout when failures are spread out, got %+v", audit.events)
+	}
+}
+
+func TestAuthService_Login_LocksIP(t *testing.T) {
+	authService, clock, audit, user := setupThrottleTest(t)
+
+	// Spread failures over many (mostly unknown) accounts from one IP
+	for i := 0; i < MaxIPLoginFailures; i++ {
+		email := "victim" + string(rune('a'+i)) + "@test.com"
+		failLogins(t, authService, clock, email, "10.0.0.1", 1)
+	}
+
+	if len(audit.events) != 1 || audit.events[0].Type != AuditIPLocked || audit.events[0].IP != "10.0.0.1" {
+		t.Fatalf("Expected one ip_locked audit event, got %+v", audit.events)
+	}
+
+	correct := models.LoginRequest{Email: user.Email, Password: "password123", ClientIP: "10.0.0.1"}
+	if _, err := authService.Login(correct); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited from locked IP, got %v", err)
+	}
+
+	correct.ClientIP = "10.0.0.2"
+	if _, err := authService.Login(correct); err != nil {
+		t.Errorf("Expected login from another IP, got %v", err)
+	}
+}
+
+func TestAuthService_UnlockAccount(t *testing.T) {
+	authService, clock, audit, user := setupThrottleTest(t)
+
+	failLogins(t, authService, clock, user.Email, "", MaxLoginFailures)
+
+	if err := authService.UnlockAccount(user); err != nil {
+		t.Fatalf("UnlockAccount failed: %v", err)
+	}
+	if len(audit.events) != 2 || audit.events[1].Type != AuditAccountUnlocked {
+		t.Errorf("Expected account_unlocked audit event, got %+v", audit.events)
+	}
+
+	if _, err := authService.Login(models.LoginRequest{Email: user.Email, Password: "password123"}); err != nil {
+		t.Errorf("Expected login after unlock, got %v", err)
+	}
+}
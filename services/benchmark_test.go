@@ -0,0 +1,110 @@
+package services
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+// countingUserRepository counts the user queries issued against the wrapped repository
+type countingUserRepository struct {
+	repository.UserRepository
+	queries atomic.Int64
+}
+
+func (r *countingUserRepository) GetByID(id int) (models.User, error) {
+	r.queries.Add(1)
+	return r.UserRepository.GetByID(id)
+}
+
+func (r *countingUserRepository) GetByIDs(ids []int) (map[int]models.User, error) {
+	r.queries.Add(1)
+	return r.UserRepository.GetByIDs(ids)
+}
+
+func setupBenchmarkUsers(b *testing.B, n int) (*countingUserRepository, []int) {
+	b.Helper()
+	userRepo := &countingUserRepository{UserRepository: repository.NewInMemoryUserRepository()}
+	ids := make([]int, n)
+	for i := range ids {
+		user := models.User{Name: fmt.Sprintf("User%d", i), Email: fmt.Sprintf("user%d@test.com", i)}
+		if err := userRepo.Create(&user); err != nil {
+			b.Fatalf("Failed to create user: %v", err)
+		}
+		ids[i] = user.ID
+	}
+	return userRepo, ids
+}
+
+// BenchmarkUserLookup_PerID is the previous one-query-per-user pattern, kept as a baseline
+func BenchmarkUserLookup_PerID(b *testing.B) {
+	userRepo, ids := setupBenchmarkUsers(b, 100)
+	userRepo.queries.Store(0)
+
+	for i := 0; i < b.N; i++ {
+		for _, id := range ids {
+			userRepo.GetByID(id)
+		}
+	}
+	b.ReportMetric(float64(userRepo.queries.Load())/float64(b.N), "queries/op")
+}
+
+func BenchmarkUserService_GetUsersByIDs(b *testing.B) {
+	userRepo, ids := setupBenchmarkUsers(b, 100)
+	userService := newTestUserService(userRepo)
+	userRepo.queries.Store(0)
+
+	for i := 0; i < b.N; i++ {
+		if _, err := userService.GetUsersByIDs(ids); err != nil {
+			b.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	b.ReportMetric(float64(userRepo.queries.Load())/float64(b.N), "queries/op")
+}
+
+func BenchmarkFollowService_GetFollowers(b *testing.B) {
+	userRepo, ids := setupBenchmarkUsers(b, 101)
+	followRepo := repository.NewInMemoryFollowRepository()
+	postRepo := repository.NewInMemoryPostRepository()
+	timelineService := NewTimelineService(repository.NewInMemoryTimelineStore(), postRepo, userRepo, followRepo)
+	followService := NewFollowService(followRepo, userRepo, timelineService, newTestNotificationService(postRepo, userRepo))
+
+	for _, id := range ids[1:] {
+		followRepo.Create(models.Follow{UserID: id, FollowUserID: ids[0]})
+	}
+	userRepo.queries.Store(0)
+
+	for i := 0; i < b.N; i++ {
+		if _, err := followService.GetFollowers(ids[0], models.PageRequest{Limit: MaxPageLimit}); err != nil {
+			b.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	b.ReportMetric(float64(userRepo.queries.Load())/float64(b.N), "queries/op")
+}
+
+func BenchmarkPostService_GetTimeline(b *testing.B) {
+	userRepo, ids := setupBenchmarkUsers(b, 51)
+	followRepo := repository.NewInMemoryFollowRepository()
+	postRepo := repository.NewInMemoryPostRepository()
+	timelineService := NewTimelineService(repository.NewInMemoryTimelineStore(), postRepo, userRepo, followRepo)
+	postService := NewPostService(postRepo, userRepo, followRepo, repository.NewInMemoryLikeRepository(), timelineService, newTestSearchService(postRepo, userRepo), newTestTrendService(postRepo), newTestNotificationService(postRepo, userRepo))
+
+	// User 0 follows 50 users who each posted twice
+	for _, id := range ids[1:] {
+		followRepo.Create(models.Follow{UserID: ids[0], FollowUserID: id})
+		for j := 0; j < 2; j++ {
+			postRepo.Create(&models.Post{UserID: id, Content: "게시글"})
+		}
+	}
+	userRepo.queries.Store(0)
+
+	for i := 0; i < b.N; i++ {
+		if _, err := postService.GetTimeline(ids[0], 0, models.PageRequest{Limit: MaxPageLimit}); err != nil {
+			b.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	b.ReportMetric(float64(userRepo.queries.Load())/float64(b.N), "queries/op")
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+// MaxHashtagLength is the longest hashtag recognized, in characters without the #
+const MaxHashtagLength = 100
+
+// taggedWord is a word prefixed with # or @ in a post, at code point offsets
+// [start, end) including the prefix
+type taggedWord struct {
+	start int
+	end   int
+	word  string // without the prefix
+}
+
+// scanTaggedWords finds the words prefixed with sigil in content. A word is a
+// run of letters, digits, combining marks and underscores in any script; the
+// sigil must not follow such a character, so e-mail addresses are not mentions.
+func scanTaggedWords(content string, sigil rune) []taggedWord {
+	runes := []rune(content)
+	var words []taggedWord
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != sigil || (i > 0 && (isWordRune(runes[i-1]) || runes[i-1] == sigil)) {
+			continue
+		}
+		end := i + 1
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+		if end > i+1 {
+			words = append(words, taggedWord{start: i, end: end, word: string(runes[i+1 : end])})
+		}
+		i = end - 1
+	}
+	return words
+}
+
+// isWordRune reports whether r can be part of a hashtag or mentioned name
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r) || r == '_'
+}
+
+// normalizeHashtag returns the stored form of a hashtag written without the #,
+// reporting false if it is not a valid hashtag: hashtags need at least one
+// letter, so "#1" is not one
+func normalizeHashtag(word string) (string, bool) {
+	if utf8.RuneCountInString(word) > MaxHashtagLength || strings.IndexFunc(word, unicode.IsLetter) < 0 {
+		return "", false
+	}
+	for _, r := range word {
+		if !isWordRune(r) {
+			return "", false
+		}
+	}
+	return strings.ToLower(word), true
+}
+
+// parseEntities finds the hashtags and mentions in a post's content. Mentions
+// link to the user that users maps the mentioned name to; other @words are
+// left as plain text.
+func parseEntities(content string, users map[string]int) models.PostEntities {
+	entities := models.PostEntities{
+		Hashtags: []models.HashtagEntity{},
+		Mentions: []models.MentionEntity{},
+	}
+	for _, word := range scanTaggedWords(content, '#') {
+		if tag, ok := normalizeHashtag(word.word); ok {
+			entities.Hashtags = append(entities.Hashtags, models.HashtagEntity{Start: word.start, End: word.end, Tag: tag})
+		}
+	}
+	for _, word := range scanTaggedWords(content, '@') {
+		if userID, ok := users[word.word]; ok {
+			entities.Mentions = append(entities.Mentions, models.MentionEntity{Start: word.start, End: word.end, UserID: userID, Name: word.word})
+		}
+	}
+	return entities
+}
+
+// resolveEntities parses the entities of new post content, linking each
+// @name to the user with exactly that name. Names shared by several users
+// are ambiguous and not linked.
+func resolveEntities(userRepo repository.UserRepository, content string) (models.PostEntities, error) {
+	var names []string
+	for _, word := range scanTaggedWords(content, '@') {
+		names = append(names, word.word)
+	}
+
+	users := make(map[string]int)
+	if len(names) > 0 {
+		found, err := userRepo.GetByNames(names)
+		if err != nil {
+			return models.PostEntities{}, fmt.Errorf("failed to get mentioned users: %w", err)
+		}
+		ambiguous := make(map[string]bool)
+		for _, user := range found {
+			if _, seen := users[user.Name]; seen {
+				ambiguous[user.Name] = true
+			}
+			users[user.Name] = user.ID
+		}
+		for name := range ambiguous {
+			delete(users, name)
+		}
+	}
+
+	return parseEntities(content, users), nil
+}
+
+// attachEntities fills in the hashtags and mentions of posts, linking
+// mentions to the users recorded when each post was written
+func attachEntities(postRepo repository.PostRepository, posts []models.PostWithUser) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]int, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+	mentions, err := postRepo.GetMentionsByPostIDs(postIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get mentions: %w", err)
+	}
+
+	for i := range posts {
+		posts[i].Entities = storedEntities(posts[i].Content, mentions[posts[i].ID])
+	}
+	return nil
+}
+
+// storedEntities parses the entities of stored post content with its recorded mentions
+func storedEntities(content string, mentions []models.PostMention) models.PostEntities {
+	users := make(map[string]int, len(mentions))
+	for _, mention := range mentions {
+		users[mention.Name] = mention.UserID
+	}
+	return parseEntities(content, users)
+}
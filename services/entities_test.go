@@ -0,0 +1,64 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"python-backend-with-go/models"
+)
+
+func TestParseEntities(t *testing.T) {
+	users := map[string]int{"alice": 1, "김철수": 2}
+
+	tests := []struct {
+		name     string
+		content  string
+		hashtags []models.HashtagEntity
+		mentions []models.MentionEntity
+	}{
+		{
+			name:     "ascii",
+			content:  "hi @alice, see #Go!",
+			hashtags: []models.HashtagEntity{{Start: 15, End: 18, Tag: "go"}},
+			mentions: []models.MentionEntity{{Start: 3, End: 9, UserID: 1, Name: "alice"}},
+		},
+		{
+			name:     "offsets count code points",
+			content:  "😀 #맛집 @김철수 님",
+			hashtags: []models.HashtagEntity{{Start: 2, End: 5, Tag: "맛집"}},
+			mentions: []models.MentionEntity{{Start: 6, End: 10, UserID: 2, Name: "김철수"}},
+		},
+		{
+			name:     "hashtags need a letter and a word boundary",
+			content:  "#1 a#b ##x #_ok #snake_case",
+			hashtags: []models.HashtagEntity{{Start: 11, End: 15, Tag: "_ok"}, {Start: 16, End: 27, Tag: "snake_case"}},
+		},
+		{
+			name:    "e-mail addresses and unknown names are not mentions",
+			content: "mail alice@example.com or @bob or @Alice",
+		},
+		{
+			name:    "too long hashtag",
+			content: "#" + strings.Repeat("a", MaxHashtagLength+1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseEntities(tt.content, users)
+			if tt.hashtags == nil {
+				tt.hashtags = []models.HashtagEntity{}
+			}
+			if tt.mentions == nil {
+				tt.mentions = []models.MentionEntity{}
+			}
+			if !reflect.DeepEqual(got.Hashtags, tt.hashtags) {
+				t.Errorf("Hashtags = %+v, want %+v", got.Hashtags, tt.hashtags)
+			}
+			if !reflect.DeepEqual(got.Mentions, tt.mentions) {
+				t.Errorf("Mentions = %+v, want %+v", got.Mentions, tt.mentions)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"python-backend-with-go/repository"
+	"python-backend-with-go/validation"
+)
+
+// Error kinds. Every error returned by a service either wraps one of these
+// or is an unexpected (internal) failure.
+var (
+	// ErrNotFound is shared with the repository layer so repository errors classify directly
+	ErrNotFound        = repository.ErrNotFound
+	ErrConflict        = errors.New("conflict")
+	ErrValidation      = errors.New("validation failed")
+	ErrForbidden       = errors.New("forbidden")
+	ErrUnauthenticated = errors.New("unauthenticated")
+	ErrRateLimited     = errors.New("rate limited")
+)
+
+// Error is a domain error with a client-facing message classified by one of the error kinds
+type Error struct {
+	Kind    error
+	Message string
+	Fields  map[string]string // invalid fields and their reasons, for field-level validation errors
+}
+
+// Error returns the client-facing message
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the error kind so errors.Is matches it
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// NewError creates a domain error of the given kind
+func NewError(kind error, format string, args ...any) error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+// validateRequest checks a request against its validate tags. Invalid fields
+// are reported as an ErrValidation error listing each field.
+func validateRequest(req any) error {
+	fields := validation.Struct(req)
+	if len(fields) == 0 {
+		return nil
+	}
+	return &Error{Kind: ErrValidation, Message: fields.Error(), Fields: fields}
+}
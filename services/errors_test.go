@@ -0,0 +1,130 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"python-backend-with-go/models"
+)
+
+func TestServiceErrors_Kinds(t *testing.T) {
+	postService, userService, followService := setupPostServiceTest(t)
+
+	createResp, err := postService.CreatePost(1, models.CreatePostRequest{Content: "게시글"})
+	if err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+	followService.Follow(1, 2)
+
+	tests := []struct {
+		name string
+		call func() error
+		kind error
+	}{
+		{
+			name: "missing content",
+			call: func() error {
+				_, err := postService.CreatePost(1, models.CreatePostRequest{})
+				return err
+			},
+			kind: ErrValidation,
+		},
+		{
+			name: "invalid cursor",
+			call: func() error {
+				_, err := postService.GetTimeline(1, 0, models.PageRequest{Cursor: "???"})
+				return err
+			},
+			kind: ErrValidation,
+		},
+		{
+			name: "post not found",
+			call: func() error {
+				_, err := postService.DeletePost(999, 1)
+				return err
+			},
+			kind: ErrNotFound,
+		},
+		{
+			name: "user not found",
+			call: func() error {
+				_, err := postService.GetUserPosts(999, 0, models.PageRequest{})
+				return err
+			},
+			kind: ErrNotFound,
+		},
+		{
+			name: "follow relationship not found",
+			call: func() error {
+				_, err := followService.Unfollow(2, 1)
+				return err
+			},
+			kind: ErrNotFound,
+		},
+		{
+			name: "not the post owner",
+			call: func() error {
+				_, err := postService.DeletePost(createResp.PostID, 2)
+				return err
+			},
+			kind: ErrForbidden,
+		},
+		{
+			name: "already following",
+			call: func() error {
+				_, err := followService.Follow(1, 2)
+				return err
+			},
+			kind: ErrConflict,
+		},
+		{
+			name: "duplicate email",
+			call: func() error {
+				_, err := userService.Signup(models.SignupRequest{
+					Name:     "User1",
+					Email:    "user1@test.com",
+					Password: "password123",
+				})
+				return err
+			},
+			kind: ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if err == nil {
+				t.Fatal("Expected error but got none")
+			}
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("Expected error kind '%v', got '%v'", tt.kind, err)
+			}
+		})
+	}
+}
+
+func TestValidateRequest_Fields(t *testing.T) {
+	err := validateRequest(models.SignupRequest{Name: "홍길동", Email: "not-an-email", Password: "short"})
+
+	var domainErr *Error
+	if !errors.As(err, &domainErr) || !errors.Is(err, ErrValidation) {
+		t.Fatalf("Expected a validation error, got %v", err)
+	}
+	want := map[string]string{
+		"email":    "must be a valid email address",
+		"password": "must be at least 8 characters",
+	}
+	if len(domainErr.Fields) != len(want) {
+		t.Fatalf("Expected fields %v, got %v", want, domainErr.Fields)
+	}
+	for field, reason := range want {
+		if domainErr.Fields[field] != reason {
+			t.Errorf("Field %s: expected %q, got %q", field, reason, domainErr.Fields[field])
+		}
+	}
+
+	if err := validateRequest(models.SignupRequest{Name: "홍길동", Email: "hong@example.com", Password: "password123"}); err != nil {
+		t.Errorf("Expected a valid request, got %v", err)
+	}
+}
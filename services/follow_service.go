@@ -1,6 +1,7 @@
 package services
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,15 +11,19 @@ import (
 
 // FollowService handles follow business logic
 type FollowService struct {
-	followRepo repository.FollowRepository
-	userRepo   repository.UserRepository
+	followRepo    repository.FollowRepository
+	userRepo      repository.UserRepository
+	timelines     *TimelineService
+	notifications *NotificationService
 }
 
 // NewFollowService creates a new follow service
-func NewFollowService(followRepo repository.FollowRepository, userRepo repository.UserRepository) *FollowService {
+func NewFollowService(followRepo repository.FollowRepository, userRepo repository.UserRepository, timelines *TimelineService, notifications *NotificationService) *FollowService {
 	return &FollowService{
-		followRepo: followRepo,
-		userRepo:   userRepo,
+		followRepo:    followRepo,
+		userRepo:      userRepo,
+		timelines:     timelines,
+		notifications: notifications,
 	}
 }
 
@@ -26,25 +31,31 @@ func NewFollowService(followRepo repository.FollowRepository, userRepo repositor
 func (s *FollowService) Follow(followerID, followingID int) (models.FollowResponse, error) {
 	// Validate IDs
 	if followerID == 0 || followingID == 0 {
-		return models.FollowResponse{}, fmt.Errorf("follower_id and following user ID are required")
+		return models.FollowResponse{}, NewError(ErrValidation, "follower_id and following user ID are required")
 	}
 
 	// Check if trying to follow themselves
 	if followerID == followingID {
-		return models.FollowResponse{}, fmt.Errorf("cannot follow yourself")
+		return models.FollowResponse{}, NewError(ErrValidation, "cannot follow yourself")
 	}
 
 	// Check if both users exist
 	if _, err := s.userRepo.GetByID(followerID); err != nil {
-		return models.FollowResponse{}, fmt.Errorf("follower user not found")
+		if errors.Is(err, ErrNotFound) {
+			return models.FollowResponse{}, NewError(ErrNotFound, "follower user not found")
+		}
+		return models.FollowResponse{}, err
 	}
 	if _, err := s.userRepo.GetByID(followingID); err != nil {
-		return models.FollowResponse{}, fmt.Errorf("following user not found")
+		if errors.Is(err, ErrNotFound) {
+			return models.FollowResponse{}, NewError(ErrNotFound, "following user not found")
+		}
+		return models.FollowResponse{}, err
 	}
 
 	// Check if already following
 	if s.followRepo.Exists(followerID, followingID) {
-		return models.FollowResponse{}, fmt.Errorf("already following this user")
+		return models.FollowResponse{}, NewError(ErrConflict, "already following this user")
 	}
 
 	// Create follow relationship
@@ -56,9 +67,16 @@ func (s *FollowService) Follow(followerID, followingID int) (models.FollowRespon
 	}
 
 	if err := s.followRepo.Create(follow); err != nil {
+		if errors.Is(err, repository.ErrFollowExists) {
+			return models.FollowResponse{}, NewError(ErrConflict, "already following this user")
+		}
 		return models.FollowResponse{}, fmt.Errorf("failed to create follow: %w", err)
 	}
 
+	// Backfill the follower's timeline and notify the followed user
+	s.timelines.Followed(followerID, followingID)
+	s.notifications.Followed(followerID, followingID)
+
 	return models.FollowResponse{
 		Message:     "팔로우 성공",
 		FollowerID:  followerID,
@@ -71,7 +89,7 @@ func (s *FollowService) Follow(followerID, followingID int) (models.FollowRespon
 func (s *FollowService) Unfollow(followerID, followingID int) (models.FollowResponse, error) {
 	// Validate IDs
 	if followerID == 0 || followingID == 0 {
-		return models.FollowResponse{}, fmt.Errorf("follower_id and following user ID are required")
+		return models.FollowResponse{}, NewError(ErrValidation, "follower_id and following user ID are required")
 	}
 
 	// Delete follow relationship
@@ -79,6 +97,10 @@ func (s *FollowService) Unfollow(followerID, followingID int) (models.FollowResp
 		return models.FollowResponse{}, err
 	}
 
+	// Drop the unfollowed user's posts from the follower's timeline and withdraw the follow notification
+	s.timelines.Unfollowed(followerID, followingID)
+	s.notifications.Unfollowed(followerID, followingID)
+
 	return models.FollowResponse{
 		Message:     "언팔로우 성공",
 		FollowerID:  followerID,
@@ -86,71 +108,81 @@ func (s *FollowService) Unfollow(followerID, followingID int) (models.FollowResp
 	}, nil
 }
 
-// GetFollowers retrieves followers for a user
-func (s *FollowService) GetFollowers(userID int) (models.FollowListResponse, error) {
+// GetFollowers retrieves a page of followers for a user
+func (s *FollowService) GetFollowers(userID int, page models.PageRequest) (models.FollowListResponse, error) {
+	// Validate pagination
+	query, limit, err := pageQuery(page)
+	if err != nil {
+		return models.FollowListResponse{}, err
+	}
+
 	// Check if user exists
 	if _, err := s.userRepo.GetByID(userID); err != nil {
-		return models.FollowListResponse{}, fmt.Errorf("user not found")
+		return models.FollowListResponse{}, err
 	}
 
-	// Get follower IDs
-	followerIDs, err := s.followRepo.GetFollowers(userID)
+	// Get followers
+	follows, err := s.followRepo.GetFollowers(userID, query)
 	if err != nil {
 		return models.FollowListResponse{}, fmt.Errorf("failed to get followers: %w", err)
 	}
+	follows, nextCursor := nextPage(follows, limit, func(f models.Follow) repository.Cursor {
+		return repository.Cursor{CreatedAt: f.CreatedAt, ID: f.UserID}
+	})
 
-	// Convert to user info
-	users := make([]models.UserInfo, 0, len(followerIDs))
-	for _, id := range followerIDs {
-		user, err := s.userRepo.GetByID(id)
-		if err != nil {
-			continue
-		}
-		users = append(users, models.UserInfo{
-			ID:      user.ID,
-			Name:    user.Name,
-			Email:   user.Email,
-			Profile: user.Profile,
-		})
+	// Convert to public user info with a single batch lookup
+	ids := make([]int, len(follows))
+	for i, follow := range follows {
+		ids[i] = follow.UserID
+	}
+	users, err := loadPublicUsers(s.userRepo, ids)
+	if err != nil {
+		return models.FollowListResponse{}, err
 	}
 
 	return models.FollowListResponse{
-		Users: users,
-		Count: len(users),
+		Users:      users,
+		Count:      len(users),
+		NextCursor: nextCursor,
 	}, nil
 }
 
-// GetFollowing retrieves following for a user
-func (s *FollowService) GetFollowing(userID int) (models.FollowListResponse, error) {
+// GetFollowing retrieves a page of following for a user
+func (s *FollowService) GetFollowing(userID int, page models.PageRequest) (models.FollowListResponse, error) {
+	// Validate pagination
+	query, limit, err := pageQuery(page)
+	if err != nil {
+		return models.FollowListResponse{}, err
+	}
+
 	// Check if user exists
 	if _, err := s.userRepo.GetByID(userID); err != nil {
-		return models.FollowListResponse{}, fmt.Errorf("user not found")
+		return models.FollowListResponse{}, err
 	}
 
-	// Get following IDs
-	followingIDs, err := s.followRepo.GetFollowing(userID)
+	// Get following
+	follows, err := s.followRepo.GetFollowing(userID, query)
 	if err != nil {
 		return models.FollowListResponse{}, fmt.Errorf("failed to get following: %w", err)
 	}
+	follows, nextCursor := nextPage(follows, limit, func(f models.Follow) repository.Cursor {
+		return repository.Cursor{CreatedAt: f.CreatedAt, ID: f.FollowUserID}
+	})
 
-	// Convert to user info
-	users := make([]models.UserInfo, 0, len(followingIDs))
-	for _, id := range followingIDs {
-		user, err := s.userRepo.GetByID(id)
-		if err != nil {
-			continue
-		}
-		users = append(users, models.UserInfo{
-			ID:      user.ID,
-			Name:    user.Name,
-			Email:   user.Email,
-			Profile: user.Profile,
-		})
+	// Convert to public user info with a single batch lookup
+	ids := make([]int, len(follows))
+	for i, follow := range follows {
+		ids[i] = follow.FollowUserID
+	}
+	users, err := loadPublicUsers(s.userRepo, ids)
+	if err != nil {
+		return models.FollowListResponse{}, err
 	}
 
 	return models.FollowListResponse{
-		Users: users,
-		Count: len(users),
+		Users:      users,
+		Count:      len(users),
+		NextCursor: nextCursor,
 	}, nil
 }
 
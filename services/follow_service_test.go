@@ -10,8 +10,10 @@ import (
 func setupFollowServiceTest(_ *testing.T) (*FollowService, *UserService) {
 	userRepo := repository.NewInMemoryUserRepository()
 	followRepo := repository.NewInMemoryFollowRepository()
-	userService := NewUserService(userRepo)
-	followService := NewFollowService(followRepo, userRepo)
+	postRepo := repository.NewInMemoryPostRepository()
+	timelineService := NewTimelineService(repository.NewInMemoryTimelineStore(), postRepo, userRepo, followRepo)
+	userService := newTestUserService(userRepo)
+	followService := NewFollowService(followRepo, userRepo, timelineService, newTestNotificationService(postRepo, userRepo))
 
 	// Create test users
 	for i := 1; i <= 3; i++ {
@@ -143,7 +145,7 @@ func TestFollowService_GetFollowers(t *testing.T) {
 	followService.Follow(2, 1)
 	followService.Follow(3, 1)
 
-	resp, err := followService.GetFollowers(1)
+	resp, err := followService.GetFollowers(1, models.PageRequest{})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -163,7 +165,7 @@ func TestFollowService_GetFollowing(t *testing.T) {
 	followService.Follow(1, 2)
 	followService.Follow(1, 3)
 
-	resp, err := followService.GetFollowing(1)
+	resp, err := followService.GetFollowing(1, models.PageRequest{})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -194,3 +196,31 @@ func TestFollowService_GetFollowStatus(t *testing.T) {
 		t.Error("Expected IsFollowing=false, got true")
 	}
 }
+
+func TestFollowService_GetFollowers_Pagination(t *testing.T) {
+	followService, _ := setupFollowServiceTest(t)
+
+	// User 2 and 3 follow User 1
+	followService.Follow(2, 1)
+	followService.Follow(3, 1)
+
+	first, err := followService.GetFollowers(1, models.PageRequest{Limit: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if first.Count != 1 || first.NextCursor == "" {
+		t.Fatalf("Expected 1 user and a next cursor, got %d users and cursor '%s'", first.Count, first.NextCursor)
+	}
+
+	second, err := followService.GetFollowers(1, models.PageRequest{Limit: 1, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if second.Count != 1 || second.NextCursor != "" {
+		t.Fatalf("Expected 1 user and no next cursor, got %d users and cursor '%s'", second.Count, second.NextCursor)
+	}
+
+	if first.Users[0].ID == second.Users[0].ID {
+		t.Errorf("Expected distinct followers across pages, got %d twice", first.Users[0].ID)
+	}
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+// LikeService handles like business logic
+type LikeService struct {
+	likeRepo      repository.LikeRepository
+	postRepo      repository.PostRepository
+	userRepo      repository.UserRepository
+	notifications *NotificationService
+}
+
+// NewLikeService creates a new like service
+func NewLikeService(likeRepo repository.LikeRepository, postRepo repository.PostRepository, userRepo repository.UserRepository, notifications *NotificationService) *LikeService {
+	return &LikeService{
+		likeRepo:      likeRepo,
+		postRepo:      postRepo,
+		userRepo:      userRepo,
+		notifications: notifications,
+	}
+}
+
+// Like records that userID likes a post. Liking a post twice is not an error;
+// liking a repost likes the post it shares.
+func (s *LikeService) Like(postID, userID int) (models.LikeResponse, error) {
+	// Validate IDs
+	if postID == 0 || userID == 0 {
+		return models.LikeResponse{}, NewError(ErrValidation, "post_id and user_id are required")
+	}
+
+	// Check if post exists
+	post, err := sharedPost(s.postRepo, postID)
+	if err != nil {
+		return models.LikeResponse{}, err
+	}
+	postID = post.ID
+
+	// Create like and notify the author; an existing like already has the
+	// requested outcome and was notified before
+	like := models.Like{
+		UserID:    userID,
+		PostID:    postID,
+		CreatedAt: time.Now(),
+	}
+	if err := s.likeRepo.Create(like); err == nil {
+		s.notifications.PostLiked(userID, post)
+	} else if !errors.Is(err, repository.ErrLikeExists) {
+		return models.LikeResponse{}, fmt.Errorf("failed to create like: %w", err)
+	}
+
+	return s.likeResponse("좋아요 성공", postID, true)
+}
+
+// Unlike removes userID's like from a post. Unliking a post that is not liked is not an error.
+func (s *LikeService) Unlike(postID, userID int) (models.LikeResponse, error) {
+	// Validate IDs
+	if postID == 0 || userID == 0 {
+		return models.LikeResponse{}, NewError(ErrValidation, "post_id and user_id are required")
+	}
+
+	// Check if post exists
+	post, err := sharedPost(s.postRepo, postID)
+	if err != nil {
+		return models.LikeResponse{}, err
+	}
+	postID = post.ID
+
+	// Delete like and withdraw its notification; a missing like already has
+	// the requested outcome
+	if err := s.likeRepo.Delete(userID, postID); err == nil {
+		s.notifications.PostUnliked(userID, post)
+	} else if !errors.Is(err, repository.ErrLikeNotFound) {
+		return models.LikeResponse{}, fmt.Errorf("failed to delete like: %w", err)
+	}
+
+	return s.likeResponse("좋아요 취소 성공", postID, false)
+}
+
+// GetLikes retrieves a page of users who liked a post, most recent first
+func (s *LikeService) GetLikes(postID int, page models.PageRequest) (models.LikeListResponse, error) {
+	// Validate pagination
+	query, limit, err := pageQuery(page)
+	if err != nil {
+		return models.LikeListResponse{}, err
+	}
+
+	// Check if post exists
+	if _, err := s.postRepo.GetByID(postID); err != nil {
+		return models.LikeListResponse{}, err
+	}
+
+	// Get likes
+	likes, err := s.likeRepo.GetByPostID(postID, query)
+	if err != nil {
+		return models.LikeListResponse{}, fmt.Errorf("failed to get likes: %w", err)
+	}
+	likes, nextCursor := nextPage(likes, limit, func(l models.Like) repository.Cursor {
+		return repository.Cursor{CreatedAt: l.CreatedAt, ID: l.UserID}
+	})
+
+	// Convert to public user info with a single batch lookup
+	ids := make([]int, len(likes))
+	for i, like := range likes {
+		ids[i] = like.UserID
+	}
+	users, err := loadPublicUsers(s.userRepo, ids)
+	if err != nil {
+		return models.LikeListResponse{}, err
+	}
+
+	return models.LikeListResponse{
+		Users:      users,
+		Count:      len(users),
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// likeResponse builds a like/unlike response with the post's current like count
+func (s *LikeService) likeResponse(message string, postID int, liked bool) (models.LikeResponse, error) {
+	counts, err := s.likeRepo.CountByPostIDs([]int{postID})
+	if err != nil {
+		return models.LikeResponse{}, fmt.Errorf("failed to count likes: %w", err)
+	}
+
+	return models.LikeResponse{
+		Message:   message,
+		PostID:    postID,
+		Liked:     liked,
+		LikeCount: counts[postID],
+	}, nil
+}
+
+// attachLikes fills in like counts, and whether viewerID liked each post, with
+// one query for the counts and one for the viewer's likes. viewerID 0 is an
+// anonymous viewer, who has liked nothing.
+func attachLikes(likeRepo repository.LikeRepository, posts []models.PostWithUser, viewerID int) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]int, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+
+	counts, err := likeRepo.CountByPostIDs(postIDs)
+	if err != nil {
+		return fmt.Errorf("failed to count likes: %w", err)
+	}
+
+	liked := map[int]bool{}
+	if viewerID != 0 {
+		if liked, err = likeRepo.LikedPostIDs(viewerID, postIDs); err != nil {
+			return fmt.Errorf("failed to get likes: %w", err)
+		}
+	}
+
+	for i := range posts {
+		posts[i].LikeCount = counts[posts[i].ID]
+		posts[i].LikedByMe = liked[posts[i].ID]
+	}
+	return nil
+}
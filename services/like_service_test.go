@@ -0,0 +1,233 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+// countingLikeRepository counts the batch queries issued against the wrapped repository
+type countingLikeRepository struct {
+	repository.LikeRepository
+	queries int
+}
+
+func (r *countingLikeRepository) CountByPostIDs(postIDs []int) (map[int]int, error) {
+	r.queries++
+	return r.LikeRepository.CountByPostIDs(postIDs)
+}
+
+func (r *countingLikeRepository) LikedPostIDs(userID int, postIDs []int) (map[int]bool, error) {
+	r.queries++
+	return r.LikeRepository.LikedPostIDs(userID, postIDs)
+}
+
+type likeTestEnv struct {
+	likeService   *LikeService
+	postService   *PostService
+	followService *FollowService
+	likeRepo      *countingLikeRepository
+}
+
+func setupLikeServiceTest(_ *testing.T) *likeTestEnv {
+	userRepo := repository.NewInMemoryUserRepository()
+	followRepo := repository.NewInMemoryFollowRepository()
+	postRepo := repository.NewInMemoryPostRepository()
+	likeRepo := &countingLikeRepository{LikeRepository: repository.NewInMemoryLikeRepository()}
+	timelineService := NewTimelineService(repository.NewInMemoryTimelineStore(), postRepo, userRepo, followRepo)
+
+	// Create test users
+	for i := 1; i <= 3; i++ {
+		userRepo.Create(&models.User{
+			Name:  "User" + string(rune('0'+i)),
+			Email: "user" + string(rune('0'+i)) + "@test.com",
+		})
+	}
+
+	return &likeTestEnv{
+		likeService:   NewLikeService(likeRepo, postRepo, userRepo, newTestNotificationService(postRepo, userRepo)),
+		postService:   NewPostService(postRepo, userRepo, followRepo, likeRepo, timelineService, newTestSearchService(postRepo, userRepo), newTestTrendService(postRepo), newTestNotificationService(postRepo, userRepo)),
+		followService: NewFollowService(followRepo, userRepo, timelineService, newTestNotificationService(postRepo, userRepo)),
+		likeRepo:      likeRepo,
+	}
+}
+
+func TestLikeService_LikeAndUnlike(t *testing.T) {
+	env := setupLikeServiceTest(t)
+	post, err := env.postService.CreatePost(1, models.CreatePostRequest{Content: "게시글"})
+	if err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+
+	// Liking is idempotent
+	for i := 0; i < 2; i++ {
+		resp, err := env.likeService.Like(post.PostID, 2)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !resp.Liked || resp.LikeCount != 1 {
+			t.Errorf("Expected liked with count 1, got %+v", resp)
+		}
+	}
+
+	resp, err := env.likeService.Like(post.PostID, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resp.LikeCount != 2 {
+		t.Errorf("Expected count 2, got %d", resp.LikeCount)
+	}
+
+	// Unliking is idempotent
+	for i := 0; i < 2; i++ {
+		resp, err := env.likeService.Unlike(post.PostID, 2)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if resp.Liked || resp.LikeCount != 1 {
+			t.Errorf("Expected unliked with count 1, got %+v", resp)
+		}
+	}
+}
+
+func TestLikeService_Errors(t *testing.T) {
+	env := setupLikeServiceTest(t)
+
+	tests := []struct {
+		name string
+		call func() error
+		kind error
+	}{
+		{
+			name: "like missing post",
+			call: func() error {
+				_, err := env.likeService.Like(999, 1)
+				return err
+			},
+			kind: ErrNotFound,
+		},
+		{
+			name: "unlike missing post",
+			call: func() error {
+				_, err := env.likeService.Unlike(999, 1)
+				return err
+			},
+			kind: ErrNotFound,
+		},
+		{
+			name: "like without user",
+			call: func() error {
+				_, err := env.likeService.Like(1, 0)
+				return err
+			},
+			kind: ErrValidation,
+		},
+		{
+			name: "likes of missing post",
+			call: func() error {
+				_, err := env.likeService.GetLikes(999, models.PageRequest{})
+				return err
+			},
+			kind: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, tt.kind) {
+				t.Errorf("Expected error kind '%v', got '%v'", tt.kind, err)
+			}
+		})
+	}
+}
+
+func TestLikeService_GetLikes_Pagination(t *testing.T) {
+	env := setupLikeServiceTest(t)
+	post, _ := env.postService.CreatePost(1, models.CreatePostRequest{Content: "게시글"})
+	for userID := 1; userID <= 3; userID++ {
+		if _, err := env.likeService.Like(post.PostID, userID); err != nil {
+			t.Fatalf("Failed to like: %v", err)
+		}
+	}
+
+	seen := make(map[int]bool)
+	page := models.PageRequest{Limit: 2}
+	for {
+		resp, err := env.likeService.GetLikes(post.PostID, page)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, user := range resp.Users {
+			if seen[user.ID] {
+				t.Errorf("User %d returned twice", user.ID)
+			}
+			seen[user.ID] = true
+		}
+		if resp.NextCursor == "" {
+			break
+		}
+		page.Cursor = resp.NextCursor
+	}
+	if len(seen) != 3 {
+		t.Errorf("Expected 3 likers across pages, got %d", len(seen))
+	}
+}
+
+func TestPostService_Timeline_LikeFields(t *testing.T) {
+	env := setupLikeServiceTest(t)
+	env.followService.Follow(1, 2)
+	env.followService.Follow(1, 3)
+
+	var postIDs []int
+	for _, authorID := range []int{2, 3, 2} {
+		resp, err := env.postService.CreatePost(authorID, models.CreatePostRequest{Content: "게시글"})
+		if err != nil {
+			t.Fatalf("Failed to create post: %v", err)
+		}
+		postIDs = append(postIDs, resp.PostID)
+	}
+	env.likeService.Like(postIDs[0], 1)
+	env.likeService.Like(postIDs[0], 3)
+	env.likeService.Like(postIDs[1], 2)
+
+	env.likeRepo.queries = 0
+	timeline, err := env.postService.GetTimeline(1, 1, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if env.likeRepo.queries != 2 {
+		t.Errorf("Expected 2 like queries for the whole page, got %d", env.likeRepo.queries)
+	}
+
+	want := map[int]struct {
+		count int
+		liked bool
+	}{
+		postIDs[0]: {2, true},
+		postIDs[1]: {1, false},
+		postIDs[2]: {0, false},
+	}
+	for _, post := range timeline.Posts {
+		if post.LikeCount != want[post.ID].count || post.LikedByMe != want[post.ID].liked {
+			t.Errorf("Post %d: expected count %d liked %v, got count %d liked %v",
+				post.ID, want[post.ID].count, want[post.ID].liked, post.LikeCount, post.LikedByMe)
+		}
+	}
+
+	// Anonymous viewers get counts without any per-viewer lookup
+	env.likeRepo.queries = 0
+	userPosts, err := env.postService.GetUserPosts(2, 0, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if env.likeRepo.queries != 1 {
+		t.Errorf("Expected 1 like query for an anonymous viewer, got %d", env.likeRepo.queries)
+	}
+	for _, post := range userPosts.Posts {
+		if post.LikedByMe || post.LikeCount != want[post.ID].count || post.UserName != "User2" {
+			t.Errorf("Unexpected post for anonymous viewer: %+v", post)
+		}
+	}
+}
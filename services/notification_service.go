@@ -0,0 +1,284 @@
+package services
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+// MaxNotificationActors is the most actors a notification group lists
+const MaxNotificationActors = 3
+
+// MaxMarkReadIDs is the most notification IDs one mark read request may name
+const MaxMarkReadIDs = 100
+
+// NotificationService records notifications as users follow, like, reply to
+// and mention each other, and lists them grouped for the recipient
+type NotificationService struct {
+	notificationRepo repository.NotificationRepository
+	postRepo         repository.PostRepository
+	userRepo         repository.UserRepository
+	now              func() time.Time
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(notificationRepo repository.NotificationRepository, postRepo repository.PostRepository, userRepo repository.UserRepository) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		postRepo:         postRepo,
+		userRepo:         userRepo,
+		now:              time.Now,
+	}
+}
+
+// Followed notifies followingID that followerID followed them
+func (s *NotificationService) Followed(followerID, followingID int) {
+	s.notify(followingID, followerID, models.NotificationFollow, 0)
+}
+
+// Unfollowed withdraws the notification of followerID's follow, so following
+// again does not notify twice
+func (s *NotificationService) Unfollowed(followerID, followingID int) {
+	s.withdraw(followingID, followerID, models.NotificationFollow, 0)
+}
+
+// PostLiked notifies the author of a post that userID liked it
+func (s *NotificationService) PostLiked(userID int, post models.Post) {
+	s.notify(post.UserID, userID, models.NotificationLike, post.ID)
+}
+
+// PostUnliked withdraws the notification of userID's like of a post
+func (s *NotificationService) PostUnliked(userID int, post models.Post) {
+	s.withdraw(post.UserID, userID, models.NotificationLike, post.ID)
+}
+
+// PostCreated notifies the author of the post a new post replies to and the
+// users it mentions. Each user is notified once; a reply that also mentions
+// the parent's author is reported as a reply.
+func (s *NotificationService) PostCreated(post models.Post) {
+	notified := map[int]bool{post.UserID: true}
+
+	if post.InReplyToPostID != nil {
+		parent, err := s.postRepo.GetByID(*post.InReplyToPostID)
+		if err != nil {
+			slog.Warn("Notification failed", "post_id", post.ID, "type", models.NotificationReply, "error", err)
+		} else if !notified[parent.UserID] {
+			notified[parent.UserID] = true
+			s.notify(parent.UserID, post.UserID, models.NotificationReply, post.ID)
+		}
+	}
+
+	for _, mention := range post.Entities.Mentions {
+		if notified[mention.UserID] {
+			continue
+		}
+		notified[mention.UserID] = true
+		s.notify(mention.UserID, post.UserID, models.NotificationMention, post.ID)
+	}
+}
+
+// PostDeleted removes the notifications about a deleted post. They are not
+// brought back if the post is restored.
+func (s *NotificationService) PostDeleted(post models.Post) {
+	if err := s.notificationRepo.DeleteByPostID(post.ID); err != nil {
+		slog.Warn("Notification removal failed", "post_id", post.ID, "error", err)
+	}
+}
+
+// notify records a notification for userID; users are not notified of their own actions
+func (s *NotificationService) notify(userID, actorID int, kind models.NotificationType, postID int) {
+	if userID == actorID {
+		return
+	}
+
+	notification := models.Notification{
+		UserID:    userID,
+		ActorID:   actorID,
+		Type:      kind,
+		CreatedAt: s.now(),
+	}
+	if postID != 0 {
+		notification.PostID = &postID
+	}
+	if err := s.notificationRepo.Create(&notification); err != nil {
+		slog.Warn("Notification failed", "user_id", userID, "actor_id", actorID, "type", kind, "error", err)
+	}
+}
+
+// withdraw removes the notifications notify recorded for an action that was undone
+func (s *NotificationService) withdraw(userID, actorID int, kind models.NotificationType, postID int) {
+	if err := s.notificationRepo.Delete(userID, actorID, kind, postID); err != nil {
+		slog.Warn("Notification removal failed", "user_id", userID, "actor_id", actorID, "type", kind, "error", err)
+	}
+}
+
+// GetNotifications retrieves a page of userID's notifications, newest first,
+// with follows and likes of the same post grouped, and the unread count
+func (s *NotificationService) GetNotifications(userID int, page models.PageRequest) (models.NotificationsResponse, error) {
+	// Validate pagination
+	query, limit, err := pageQuery(page)
+	if err != nil {
+		return models.NotificationsResponse{}, err
+	}
+
+	// Get notifications
+	notifications, err := s.notificationRepo.GetByUserID(userID, query)
+	if err != nil {
+		return models.NotificationsResponse{}, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	notifications, nextCursor := nextPage(notifications, limit, func(n models.Notification) repository.Cursor {
+		return repository.Cursor{CreatedAt: n.CreatedAt, ID: n.ID}
+	})
+
+	// Group them and name the actors with a single batch lookup
+	groups, actorIDs := groupNotifications(notifications)
+	usersByID, err := s.userRepo.GetByIDs(actorIDs)
+	if err != nil {
+		return models.NotificationsResponse{}, fmt.Errorf("failed to get users: %w", err)
+	}
+	result := make([]models.NotificationGroup, len(groups))
+	for i, group := range groups {
+		result[i] = group.build(usersByID)
+	}
+
+	unread, err := s.notificationRepo.CountUnread(userID)
+	if err != nil {
+		return models.NotificationsResponse{}, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	return models.NotificationsResponse{
+		Notifications: result,
+		Count:         len(result),
+		UnreadCount:   unread,
+		NextCursor:    nextCursor,
+	}, nil
+}
+
+// MarkRead marks the notifications named in req, or all of userID's
+// notifications if it names none, read
+func (s *NotificationService) MarkRead(userID int, req models.MarkNotificationsReadRequest) (models.MarkNotificationsReadResponse, error) {
+	// Validate request
+	if userID == 0 {
+		return models.MarkNotificationsReadResponse{}, NewError(ErrValidation, "user_id is required")
+	}
+	if len(req.IDs) > MaxMarkReadIDs {
+		return models.MarkNotificationsReadResponse{}, NewError(ErrValidation, "at most %d notification IDs can be marked at once", MaxMarkReadIDs)
+	}
+
+	marked, err := s.notificationRepo.MarkRead(userID, req.IDs, s.now())
+	if err != nil {
+		return models.MarkNotificationsReadResponse{}, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+	unread, err := s.notificationRepo.CountUnread(userID)
+	if err != nil {
+		return models.MarkNotificationsReadResponse{}, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	return models.MarkNotificationsReadResponse{
+		Message:     "알림을 읽음으로 표시했습니다.",
+		Marked:      marked,
+		UnreadCount: unread,
+	}, nil
+}
+
+// notificationGroup collects the notifications folded into one list entry
+type notificationGroup struct {
+	first    models.Notification // newest notification in the group
+	actorIDs []int               // distinct, newest first
+	ids      []int
+	read     bool
+}
+
+// groupNotifications folds a page of notifications, newest first, into
+// groups: follows into one, likes into one per post, and every reply and
+// mention into its own. Groups are ordered by their newest notification.
+// It also returns the IDs of the actors the groups list.
+func groupNotifications(notifications []models.Notification) ([]*notificationGroup, []int) {
+	type groupKey struct {
+		kind   models.NotificationType
+		postID int
+	}
+
+	groups := make([]*notificationGroup, 0, len(notifications))
+	byKey := make(map[groupKey]*notificationGroup)
+	listed := make(map[int]bool)
+	actorIDs := make([]int, 0)
+	for _, n := range notifications {
+		grouped := n.Type == models.NotificationFollow || n.Type == models.NotificationLike
+		key := groupKey{kind: n.Type}
+		if n.PostID != nil {
+			key.postID = *n.PostID
+		}
+
+		group := byKey[key]
+		if group == nil || !grouped {
+			group = &notificationGroup{first: n, read: true}
+			groups = append(groups, group)
+			if grouped {
+				byKey[key] = group
+			}
+		}
+
+		group.ids = append(group.ids, n.ID)
+		group.read = group.read && n.ReadAt != nil
+		if !slices.Contains(group.actorIDs, n.ActorID) {
+			group.actorIDs = append(group.actorIDs, n.ActorID)
+			if len(group.actorIDs) <= MaxNotificationActors && !listed[n.ActorID] {
+				listed[n.ActorID] = true
+				actorIDs = append(actorIDs, n.ActorID)
+			}
+		}
+	}
+	return groups, actorIDs
+}
+
+// build turns a group into its list entry, naming its first actors
+func (g *notificationGroup) build(usersByID map[int]models.User) models.NotificationGroup {
+	actors := make([]models.PublicUser, 0, MaxNotificationActors)
+	for _, id := range g.actorIDs[:min(len(g.actorIDs), MaxNotificationActors)] {
+		user, exists := usersByID[id]
+		if !exists {
+			continue // Skip non-existent users
+		}
+		actors = append(actors, publicUser(user))
+	}
+
+	return models.NotificationGroup{
+		Type:            g.first.Type,
+		PostID:          g.first.PostID,
+		Actors:          actors,
+		ActorCount:      len(g.actorIDs),
+		Message:         notificationMessage(g.first.Type, actors, len(g.actorIDs)),
+		Read:            g.read,
+		CreatedAt:       g.first.CreatedAt,
+		NotificationIDs: g.ids,
+	}
+}
+
+// notificationMessage describes a group, e.g. "A님 외 3명이 회원님을 팔로우했습니다."
+func notificationMessage(kind models.NotificationType, actors []models.PublicUser, actorCount int) string {
+	subject := "알 수 없는 사용자가"
+	if len(actors) > 0 {
+		subject = actors[0].Name + "님이"
+		if actorCount > 1 {
+			subject = fmt.Sprintf("%s님 외 %d명이", actors[0].Name, actorCount-1)
+		}
+	}
+
+	switch kind {
+	case models.NotificationFollow:
+		return subject + " 회원님을 팔로우했습니다."
+	case models.NotificationLike:
+		return subject + " 회원님의 게시글을 좋아합니다."
+	case models.NotificationReply:
+		return subject + " 회원님의 게시글에 답글을 남겼습니다."
+	case models.NotificationMention:
+		return subject + " 게시글에서 회원님을 언급했습니다."
+	default:
+		return subject + " 새 알림을 보냈습니다."
+	}
+}
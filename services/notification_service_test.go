@@ -0,0 +1,222 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+// newTestNotificationService creates a notification service over a fresh in-memory repository
+func newTestNotificationService(postRepo repository.PostRepository, userRepo repository.UserRepository) *NotificationService {
+	return NewNotificationService(repository.NewInMemoryNotificationRepository(), postRepo, userRepo)
+}
+
+type notificationTestEnv struct {
+	notificationService *NotificationService
+	followService       *FollowService
+	postService         *PostService
+	likeService         *LikeService
+}
+
+// setupNotificationServiceTest wires the follow, post and like services to one
+// notification service and creates users User1..User<users>
+func setupNotificationServiceTest(t *testing.T, users int) *notificationTestEnv {
+	t.Helper()
+
+	userRepo := repository.NewInMemoryUserRepository()
+	followRepo := repository.NewInMemoryFollowRepository()
+	postRepo := repository.NewInMemoryPostRepository()
+	likeRepo := repository.NewInMemoryLikeRepository()
+	timelineService := NewTimelineService(repository.NewInMemoryTimelineStore(), postRepo, userRepo, followRepo)
+	notificationService := newTestNotificationService(postRepo, userRepo)
+
+	for i := 1; i <= users; i++ {
+		if err := userRepo.Create(&models.User{Name: fmt.Sprintf("User%d", i), Email: fmt.Sprintf("user%d@test.com", i)}); err != nil {
+			t.Fatalf("Create user failed: %v", err)
+		}
+	}
+
+	return &notificationTestEnv{
+		notificationService: notificationService,
+		followService:       NewFollowService(followRepo, userRepo, timelineService, notificationService),
+		postService:         NewPostService(postRepo, userRepo, followRepo, likeRepo, timelineService, newTestSearchService(postRepo, userRepo), newTestTrendService(postRepo), notificationService),
+		likeService:         NewLikeService(likeRepo, postRepo, userRepo, notificationService),
+	}
+}
+
+// notificationMessages lists the messages of a page of notification groups
+func notificationMessages(resp models.NotificationsResponse) []string {
+	messages := make([]string, len(resp.Notifications))
+	for i, group := range resp.Notifications {
+		messages[i] = group.Message
+	}
+	return messages
+}
+
+func TestNotificationService_Events(t *testing.T) {
+	env := setupNotificationServiceTest(t, 4)
+	mustDo := func(_ any, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	// User2 follows User1, then User2 and User3 like User1's post; liking your
+	// own post, mentioning yourself and liking twice notify no one
+	mustDo(env.followService.Follow(2, 1))
+	post, err := env.postService.CreatePost(1, models.CreatePostRequest{Content: "hello from @User1"})
+	if err != nil {
+		t.Fatalf("CreatePost failed: %v", err)
+	}
+	mustDo(env.likeService.Like(post.PostID, 1))
+	mustDo(env.likeService.Like(post.PostID, 2))
+	mustDo(env.likeService.Like(post.PostID, 2))
+	mustDo(env.likeService.Like(post.PostID, 3))
+
+	// User3 replies mentioning User1 and User4; User1 is notified of the reply only
+	reply, err := env.postService.CreateReply(post.PostID, 3, models.CreatePostRequest{Content: "@User1 @User4 agreed"})
+	if err != nil {
+		t.Fatalf("CreateReply failed: %v", err)
+	}
+
+	resp, err := env.notificationService.GetNotifications(1, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("GetNotifications failed: %v", err)
+	}
+	want := []string{
+		"User3님이 회원님의 게시글에 답글을 남겼습니다.",
+		"User3님 외 1명이 회원님의 게시글을 좋아합니다.",
+		"User2님이 회원님을 팔로우했습니다.",
+	}
+	if got := notificationMessages(resp); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("Expected messages %q, got %q", want, got)
+	}
+	if resp.Count != 3 || resp.UnreadCount != 4 || resp.NextCursor != "" {
+		t.Errorf("Expected 3 groups of 4 unread notifications on one page, got %+v", resp)
+	}
+	if group := resp.Notifications[0]; group.Type != models.NotificationReply || group.PostID == nil || *group.PostID != reply.PostID || group.Read {
+		t.Errorf("Unexpected reply notification: %+v", group)
+	}
+	if group := resp.Notifications[1]; group.ActorCount != 2 || len(group.Actors) != 2 || group.Actors[0].ID != 3 || group.Actors[1].ID != 2 || len(group.NotificationIDs) != 2 {
+		t.Errorf("Unexpected like notification: %+v", group)
+	}
+
+	mentioned, err := env.notificationService.GetNotifications(4, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("GetNotifications failed: %v", err)
+	}
+	if got := notificationMessages(mentioned); len(got) != 1 || got[0] != "User3님이 게시글에서 회원님을 언급했습니다." {
+		t.Errorf("Expected a mention notification for User4, got %q", got)
+	}
+
+	// Undoing a follow or a like withdraws its notification; deleting the reply
+	// removes the notifications about it
+	mustDo(env.followService.Unfollow(2, 1))
+	mustDo(env.likeService.Unlike(post.PostID, 2))
+	mustDo(env.postService.DeletePost(reply.PostID, 3))
+
+	resp, err = env.notificationService.GetNotifications(1, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("GetNotifications failed: %v", err)
+	}
+	if got := notificationMessages(resp); len(got) != 1 || got[0] != "User3님이 회원님의 게시글을 좋아합니다." || resp.UnreadCount != 1 {
+		t.Errorf("Expected only User3's like to remain, got %q (unread %d)", got, resp.UnreadCount)
+	}
+	if mentioned, _ = env.notificationService.GetNotifications(4, models.PageRequest{}); mentioned.Count != 0 {
+		t.Errorf("Expected the mention to be removed with the reply, got %+v", mentioned.Notifications)
+	}
+}
+
+func TestNotificationService_Grouping(t *testing.T) {
+	env := setupNotificationServiceTest(t, 6)
+
+	// Five follows, newest from User6, with a like of another post in between
+	post, err := env.postService.CreatePost(1, models.CreatePostRequest{Content: "post"})
+	if err != nil {
+		t.Fatalf("CreatePost failed: %v", err)
+	}
+	for id := 2; id <= 6; id++ {
+		env.notificationService.Followed(id, 1)
+		if id == 3 {
+			env.notificationService.PostLiked(2, post.Post)
+		}
+	}
+
+	resp, err := env.notificationService.GetNotifications(1, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("GetNotifications failed: %v", err)
+	}
+	if resp.Count != 2 {
+		t.Fatalf("Expected a follow group and a like group, got %q", notificationMessages(resp))
+	}
+	follows := resp.Notifications[0]
+	if follows.Message != "User6님 외 4명이 회원님을 팔로우했습니다." || follows.ActorCount != 5 || len(follows.NotificationIDs) != 5 {
+		t.Errorf("Unexpected follow group: %+v", follows)
+	}
+	if len(follows.Actors) != MaxNotificationActors || follows.Actors[0].ID != 6 || follows.Actors[2].ID != 4 {
+		t.Errorf("Expected the %d newest followers, got %+v", MaxNotificationActors, follows.Actors)
+	}
+
+	// Groups only span a page
+	page, err := env.notificationService.GetNotifications(1, models.PageRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("GetNotifications failed: %v", err)
+	}
+	if page.Count != 1 || page.Notifications[0].ActorCount != 2 || page.NextCursor == "" {
+		t.Fatalf("Expected one group of two follows and a next cursor, got %+v", page)
+	}
+	rest, err := env.notificationService.GetNotifications(1, models.PageRequest{Limit: 2, Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("GetNotifications failed: %v", err)
+	}
+	want := []string{"User4님이 회원님을 팔로우했습니다.", "User2님이 회원님의 게시글을 좋아합니다."}
+	if got := notificationMessages(rest); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Expected messages %q on the second page, got %q", want, got)
+	}
+}
+
+func TestNotificationService_MarkRead(t *testing.T) {
+	env := setupNotificationServiceTest(t, 4)
+	for id := 2; id <= 4; id++ {
+		env.notificationService.Followed(id, 1)
+	}
+	env.notificationService.Followed(1, 2)
+
+	resp, err := env.notificationService.GetNotifications(1, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("GetNotifications failed: %v", err)
+	}
+	ids := resp.Notifications[0].NotificationIDs
+
+	// Another user's notification is ignored
+	marked, err := env.notificationService.MarkRead(1, models.MarkNotificationsReadRequest{IDs: []int{ids[0], 4}})
+	if err != nil {
+		t.Fatalf("MarkRead failed: %v", err)
+	}
+	if marked.Marked != 1 || marked.UnreadCount != 2 {
+		t.Errorf("Expected 1 marked and 2 unread, got %+v", marked)
+	}
+	if resp, _ = env.notificationService.GetNotifications(1, models.PageRequest{}); resp.Notifications[0].Read {
+		t.Error("Expected the group to stay unread while some of it is unread")
+	}
+
+	// No IDs marks everything
+	if marked, err = env.notificationService.MarkRead(1, models.MarkNotificationsReadRequest{}); err != nil || marked.Marked != 2 || marked.UnreadCount != 0 {
+		t.Errorf("Expected 2 marked and none unread, got %+v (%v)", marked, err)
+	}
+	if resp, _ = env.notificationService.GetNotifications(1, models.PageRequest{}); !resp.Notifications[0].Read || resp.UnreadCount != 0 {
+		t.Errorf("Expected every notification read, got %+v", resp)
+	}
+	if other, _ := env.notificationService.GetNotifications(2, models.PageRequest{}); other.UnreadCount != 1 {
+		t.Errorf("Expected User2's notification to stay unread, got %d unread", other.UnreadCount)
+	}
+
+	_, err = env.notificationService.MarkRead(1, models.MarkNotificationsReadRequest{IDs: make([]int, MaxMarkReadIDs+1)})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected validation error for too many IDs, got %v", err)
+	}
+}
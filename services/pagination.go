@@ -0,0 +1,51 @@
+package services
+
+import (
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+// Page sizes; MaxPageLimit is enforced by the validate tag of models.PageRequest
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// pageQuery validates a page request and builds a repository query that
+// fetches one extra item so the caller can tell whether a next page exists
+func pageQuery(page models.PageRequest) (repository.PageQuery, int, error) {
+	if err := validateRequest(page); err != nil {
+		return repository.PageQuery{}, 0, err
+	}
+
+	limit := page.Limit
+	if limit == 0 {
+		limit = DefaultPageLimit
+	}
+
+	query := repository.PageQuery{Limit: limit + 1}
+	if page.Cursor != "" {
+		cursor, err := repository.DecodeCursor(page.Cursor)
+		if err != nil {
+			return repository.PageQuery{}, 0, NewError(ErrValidation, "invalid cursor")
+		}
+		query.Cursor = &cursor
+	}
+
+	return query, limit, nil
+}
+
+// nextPage trims the lookahead item and returns the cursor for the following page
+// ("" when items is the last page)
+func nextPage[T any, C interface{ Encode() string }](items []T, limit int, key func(T) C) ([]T, string) {
+	if len(items) <= limit {
+		return items, ""
+	}
+	items = items[:limit]
+	return items, key(items[limit-1]).Encode()
+}
+
+// postCursor returns the pagination cursor of a post
+func postCursor(post models.Post) repository.Cursor {
+	return repository.Cursor{CreatedAt: post.CreatedAt, ID: post.ID}
+}
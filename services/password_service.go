@@ -0,0 +1,178 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+// PasswordResetTTL is how long a password reset token stays valid
+const PasswordResetTTL = time.Hour
+
+// PasswordService handles password changes and password resets
+type PasswordService struct {
+	userRepo    repository.UserRepository
+	resetRepo   repository.PasswordResetRepository
+	authService *AuthService
+	mailer      Mailer
+	now         func() time.Time
+}
+
+// NewPasswordService creates a new password service
+func NewPasswordService(userRepo repository.UserRepository, resetRepo repository.PasswordResetRepository, authService *AuthService, mailer Mailer) *PasswordService {
+	return &PasswordService{
+		userRepo:    userRepo,
+		resetRepo:   resetRepo,
+		authService: authService,
+		mailer:      mailer,
+		now:         time.Now,
+	}
+}
+
+// ChangePassword replaces the user's password after verifying the current one.
+// Every session of the user is revoked, so all devices must log in again.
+func (s *PasswordService) ChangePassword(userID int, req models.ChangePasswordRequest) (models.SuccessResponse, error) {
+	// Validate fields
+	if err := validateRequest(req); err != nil {
+		return models.SuccessResponse{}, err
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return models.SuccessResponse{}, err
+	}
+
+	// Verify current password
+	if err := bcrypt.CompareHashAndPassword([]byte(user.HashedPassword), []byte(req.CurrentPassword)); err != nil {
+		return models.SuccessResponse{}, NewError(ErrForbidden, "current password is incorrect")
+	}
+	if req.CurrentPassword == req.NewPassword {
+		return models.SuccessResponse{}, NewError(ErrValidation, "new password must differ from the current password")
+	}
+
+	if err := s.setPassword(user.ID, req.NewPassword); err != nil {
+		return models.SuccessResponse{}, err
+	}
+
+	return models.SuccessResponse{
+		Message: "비밀번호가 변경되었습니다. 다시 로그인해주세요.",
+		Status:  "success",
+	}, nil
+}
+
+// RequestPasswordReset emails a single-use reset token to the account's address.
+// The response is the same whether or not the email is registered, so it cannot
+// be used to discover accounts.
+func (s *PasswordService) RequestPasswordReset(req models.ForgotPasswordRequest) (models.SuccessResponse, error) {
+	if err := validateRequest(req); err != nil {
+		return models.SuccessResponse{}, err
+	}
+
+	resp := models.SuccessResponse{
+		Message: "등록된 이메일이라면 비밀번호 재설정 안내를 보냈습니다.",
+		Status:  "success",
+	}
+
+	user, err := s.userRepo.GetByEmail(req.Email)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return resp, nil
+		}
+		return models.SuccessResponse{}, err
+	}
+
+	token, err := generateOpaqueToken()
+	if err != nil {
+		return models.SuccessResponse{}, fmt.Errorf("failed to generate reset token: %w", err)
+	}
+
+	stored := models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: s.now().Add(PasswordResetTTL),
+	}
+	if err := s.resetRepo.Create(&stored); err != nil {
+		return models.SuccessResponse{}, fmt.Errorf("failed to store reset token: %w", err)
+	}
+
+	msg := Message{
+		To:      user.Email,
+		Subject: "비밀번호 재설정 안내",
+		Body: fmt.Sprintf("비밀번호를 재설정하려면 아래 토큰을 사용하세요. 토큰은 %d분 동안 한 번만 사용할 수 있습니다.\n\n%s\n\n"+
+			"요청하지 않았다면 이 메일을 무시하세요.", int(PasswordResetTTL.Minutes()), token),
+	}
+	if err := s.mailer.Send(msg); err != nil {
+		// Do not reveal delivery problems to the requester
+		slog.Error("Failed to send password reset email", "user_id", user.ID, "error", err)
+	}
+
+	return resp, nil
+}
+
+// ResetPassword sets a new password using a reset token. The token is consumed,
+// every session of the user is revoked and any login lockout is lifted.
+func (s *PasswordService) ResetPassword(req models.ResetPasswordRequest) (models.SuccessResponse, error) {
+	// Validate fields
+	if err := validateRequest(req); err != nil {
+		return models.SuccessResponse{}, err
+	}
+
+	invalidToken := NewError(ErrValidation, "invalid or expired reset token")
+
+	stored, err := s.resetRepo.GetByHash(hashToken(req.Token))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return models.SuccessResponse{}, invalidToken
+		}
+		return models.SuccessResponse{}, err
+	}
+
+	now := s.now()
+	if stored.UsedAt != nil || now.After(stored.ExpiresAt) {
+		return models.SuccessResponse{}, invalidToken
+	}
+	if err := s.resetRepo.MarkUsed(stored.ID, now); err != nil {
+		if errors.Is(err, repository.ErrResetTokenUsed) {
+			return models.SuccessResponse{}, invalidToken
+		}
+		return models.SuccessResponse{}, fmt.Errorf("failed to consume reset token: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(stored.UserID)
+	if err != nil {
+		return models.SuccessResponse{}, err
+	}
+
+	if err := s.setPassword(user.ID, req.NewPassword); err != nil {
+		return models.SuccessResponse{}, err
+	}
+
+	// Proving control of the mailbox lifts a lockout caused by failed logins
+	if err := s.authService.UnlockAccount(user); err != nil {
+		slog.Warn("Failed to unlock account after password reset", "user_id", user.ID, "error", err)
+	}
+
+	return models.SuccessResponse{
+		Message: "비밀번호가 재설정되었습니다. 다시 로그인해주세요.",
+		Status:  "success",
+	}, nil
+}
+
+// setPassword stores a new password hash and revokes every session of the user
+func (s *PasswordService) setPassword(userID int, password string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := s.userRepo.UpdatePassword(userID, string(hashedPassword)); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	return s.authService.LogoutAll(userID)
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+// recordingMailer keeps sent messages in memory
+type recordingMailer struct {
+	sent []Message
+}
+
+func (m *recordingMailer) Send(msg Message) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// lastToken extracts the reset token from the most recent message
+func (m *recordingMailer) lastToken(t *testing.T) string {
+	t.Helper()
+	if len(m.sent) == 0 {
+		t.Fatal("Expected a reset email, none was sent")
+	}
+	// The token is the only paragraph without spaces
+	for _, paragraph := range strings.Split(m.sent[len(m.sent)-1].Body, "\n\n") {
+		if paragraph != "" && !strings.ContainsAny(paragraph, " \n") {
+			return paragraph
+		}
+	}
+	t.Fatalf("No token found in email body %q", m.sent[len(m.sent)-1].Body)
+	return ""
+}
+
+func setupPasswordTest(t *testing.T) (*PasswordService, *AuthService, *recordingMailer, *fakeClock, models.User) {
+	t.Helper()
+	os.Setenv("JWT_SECRET", "test_secret_key_for_testing")
+	t.Cleanup(func() { os.Unsetenv("JWT_SECRET") })
+
+	userRepo := repository.NewInMemoryUserRepository()
+	authService := NewAuthService(userRepo, repository.NewInMemorySessionRepository(), repository.NewInMemoryLoginAttemptStore())
+	mailer := &recordingMailer{}
+	passwordService := NewPasswordService(userRepo, repository.NewInMemoryPasswordResetRepository(), authService, mailer)
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	authService.now = clock.Now
+	authService.audit = &recordingAuditLogger{}
+	passwordService.now = clock.Now
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	user := models.User{Name: "홍길동", Email: "hong@test.com", HashedPassword: string(hashed)}
+	if err := userRepo.Create(&user); err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+
+	return passwordService, authService, mailer, clock, user
+}
+
+func TestPasswordService_ChangePassword(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     models.ChangePasswordRequest
+		expectedErr error
+	}{
+		{
+			name:    "successful change",
+			request: models.ChangePasswordRequest{CurrentPassword: "password123", NewPassword: "newpassword456"},
+		},
+		{
+			name:        "missing fields",
+			request:     models.ChangePasswordRequest{NewPassword: "newpassword456"},
+			expectedErr: ErrValidation,
+		},
+		{
+			name:        "new password too short",
+			request:     models.ChangePasswordRequest{CurrentPassword: "password123", NewPassword: "short"},
+			expectedErr: ErrValidation,
+		},
+		{
+			name:        "wrong current password",
+			request:     models.ChangePasswordRequest{CurrentPassword: "wrongpassword", NewPassword: "newpassword456"},
+			expectedErr: ErrForbidden,
+		},
+		{
+			name:        "same password",
+			request:     models.ChangePasswordRequest{CurrentPassword: "password123", NewPassword: "password123"},
+			expectedErr: ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passwordService, authService, _, _, user := setupPasswordTest(t)
+
+			_, err := passwordService.ChangePassword(user.ID, tt.request)
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("Expected %v, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if _, err := authService.Login(models.LoginRequest{Email: user.Email, Password: tt.request.NewPassword}); err != nil {
+				t.Errorf("Expected login with new password, got %v", err)
+			}
+			if _, err := authService.Login(models.LoginRequest{Email: user.Email, Password: tt.request.CurrentPassword}); err == nil {
+				t.Error("Expected login with old password to fail")
+			}
+		})
+	}
+}
+
+func TestPasswordService_ChangePassword_RevokesSessions(t *testing.T) {
+	passwordService, authService, _, _, user := setupPasswordTest(t)
+
+	loginResp, err := authService.Login(models.LoginRequest{Email: user.Email, Password: "password123"})
+	if err != nil {
+		t.Fatalf("Failed to login: %v", err)
+	}
+
+	_, err = passwordService.ChangePassword(user.ID, models.ChangePasswordRequest{CurrentPassword: "password123", NewPassword: "newpassword456"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := authService.ValidateToken(loginResp.AccessToken); err == nil {
+		t.Error("Expected existing sessions to be revoked")
+	}
+}
+
+func TestPasswordService_RequestPasswordReset_UnknownEmail(t *testing.T) {
+	passwordService, _, mailer, _, user := setupPasswordTest(t)
+
+	known, err := passwordService.RequestPasswordReset(models.ForgotPasswordRequest{Email: user.Email})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	unknown, err := passwordService.RequestPasswordReset(models.ForgotPasswordRequest{Email: "nobody@test.com"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if known != unknown {
+		t.Errorf("Expected identical responses, got %+v and %+v", known, unknown)
+	}
+	if len(mailer.sent) != 1 || mailer.sent[0].To != user.Email {
+		t.Errorf("Expected a single email to %s, got %+v", user.Email, mailer.sent)
+	}
+}
+
+func TestPasswordService_ResetPassword(t *testing.T) {
+	passwordService, authService, mailer, _, user := setupPasswordTest(t)
+
+	loginResp, err := authService.Login(models.LoginRequest{Email: user.Email, Password: "password123"})
+	if err != nil {
+		t.Fatalf("Failed to login: %v", err)
+	}
+
+	if _, err := passwordService.RequestPasswordReset(models.ForgotPasswordRequest{Email: user.Email}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	token := mailer.lastToken(t)
+
+	if _, err := passwordService.ResetPassword(models.ResetPasswordRequest{Token: token, NewPassword: "newpassword456"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := authService.ValidateToken(loginResp.AccessToken); err == nil {
+		t.Error("Expected existing sessions to be revoked")
+	}
+	if _, err := authService.Login(models.LoginRequest{Email: user.Email, Password: "newpassword456"}); err != nil {
+		t.Errorf("Expected login with new password, got %v", err)
+	}
+
+	// The token is single-use
+	_, err = passwordService.ResetPassword(models.ResetPasswordRequest{Token: token, NewPassword: "anotherpassword789"})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for a reused token, got %v", err)
+	}
+}
+
+func TestPasswordService_ResetPassword_InvalidToken(t *testing.T) {
+	passwordService, _, mailer, clock, user := setupPasswordTest(t)
+
+	if _, err := passwordService.RequestPasswordReset(models.ForgotPasswordRequest{Email: user.Email}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	token := mailer.lastToken(t)
+
+	tests := []struct {
+		name    string
+		request models.ResetPasswordRequest
+		advance time.Duration
+	}{
+		{
+			name:    "unknown token",
+			request: models.ResetPasswordRequest{Token: "not-a-real-token", NewPassword: "newpassword456"},
+		},
+		{
+			name:    "missing token",
+			request: models.ResetPasswordRequest{NewPassword: "newpassword456"},
+		},
+		{
+			name:    "password too short",
+			request: models.ResetPasswordRequest{Token: token, NewPassword: "short"},
+		},
+		{
+			name:    "expired token",
+			request: models.ResetPasswordRequest{Token: token, NewPassword: "newpassword456"},
+			advance: PasswordResetTTL + time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock.Advance(tt.advance)
+
+			_, err := passwordService.ResetPassword(tt.request)
+			if !errors.Is(err, ErrValidation) {
+				t.Errorf("Expected ErrValidation, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPasswordService_ResetPassword_UnlocksAccount(t *testing.T) {
+	passwordService, authService, mailer, clock, user := setupPasswordTest(t)
+
+	failLogins(t, authService, clock, user.Email, "", MaxLoginFailures)
+	if _, err := authService.Login(models.LoginRequest{Email: user.Email, Password: "password123"}); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected account to be locked, got %v", err)
+	}
+
+	if _, err := passwordService.RequestPasswordReset(models.ForgotPasswordRequest{Email: user.Email}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := passwordService.ResetPassword(models.ResetPasswordRequest{Token: mailer.lastToken(t), NewPassword: "newpassword456"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := authService.Login(models.LoginRequest{Email: user.Email, Password: "newpassword456"}); err != nil {
+		t.Errorf("Expected login after password reset, got %v", err)
+	}
+}
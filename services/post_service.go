@@ -1,104 +1,247 @@
 package services
 
 import (
+	"errors"
 	"fmt"
-	"unicode/utf8"
+	"slices"
+	"strings"
+	"time"
 
 	"python-backend-with-go/models"
 	"python-backend-with-go/repository"
 )
 
-const MaxPostContentLength = 300
+// MaxThreadAncestors is the most ancestors GetThread returns; longer
+// conversations are cut off above that
+const MaxThreadAncestors = 50
+
+// PostRestoreWindow is how long after deleting a post its author can restore it
+const PostRestoreWindow = 30 * 24 * time.Hour
 
 // PostService handles post business logic
 type PostService struct {
-	postRepo   repository.PostRepository
-	userRepo   repository.UserRepository
-	followRepo repository.FollowRepository
+	postRepo      repository.PostRepository
+	userRepo      repository.UserRepository
+	followRepo    repository.FollowRepository
+	likeRepo      repository.LikeRepository
+	timelines     *TimelineService
+	search        *SearchService
+	trends        *TrendService
+	notifications *NotificationService
+	now           func() time.Time
 }
 
 // NewPostService creates a new post service
-func NewPostService(postRepo repository.PostRepository, userRepo repository.UserRepository, followRepo repository.FollowRepository) *PostService {
+func NewPostService(postRepo repository.PostRepository, userRepo repository.UserRepository, followRepo repository.FollowRepository, likeRepo repository.LikeRepository, timelines *TimelineService, search *SearchService, trends *TrendService, notifications *NotificationService) *PostService {
 	return &PostService{
-		postRepo:   postRepo,
-		userRepo:   userRepo,
-		followRepo: followRepo,
+		postRepo:      postRepo,
+		userRepo:      userRepo,
+		followRepo:    followRepo,
+		likeRepo:      likeRepo,
+		timelines:     timelines,
+		search:        search,
+		trends:        trends,
+		notifications: notifications,
+		now:           time.Now,
 	}
 }
 
-// CreatePost creates a new post
-func (s *PostService) CreatePost(req models.CreatePostRequest) (models.CreatePostResponse, error) {
-	// Validate user ID
-	if req.UserID == 0 {
-		return models.CreatePostResponse{}, fmt.Errorf("user_id is required")
+// CreatePost creates a new post authored by userID
+func (s *PostService) CreatePost(userID int, req models.CreatePostRequest) (models.CreatePostResponse, error) {
+	return s.createPost(userID, req, models.Post{})
+}
+
+// CreateReply creates a post authored by userID in reply to parentID.
+// Replying to a repost replies to the post it shares.
+func (s *PostService) CreateReply(parentID, userID int, req models.CreatePostRequest) (models.CreatePostResponse, error) {
+	// Validate parent ID
+	if parentID == 0 {
+		return models.CreatePostResponse{}, NewError(ErrValidation, "post_id is required")
 	}
 
-	// Check if user exists
-	if _, err := s.userRepo.GetByID(req.UserID); err != nil {
-		return models.CreatePostResponse{}, fmt.Errorf("user not found")
+	// Check if parent post exists
+	parent, err := sharedPost(s.postRepo, parentID)
+	if err != nil {
+		return models.CreatePostResponse{}, err
+	}
+
+	return s.createPost(userID, req, models.Post{InReplyToPostID: &parent.ID})
+}
+
+// QuotePost creates a post authored by userID that shares originalID with added content.
+// Quoting a repost quotes the post it shares.
+func (s *PostService) QuotePost(originalID, userID int, req models.CreatePostRequest) (models.CreatePostResponse, error) {
+	// Validate original ID
+	if originalID == 0 {
+		return models.CreatePostResponse{}, NewError(ErrValidation, "post_id is required")
+	}
+
+	// Check if original post exists
+	original, err := sharedPost(s.postRepo, originalID)
+	if err != nil {
+		return models.CreatePostResponse{}, err
+	}
+
+	return s.createPost(userID, req, models.Post{Kind: models.PostKindQuote, OriginalPostID: &original.ID})
+}
+
+// Repost shares originalID unchanged into the timelines of userID's followers.
+// Reposting a repost reposts the post it shares; each post can be reposted once per user.
+func (s *PostService) Repost(originalID, userID int) (models.CreatePostResponse, error) {
+	// Validate IDs
+	if originalID == 0 || userID == 0 {
+		return models.CreatePostResponse{}, NewError(ErrValidation, "post_id and user_id are required")
+	}
+
+	// Check if original post exists
+	original, err := sharedPost(s.postRepo, originalID)
+	if err != nil {
+		return models.CreatePostResponse{}, err
+	}
+
+	// Check if already reposted
+	if _, err := s.postRepo.GetRepost(userID, original.ID); err == nil {
+		return models.CreatePostResponse{}, NewError(ErrConflict, "already reposted this post")
+	}
+
+	return s.publish(userID, models.Post{Kind: models.PostKindRepost, OriginalPostID: &original.ID})
+}
+
+// UndoRepost deletes userID's repost of originalID
+func (s *PostService) UndoRepost(originalID, userID int) (models.UndoRepostResponse, error) {
+	// Validate IDs
+	if originalID == 0 || userID == 0 {
+		return models.UndoRepostResponse{}, NewError(ErrValidation, "post_id and user_id are required")
+	}
+
+	// Find the repost
+	original, err := sharedPost(s.postRepo, originalID)
+	if err != nil {
+		return models.UndoRepostResponse{}, err
+	}
+	repost, err := s.postRepo.GetRepost(userID, original.ID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return models.UndoRepostResponse{}, NewError(ErrNotFound, "repost not found")
+		}
+		return models.UndoRepostResponse{}, err
 	}
 
-	// Validate content
-	if req.Content == "" {
-		return models.CreatePostResponse{}, fmt.Errorf("content is required")
+	// Delete repost
+	if err := s.postRepo.Delete(repost.ID); err != nil {
+		return models.UndoRepostResponse{}, fmt.Errorf("failed to delete repost: %w", err)
 	}
 
-	// Check content length (300 characters limit)
-	if utf8.RuneCountInString(req.Content) > MaxPostContentLength {
-		return models.CreatePostResponse{}, fmt.Errorf("content must be %d characters or less", MaxPostContentLength)
+	// Remove from followers' timelines
+	s.timelines.PostDeleted(repost)
+
+	return models.UndoRepostResponse{
+		Message:        "리포스트 취소 성공",
+		PostID:         repost.ID,
+		OriginalPostID: original.ID,
+	}, nil
+}
+
+// createPost implements CreatePost, CreateReply and QuotePost: it validates
+// the content and publishes post with it
+func (s *PostService) createPost(userID int, req models.CreatePostRequest, post models.Post) (models.CreatePostResponse, error) {
+	// Validate user ID
+	if userID == 0 {
+		return models.CreatePostResponse{}, NewError(ErrValidation, "user_id is required")
+	}
+	if err := validateRequest(req); err != nil {
+		return models.CreatePostResponse{}, err
 	}
 
-	// Create post (ID will be auto-generated by database)
-	post := models.Post{
-		UserID:  req.UserID,
-		Content: req.Content,
+	post.Content = req.Content
+	return s.publish(userID, post)
+}
+
+// publish stores a post authored by userID and pushes it into followers' timelines
+func (s *PostService) publish(userID int, post models.Post) (models.CreatePostResponse, error) {
+	// Check if user exists and may post
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return models.CreatePostResponse{}, err
+	}
+	if err := requireVerifiedEmail(user, currentVerificationPolicy().blocksPosting()); err != nil {
+		return models.CreatePostResponse{}, err
+	}
+
+	// Create post (ID will be auto-generated by database) with its hashtags and mentions
+	post.UserID = userID
+	if post.Kind == "" {
+		post.Kind = models.PostKindPost
+	}
+	if post.Entities, err = resolveEntities(s.userRepo, post.Content); err != nil {
+		return models.CreatePostResponse{}, err
 	}
 
 	if err := s.postRepo.Create(&post); err != nil {
+		if errors.Is(err, repository.ErrRepostExists) {
+			return models.CreatePostResponse{}, NewError(ErrConflict, "already reposted this post")
+		}
 		return models.CreatePostResponse{}, fmt.Errorf("failed to create post: %w", err)
 	}
 
+	// Push into followers' timelines, the search index and the trend counts,
+	// and notify the replied-to and mentioned users
+	s.timelines.PostCreated(post)
+	s.search.PostChanged(post)
+	s.trends.PostCreated(post)
+	s.notifications.PostCreated(post)
+
+	message := "게시글이 생성되었습니다."
+	if post.Kind == models.PostKindRepost {
+		message = "리포스트 성공"
+	}
 	return models.CreatePostResponse{
-		Message: "게시글이 생성되었습니다.",
+		Message: message,
 		PostID:  post.ID, // ID is now populated by GORM after Create
 		Post:    post,
 	}, nil
 }
 
-// UpdatePost updates a post
-func (s *PostService) UpdatePost(postID int, req models.UpdatePostRequest) (models.UpdatePostResponse, error) {
+// UpdatePost updates a post on behalf of userID
+func (s *PostService) UpdatePost(postID, userID int, req models.UpdatePostRequest) (models.UpdatePostResponse, error) {
 	// Validate IDs
-	if postID == 0 || req.UserID == 0 {
-		return models.UpdatePostResponse{}, fmt.Errorf("post_id and user_id are required")
-	}
-
-	// Validate content
-	if req.Content == "" {
-		return models.UpdatePostResponse{}, fmt.Errorf("content is required")
+	if postID == 0 || userID == 0 {
+		return models.UpdatePostResponse{}, NewError(ErrValidation, "post_id and user_id are required")
 	}
 
-	// Check content length (300 characters limit)
-	if utf8.RuneCountInString(req.Content) > MaxPostContentLength {
-		return models.UpdatePostResponse{}, fmt.Errorf("content must be %d characters or less", MaxPostContentLength)
+	if err := validateRequest(req); err != nil {
+		return models.UpdatePostResponse{}, err
 	}
 
 	// Get post
 	post, err := s.postRepo.GetByID(postID)
 	if err != nil {
-		return models.UpdatePostResponse{}, fmt.Errorf("post not found")
+		return models.UpdatePostResponse{}, err
 	}
 
 	// Check authorization (only post owner can update)
-	if post.UserID != req.UserID {
-		return models.UpdatePostResponse{}, fmt.Errorf("unauthorized to update this post")
+	if post.UserID != userID {
+		return models.UpdatePostResponse{}, NewError(ErrForbidden, "unauthorized to update this post")
+	}
+	if post.Kind == models.PostKindRepost {
+		return models.UpdatePostResponse{}, NewError(ErrValidation, "reposts cannot be edited")
 	}
 
-	// Update post
+	// Update post and its hashtags and mentions; the repository keeps the
+	// previous content as a revision
+	previous := post
+	editedAt := s.now()
 	post.Content = req.Content
+	post.EditedAt = &editedAt
+	if post.Entities, err = resolveEntities(s.userRepo, post.Content); err != nil {
+		return models.UpdatePostResponse{}, err
+	}
 
 	if err := s.postRepo.Update(&post); err != nil {
 		return models.UpdatePostResponse{}, fmt.Errorf("failed to update post: %w", err)
 	}
+	s.search.PostChanged(post)
+	s.trends.PostUpdated(previous, post)
 
 	return models.UpdatePostResponse{
 		Message: "게시글이 수정되었습니다.",
@@ -107,22 +250,30 @@ func (s *PostService) UpdatePost(postID int, req models.UpdatePostRequest) (mode
 	}, nil
 }
 
-// DeletePost deletes a post
+// DeletePost deletes a post on behalf of userID. Reposts of the post are
+// deleted with it; replies and quote posts are kept. The author can restore
+// the post within PostRestoreWindow.
 func (s *PostService) DeletePost(postID int, userID int) (models.DeletePostResponse, error) {
 	// Validate IDs
 	if postID == 0 || userID == 0 {
-		return models.DeletePostResponse{}, fmt.Errorf("post_id and user_id are required")
+		return models.DeletePostResponse{}, NewError(ErrValidation, "post_id and user_id are required")
 	}
 
 	// Get post
 	post, err := s.postRepo.GetByID(postID)
 	if err != nil {
-		return models.DeletePostResponse{}, fmt.Errorf("post not found")
+		return models.DeletePostResponse{}, err
 	}
 
 	// Check authorization (only post owner can delete)
 	if post.UserID != userID {
-		return models.DeletePostResponse{}, fmt.Errorf("unauthorized to delete this post")
+		return models.DeletePostResponse{}, NewError(ErrForbidden, "unauthorized to delete this post")
+	}
+
+	// Find reposts, which the repository deletes along with the post
+	reposts, err := s.postRepo.GetReposts(postID)
+	if err != nil {
+		return models.DeletePostResponse{}, fmt.Errorf("failed to get reposts: %w", err)
 	}
 
 	// Delete post
@@ -130,43 +281,285 @@ func (s *PostService) DeletePost(postID int, userID int) (models.DeletePostRespo
 		return models.DeletePostResponse{}, fmt.Errorf("failed to delete post: %w", err)
 	}
 
+	// Remove the post and its reposts from followers' timelines, the search
+	// index and the trend counts, and the notifications about it
+	s.timelines.PostDeleted(post)
+	for _, repost := range reposts {
+		s.timelines.PostDeleted(repost)
+	}
+	s.search.PostRemoved(post)
+	s.trends.PostDeleted(post)
+	s.notifications.PostDeleted(post)
+
 	return models.DeletePostResponse{
 		Message: "게시글이 삭제되었습니다.",
 		PostID:  postID,
 	}, nil
 }
 
-// GetUserPosts retrieves posts by a specific user
-func (s *PostService) GetUserPosts(userID int) (models.UserPostsResponse, error) {
+// RestorePost restores a post userID deleted within PostRestoreWindow,
+// together with the reposts deleted with it
+func (s *PostService) RestorePost(postID, userID int) (models.RestorePostResponse, error) {
+	// Validate IDs
+	if postID == 0 || userID == 0 {
+		return models.RestorePostResponse{}, NewError(ErrValidation, "post_id and user_id are required")
+	}
+
+	// Get deleted post
+	post, err := s.postRepo.GetDeletedByID(postID)
+	if err != nil {
+		return models.RestorePostResponse{}, err
+	}
+
+	// Check authorization (only post owner can restore)
+	if post.UserID != userID {
+		return models.RestorePostResponse{}, NewError(ErrForbidden, "unauthorized to restore this post")
+	}
+	if post.Kind == models.PostKindRepost {
+		return models.RestorePostResponse{}, NewError(ErrValidation, "reposts cannot be restored; repost the post again")
+	}
+	if s.now().Sub(post.DeletedAt.Time) > PostRestoreWindow {
+		return models.RestorePostResponse{}, NewError(ErrConflict, "restore window has expired")
+	}
+
+	// Restore post
+	if err := s.postRepo.Restore(postID); err != nil {
+		return models.RestorePostResponse{}, fmt.Errorf("failed to restore post: %w", err)
+	}
+	if post, err = s.postRepo.GetByID(postID); err != nil {
+		return models.RestorePostResponse{}, fmt.Errorf("failed to get restored post: %w", err)
+	}
+	mentions, err := s.postRepo.GetMentionsByPostIDs([]int{postID})
+	if err != nil {
+		return models.RestorePostResponse{}, fmt.Errorf("failed to get mentions: %w", err)
+	}
+	post.Entities = storedEntities(post.Content, mentions[postID])
+
+	// Put the post and its restored reposts back into followers' timelines, the
+	// search index and the trend counts
+	reposts, err := s.postRepo.GetReposts(postID)
+	if err != nil {
+		return models.RestorePostResponse{}, fmt.Errorf("failed to get reposts: %w", err)
+	}
+	s.timelines.PostCreated(post)
+	for _, repost := range reposts {
+		s.timelines.PostCreated(repost)
+	}
+	s.search.PostChanged(post)
+	s.trends.PostCreated(post)
+
+	return models.RestorePostResponse{
+		Message: "게시글이 복구되었습니다.",
+		PostID:  postID,
+		Post:    post,
+	}, nil
+}
+
+// GetPostHistory retrieves a page of the earlier versions of a post, newest first
+func (s *PostService) GetPostHistory(postID int, page models.PageRequest) (models.PostHistoryResponse, error) {
+	// Validate pagination
+	query, limit, err := pageQuery(page)
+	if err != nil {
+		return models.PostHistoryResponse{}, err
+	}
+
+	// Check if post exists
+	if _, err := s.postRepo.GetByID(postID); err != nil {
+		return models.PostHistoryResponse{}, err
+	}
+
+	// Get revisions
+	revisions, err := s.postRepo.GetRevisions(postID, query)
+	if err != nil {
+		return models.PostHistoryResponse{}, fmt.Errorf("failed to get revisions: %w", err)
+	}
+	revisions, nextCursor := nextPage(revisions, limit, func(r models.PostRevision) repository.Cursor {
+		return repository.Cursor{CreatedAt: r.CreatedAt, ID: r.ID}
+	})
+
+	return models.PostHistoryResponse{
+		PostID:     postID,
+		Revisions:  revisions,
+		Count:      len(revisions),
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// GetPost retrieves a single post with its author and engagement as seen by
+// viewerID (0 for an anonymous viewer)
+func (s *PostService) GetPost(postID, viewerID int) (models.PostWithUser, error) {
+	// Get post
+	post, err := s.postRepo.GetByID(postID)
+	if err != nil {
+		return models.PostWithUser{}, err
+	}
+
+	// Attach author and engagement
+	withUsers, err := attachUsers(s.userRepo, []models.Post{post})
+	if err != nil {
+		return models.PostWithUser{}, err
+	}
+	if len(withUsers) == 0 {
+		return models.PostWithUser{}, fmt.Errorf("author of post %d not found", post.ID)
+	}
+	if err := attachEngagement(s.postRepo, s.userRepo, s.likeRepo, withUsers, viewerID); err != nil {
+		return models.PostWithUser{}, err
+	}
+
+	return withUsers[0], nil
+}
+
+// GetUserPosts retrieves a page of posts by a specific user as seen by viewerID
+// (0 for an anonymous viewer)
+func (s *PostService) GetUserPosts(userID, viewerID int, page models.PageRequest) (models.UserPostsResponse, error) {
+	// Validate pagination
+	query, limit, err := pageQuery(page)
+	if err != nil {
+		return models.UserPostsResponse{}, err
+	}
+
 	// Check if user exists
-	if _, err := s.userRepo.GetByID(userID); err != nil {
-		return models.UserPostsResponse{}, fmt.Errorf("user not found")
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return models.UserPostsResponse{}, err
 	}
 
 	// Get user's posts
-	posts, err := s.postRepo.GetByUserID(userID)
+	posts, err := s.postRepo.GetByUserID(userID, query)
 	if err != nil {
 		return models.UserPostsResponse{}, fmt.Errorf("failed to get posts: %w", err)
 	}
+	posts, nextCursor := nextPage(posts, limit, postCursor)
+
+	// Every post has the same author, who was loaded above
+	postsWithUser := make([]models.PostWithUser, len(posts))
+	for i, post := range posts {
+		postsWithUser[i] = models.PostWithUser{
+			ID:              post.ID,
+			UserID:          post.UserID,
+			UserName:        user.Name,
+			Kind:            post.Kind,
+			Content:         post.Content,
+			InReplyToPostID: post.InReplyToPostID,
+			OriginalPostID:  post.OriginalPostID,
+			CreatedAt:       post.CreatedAt,
+			EditedAt:        post.EditedAt,
+		}
+	}
+	if err := attachEngagement(s.postRepo, s.userRepo, s.likeRepo, postsWithUser, viewerID); err != nil {
+		return models.UserPostsResponse{}, err
+	}
 
 	return models.UserPostsResponse{
-		Posts: posts,
-		Count: len(posts),
+		Posts:      postsWithUser,
+		Count:      len(postsWithUser),
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// GetHashtagPosts retrieves a page of the posts tagged with a hashtag, given
+// with or without the #, as seen by viewerID (0 for an anonymous viewer)
+func (s *PostService) GetHashtagPosts(tag string, viewerID int, page models.PageRequest) (models.HashtagPostsResponse, error) {
+	// Validate hashtag and pagination
+	tag, ok := normalizeHashtag(strings.TrimPrefix(tag, "#"))
+	if !ok {
+		return models.HashtagPostsResponse{}, NewError(ErrValidation, "invalid hashtag")
+	}
+	query, limit, err := pageQuery(page)
+	if err != nil {
+		return models.HashtagPostsResponse{}, err
+	}
+
+	// Get tagged posts
+	posts, err := s.postRepo.GetByHashtag(tag, query)
+	if err != nil {
+		return models.HashtagPostsResponse{}, fmt.Errorf("failed to get posts: %w", err)
+	}
+	posts, nextCursor := nextPage(posts, limit, postCursor)
+
+	withUsers, err := s.withEngagement(posts, viewerID)
+	if err != nil {
+		return models.HashtagPostsResponse{}, err
+	}
+
+	return models.HashtagPostsResponse{
+		Tag:        tag,
+		Posts:      withUsers,
+		Count:      len(withUsers),
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// GetMentions retrieves a page of the posts mentioning a user as seen by
+// viewerID (0 for an anonymous viewer)
+func (s *PostService) GetMentions(userID, viewerID int, page models.PageRequest) (models.MentionsResponse, error) {
+	// Validate pagination
+	query, limit, err := pageQuery(page)
+	if err != nil {
+		return models.MentionsResponse{}, err
+	}
+
+	// Check if user exists
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return models.MentionsResponse{}, err
+	}
+
+	// Get posts mentioning the user
+	posts, err := s.postRepo.GetByMention(userID, query)
+	if err != nil {
+		return models.MentionsResponse{}, fmt.Errorf("failed to get posts: %w", err)
+	}
+	posts, nextCursor := nextPage(posts, limit, postCursor)
+
+	withUsers, err := s.withEngagement(posts, viewerID)
+	if err != nil {
+		return models.MentionsResponse{}, err
+	}
+
+	return models.MentionsResponse{
+		UserID:     userID,
+		Posts:      withUsers,
+		Count:      len(withUsers),
+		NextCursor: nextCursor,
 	}, nil
 }
 
-// GetTimeline retrieves timeline for a user (posts from followed users)
-func (s *PostService) GetTimeline(userID int) (models.TimelineResponse, error) {
+// withEngagement attaches authors and engagement to a page of posts
+func (s *PostService) withEngagement(posts []models.Post, viewerID int) ([]models.PostWithUser, error) {
+	withUsers, err := attachUsers(s.userRepo, posts)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachEngagement(s.postRepo, s.userRepo, s.likeRepo, withUsers, viewerID); err != nil {
+		return nil, err
+	}
+	return withUsers, nil
+}
+
+// GetTimeline retrieves a page of the timeline for a user (posts from followed users)
+// as seen by viewerID (0 for an anonymous viewer). A post reposted by several
+// followed users appears once, at its newest repost.
+func (s *PostService) GetTimeline(userID, viewerID int, page models.PageRequest) (models.TimelineResponse, error) {
+	// Validate pagination
+	query, limit, err := pageQuery(page)
+	if err != nil {
+		return models.TimelineResponse{}, err
+	}
+
 	// Check if user exists
 	if _, err := s.userRepo.GetByID(userID); err != nil {
-		return models.TimelineResponse{}, fmt.Errorf("user not found")
+		return models.TimelineResponse{}, err
 	}
 
 	// Get users that this user follows
-	followingIDs, err := s.followRepo.GetFollowing(userID)
+	follows, err := s.followRepo.GetFollowing(userID, repository.PageQuery{})
 	if err != nil {
 		return models.TimelineResponse{}, fmt.Errorf("failed to get following: %w", err)
 	}
+	followingIDs := make([]int, len(follows))
+	for i, follow := range follows {
+		followingIDs[i] = follow.FollowUserID
+	}
 
 	// If not following anyone, return empty timeline
 	if len(followingIDs) == 0 {
@@ -176,31 +569,234 @@ func (s *PostService) GetTimeline(userID int) (models.TimelineResponse, error) {
 		}, nil
 	}
 
-	// Get posts from followed users
-	posts, err := s.postRepo.GetByUserIDs(followingIDs)
+	// Get posts from followed users, with author information attached
+	posts, nextCursor, err := s.timelinePage(userID, followingIDs, query, limit)
 	if err != nil {
-		return models.TimelineResponse{}, fmt.Errorf("failed to get posts: %w", err)
+		return models.TimelineResponse{}, err
+	}
+	if err := attachEngagement(s.postRepo, s.userRepo, s.likeRepo, posts, viewerID); err != nil {
+		return models.TimelineResponse{}, err
 	}
 
-	// Convert to PostWithUser
-	postsWithUser := make([]models.PostWithUser, 0, len(posts))
-	for _, post := range posts {
-		user, err := s.userRepo.GetByID(post.UserID)
+	return models.TimelineResponse{
+		Posts:      posts,
+		Count:      len(posts),
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// GetThread retrieves a post with the posts it replies to and a page of its
+// direct replies, as seen by viewerID (0 for an anonymous viewer). Deeper
+// replies are reached by requesting the thread of a reply.
+func (s *PostService) GetThread(postID, viewerID int, page models.PageRequest) (models.ThreadResponse, error) {
+	// Validate pagination
+	query, limit, err := pageQuery(page)
+	if err != nil {
+		return models.ThreadResponse{}, err
+	}
+
+	// Get post
+	post, err := s.postRepo.GetByID(postID)
+	if err != nil {
+		return models.ThreadResponse{}, err
+	}
+
+	// Walk up the conversation, nearest ancestor first
+	var ancestors []models.Post
+	for parentID := post.InReplyToPostID; parentID != nil && len(ancestors) < MaxThreadAncestors; {
+		parent, err := s.postRepo.GetByID(*parentID)
+		if errors.Is(err, ErrNotFound) {
+			break // Deleted while we were walking
+		}
 		if err != nil {
-			continue // Skip if user not found
+			return models.ThreadResponse{}, fmt.Errorf("failed to get post: %w", err)
 		}
+		ancestors = append(ancestors, parent)
+		parentID = parent.InReplyToPostID
+	}
+	slices.Reverse(ancestors)
 
-		postsWithUser = append(postsWithUser, models.PostWithUser{
-			ID:        post.ID,
-			UserID:    post.UserID,
-			UserName:  user.Name,
-			Content:   post.Content,
-			CreatedAt: post.CreatedAt,
-		})
+	// Get direct replies
+	replies, err := s.postRepo.GetReplies(postID, query)
+	if err != nil {
+		return models.ThreadResponse{}, fmt.Errorf("failed to get replies: %w", err)
 	}
+	replies, nextCursor := nextPage(replies, limit, postCursor)
 
-	return models.TimelineResponse{
-		Posts: postsWithUser,
-		Count: len(postsWithUser),
+	// Attach authors and engagement to the whole thread at once
+	withUsers, err := attachUsers(s.userRepo, slices.Concat(ancestors, []models.Post{post}, replies))
+	if err != nil {
+		return models.ThreadResponse{}, err
+	}
+	if err := attachEngagement(s.postRepo, s.userRepo, s.likeRepo, withUsers, viewerID); err != nil {
+		return models.ThreadResponse{}, err
+	}
+	byID := make(map[int]models.PostWithUser, len(withUsers))
+	for _, p := range withUsers {
+		byID[p.ID] = p
+	}
+	pick := func(posts []models.Post) []models.PostWithUser {
+		picked := make([]models.PostWithUser, 0, len(posts))
+		for _, p := range posts {
+			if withUser, ok := byID[p.ID]; ok {
+				picked = append(picked, withUser)
+			}
+		}
+		return picked
+	}
+
+	threadPost, ok := byID[post.ID]
+	if !ok {
+		return models.ThreadResponse{}, fmt.Errorf("author of post %d not found", post.ID)
+	}
+	threadReplies := pick(replies)
+
+	return models.ThreadResponse{
+		Ancestors:  pick(ancestors),
+		Post:       threadPost,
+		Replies:    threadReplies,
+		Count:      len(threadReplies),
+		NextCursor: nextCursor,
 	}, nil
 }
+
+// attachEngagement attaches the posts shared by reposts and quote posts and
+// fills in like and reply counts, hashtags and mentions for every post
+// involved, with a fixed number of queries whatever the page size
+func attachEngagement(postRepo repository.PostRepository, userRepo repository.UserRepository, likeRepo repository.LikeRepository, posts []models.PostWithUser, viewerID int) error {
+	// Load shared posts with their authors
+	originalIDs := make([]int, 0)
+	for _, post := range posts {
+		if post.OriginalPostID != nil {
+			originalIDs = append(originalIDs, *post.OriginalPostID)
+		}
+	}
+	originals := []models.PostWithUser{}
+	if len(originalIDs) > 0 {
+		var err error
+		if originals, err = loadPostsWithUsers(postRepo, userRepo, originalIDs); err != nil {
+			return fmt.Errorf("failed to get shared posts: %w", err)
+		}
+	}
+
+	// Count likes and replies of the page and the shared posts together
+	all := slices.Concat(posts, originals)
+	if err := attachLikes(likeRepo, all, viewerID); err != nil {
+		return err
+	}
+	if len(all) > 0 {
+		postIDs := make([]int, len(all))
+		for i, post := range all {
+			postIDs[i] = post.ID
+		}
+		counts, err := postRepo.CountReplies(postIDs)
+		if err != nil {
+			return fmt.Errorf("failed to count replies: %w", err)
+		}
+		for i := range all {
+			all[i].ReplyCount = counts[all[i].ID]
+		}
+	}
+	if err := attachEntities(postRepo, all); err != nil {
+		return err
+	}
+	copy(posts, all[:len(posts)])
+
+	// Attach shared posts; a quote whose original was just deleted keeps none
+	originalsByID := make(map[int]models.PostWithUser, len(originals))
+	for _, original := range all[len(posts):] {
+		originalsByID[original.ID] = original
+	}
+	for i := range posts {
+		if id := posts[i].OriginalPostID; id != nil {
+			if original, ok := originalsByID[*id]; ok {
+				posts[i].Original = &original
+			}
+		}
+	}
+	return nil
+}
+
+// sharedPost loads a post to reply to, quote, repost or like. A repost stands
+// in for the post it shares, so the post it shares is returned instead.
+func sharedPost(postRepo repository.PostRepository, postID int) (models.Post, error) {
+	post, err := postRepo.GetByID(postID)
+	if err != nil {
+		return models.Post{}, err
+	}
+	if post.Kind == models.PostKindRepost && post.OriginalPostID != nil {
+		return postRepo.GetByID(*post.OriginalPostID)
+	}
+	return post, nil
+}
+
+// timelinePage reads userID's timeline from query on, dropping posts that
+// appear again newer in the timeline (see dedupeShares), until it has a page
+// of limit posts and the lookahead one or the timeline ends
+func (s *PostService) timelinePage(userID int, followingIDs []int, query repository.PageQuery, limit int) ([]models.PostWithUser, string, error) {
+	page := make([]models.PostWithUser, 0, query.Limit)
+	for len(page) <= limit {
+		batch, err := s.timelines.Page(userID, followingIDs, query)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get posts: %w", err)
+		}
+		kept, err := s.dedupeShares(batch, followingIDs)
+		if err != nil {
+			return nil, "", err
+		}
+		page = append(page, kept...)
+
+		if len(batch) < query.Limit {
+			break // End of the timeline
+		}
+		last := batch[len(batch)-1]
+		query.Cursor = &repository.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	posts, nextCursor := nextPage(page, limit, func(post models.PostWithUser) repository.Cursor {
+		return repository.Cursor{CreatedAt: post.CreatedAt, ID: post.ID}
+	})
+	return posts, nextCursor, nil
+}
+
+// dedupeShares keeps only the posts that are the newest appearance of the
+// post they show in the whole timeline of followingIDs, not just among posts.
+// A post is shown by itself and by its reposts; reposts are always newer, so
+// the newest appearance is the newest repost by a followed user, or the post
+// itself if none of them reposted it. This keeps pages consistent with each
+// other without remembering what earlier pages showed.
+func (s *PostService) dedupeShares(posts []models.PostWithUser, followingIDs []int) ([]models.PostWithUser, error) {
+	subject := func(post models.PostWithUser) int {
+		if post.Kind == models.PostKindRepost && post.OriginalPostID != nil {
+			return *post.OriginalPostID
+		}
+		return post.ID
+	}
+
+	subjects := make([]int, len(posts))
+	for i, post := range posts {
+		subjects[i] = subject(post)
+	}
+	reposts, err := s.postRepo.GetRepostsByUserIDs(subjects, followingIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reposts: %w", err)
+	}
+
+	newest := make(map[int]models.Post, len(reposts)) // key: original post ID, value: its newest repost
+	for _, repost := range reposts {
+		current, exists := newest[*repost.OriginalPostID]
+		if !exists || repost.CreatedAt.After(current.CreatedAt) ||
+			(repost.CreatedAt.Equal(current.CreatedAt) && repost.ID > current.ID) {
+			newest[*repost.OriginalPostID] = repost
+		}
+	}
+
+	deduped := make([]models.PostWithUser, 0, len(posts))
+	for _, post := range posts {
+		if repost, exists := newest[subject(post)]; exists && repost.ID != post.ID {
+			continue
+		}
+		deduped = append(deduped, post)
+	}
+	return deduped, nil
+}
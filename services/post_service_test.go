@@ -1,8 +1,11 @@
 package services
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"python-backend-with-go/models"
 	"python-backend-with-go/repository"
@@ -13,9 +16,11 @@ func setupPostServiceTest(_ *testing.T) (*PostService, *UserService, *FollowServ
 	followRepo := repository.NewInMemoryFollowRepository()
 	postRepo := repository.NewInMemoryPostRepository()
 
-	userService := NewUserService(userRepo)
-	followService := NewFollowService(followRepo, userRepo)
-	postService := NewPostService(postRepo, userRepo, followRepo)
+	timelineService := NewTimelineService(repository.NewInMemoryTimelineStore(), postRepo, userRepo, followRepo)
+
+	userService := newTestUserService(userRepo)
+	followService := NewFollowService(followRepo, userRepo, timelineService, newTestNotificationService(postRepo, userRepo))
+	postService := NewPostService(postRepo, userRepo, followRepo, repository.NewInMemoryLikeRepository(), timelineService, newTestSearchService(postRepo, userRepo), newTestTrendService(postRepo), newTestNotificationService(postRepo, userRepo))
 
 	// Create test users
 	for i := 1; i <= 3; i++ {
@@ -31,14 +36,15 @@ func setupPostServiceTest(_ *testing.T) (*PostService, *UserService, *FollowServ
 func TestPostService_CreatePost(t *testing.T) {
 	tests := []struct {
 		name        string
+		userID      int
 		request     models.CreatePostRequest
 		expectError bool
 		errorMsg    string
 	}{
 		{
-			name: "successful post creation",
+			name:   "successful post creation",
+			userID: 1,
 			request: models.CreatePostRequest{
-				UserID:  1,
 				Content: "안녕하세요, 첫 게시글입니다!",
 			},
 			expectError: false,
@@ -52,30 +58,36 @@ func TestPostService_CreatePost(t *testing.T) {
 			errorMsg:    "user_id is required",
 		},
 		{
-			name: "user not found",
+			name:   "user not found",
+			userID: 999,
 			request: models.CreatePostRequest{
-				UserID:  999,
 				Content: "테스트 게시글",
 			},
 			expectError: true,
 			errorMsg:    "user not found",
 		},
 		{
-			name: "missing content",
-			request: models.CreatePostRequest{
-				UserID: 1,
-			},
+			name:        "missing content",
+			userID:      1,
+			request:     models.CreatePostRequest{},
 			expectError: true,
 			errorMsg:    "content is required",
 		},
 		{
-			name: "content too long",
+			name:   "content at the limit",
+			userID: 1,
+			request: models.CreatePostRequest{
+				Content: strings.Repeat("가", 300),
+			},
+		},
+		{
+			name:   "content too long",
+			userID: 1,
 			request: models.CreatePostRequest{
-				UserID:  1,
 				Content: strings.Repeat("a", 301),
 			},
 			expectError: true,
-			errorMsg:    "content must be 300 characters or less",
+			errorMsg:    "content must be at most 300 characters",
 		},
 	}
 
@@ -83,7 +95,7 @@ func TestPostService_CreatePost(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			postService, _, _ := setupPostServiceTest(t)
 
-			resp, err := postService.CreatePost(tt.request)
+			resp, err := postService.CreatePost(tt.userID, tt.request)
 
 			if tt.expectError {
 				if err == nil {
@@ -107,8 +119,7 @@ func TestPostService_UpdatePost(t *testing.T) {
 	postService, _, _ := setupPostServiceTest(t)
 
 	// Create a post first
-	createResp, err := postService.CreatePost(models.CreatePostRequest{
-		UserID:  1,
+	createResp, err := postService.CreatePost(1, models.CreatePostRequest{
 		Content: "원본 게시글",
 	})
 	if err != nil {
@@ -118,6 +129,7 @@ func TestPostService_UpdatePost(t *testing.T) {
 	tests := []struct {
 		name        string
 		postID      int
+		userID      int
 		request     models.UpdatePostRequest
 		expectError bool
 		errorMsg    string
@@ -125,8 +137,8 @@ func TestPostService_UpdatePost(t *testing.T) {
 		{
 			name:   "successful update",
 			postID: createResp.PostID,
+			userID: 1,
 			request: models.UpdatePostRequest{
-				UserID:  1,
 				Content: "수정된 게시글",
 			},
 			expectError: false,
@@ -134,8 +146,8 @@ func TestPostService_UpdatePost(t *testing.T) {
 		{
 			name:   "unauthorized update",
 			postID: createResp.PostID,
+			userID: 2,
 			request: models.UpdatePostRequest{
-				UserID:  2,
 				Content: "다른 사용자가 수정 시도",
 			},
 			expectError: true,
@@ -144,8 +156,8 @@ func TestPostService_UpdatePost(t *testing.T) {
 		{
 			name:   "post not found",
 			postID: 999,
+			userID: 1,
 			request: models.UpdatePostRequest{
-				UserID:  1,
 				Content: "존재하지 않는 게시글",
 			},
 			expectError: true,
@@ -155,7 +167,7 @@ func TestPostService_UpdatePost(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			resp, err := postService.UpdatePost(tt.postID, tt.request)
+			resp, err := postService.UpdatePost(tt.postID, tt.userID, tt.request)
 
 			if tt.expectError {
 				if err == nil {
@@ -179,8 +191,7 @@ func TestPostService_DeletePost(t *testing.T) {
 	postService, _, _ := setupPostServiceTest(t)
 
 	// Create a post first
-	createResp, err := postService.CreatePost(models.CreatePostRequest{
-		UserID:  1,
+	createResp, err := postService.CreatePost(1, models.CreatePostRequest{
 		Content: "삭제할 게시글",
 	})
 	if err != nil {
@@ -215,17 +226,15 @@ func TestPostService_GetUserPosts(t *testing.T) {
 	postService, _, _ := setupPostServiceTest(t)
 
 	// Create posts for user 1
-	postService.CreatePost(models.CreatePostRequest{
-		UserID:  1,
+	postService.CreatePost(1, models.CreatePostRequest{
 		Content: "첫 번째 게시글",
 	})
-	postService.CreatePost(models.CreatePostRequest{
-		UserID:  1,
+	postService.CreatePost(1, models.CreatePostRequest{
 		Content: "두 번째 게시글",
 	})
 
 	// Get user posts
-	resp, err := postService.GetUserPosts(1)
+	resp, err := postService.GetUserPosts(1, 0, models.PageRequest{})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -246,17 +255,15 @@ func TestPostService_GetTimeline(t *testing.T) {
 	followService.Follow(1, 3)
 
 	// User 2 and 3 create posts
-	postService.CreatePost(models.CreatePostRequest{
-		UserID:  2,
+	postService.CreatePost(2, models.CreatePostRequest{
 		Content: "User 2의 게시글",
 	})
-	postService.CreatePost(models.CreatePostRequest{
-		UserID:  3,
+	postService.CreatePost(3, models.CreatePostRequest{
 		Content: "User 3의 게시글",
 	})
 
 	// Get timeline for User 1
-	resp, err := postService.GetTimeline(1)
+	resp, err := postService.GetTimeline(1, 0, models.PageRequest{})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -280,13 +287,12 @@ func TestPostService_GetTimeline_EmptyWhenNotFollowing(t *testing.T) {
 	postService, _, _ := setupPostServiceTest(t)
 
 	// User 2 creates a post
-	postService.CreatePost(models.CreatePostRequest{
-		UserID:  2,
+	postService.CreatePost(2, models.CreatePostRequest{
 		Content: "User 2의 게시글",
 	})
 
 	// Get timeline for User 1 (not following anyone)
-	resp, err := postService.GetTimeline(1)
+	resp, err := postService.GetTimeline(1, 0, models.PageRequest{})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -298,3 +304,669 @@ func TestPostService_GetTimeline_EmptyWhenNotFollowing(t *testing.T) {
 		t.Errorf("Expected 0 posts, got %d", len(resp.Posts))
 	}
 }
+
+func TestPostService_GetTimeline_Pagination(t *testing.T) {
+	postService, _, followService := setupPostServiceTest(t)
+
+	followService.Follow(1, 2)
+	followService.Follow(1, 3)
+
+	// Create 5 posts alternating between User 2 and 3
+	for i := 0; i < 5; i++ {
+		if _, err := postService.CreatePost(2+i%2, models.CreatePostRequest{
+			Content: "게시글 " + string(rune('0'+i)),
+		}); err != nil {
+			t.Fatalf("Failed to create post: %v", err)
+		}
+	}
+
+	// Walk the timeline two posts at a time
+	var postIDs []int
+	page := models.PageRequest{Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatal("Pagination did not terminate")
+		}
+
+		resp, err := postService.GetTimeline(1, 0, page)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if resp.Count > 2 {
+			t.Errorf("Expected at most 2 posts per page, got %d", resp.Count)
+		}
+		for _, post := range resp.Posts {
+			postIDs = append(postIDs, post.ID)
+		}
+
+		if resp.NextCursor == "" {
+			break
+		}
+		page.Cursor = resp.NextCursor
+	}
+
+	// All posts are returned exactly once, newest first
+	expected := []int{5, 4, 3, 2, 1}
+	if len(postIDs) != len(expected) {
+		t.Fatalf("Expected post IDs %v, got %v", expected, postIDs)
+	}
+	for i := range expected {
+		if postIDs[i] != expected[i] {
+			t.Fatalf("Expected post IDs %v, got %v", expected, postIDs)
+		}
+	}
+}
+
+func TestPostService_GetUserPosts_Pagination(t *testing.T) {
+	postService, _, _ := setupPostServiceTest(t)
+
+	for i := 0; i < 3; i++ {
+		postService.CreatePost(1, models.CreatePostRequest{Content: "게시글"})
+	}
+
+	resp, err := postService.GetUserPosts(1, 0, models.PageRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resp.Count != 2 || resp.NextCursor == "" {
+		t.Fatalf("Expected 2 posts and a next cursor, got %d posts and cursor '%s'", resp.Count, resp.NextCursor)
+	}
+
+	resp, err = postService.GetUserPosts(1, 0, models.PageRequest{Limit: 2, Cursor: resp.NextCursor})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resp.Count != 1 || resp.NextCursor != "" {
+		t.Errorf("Expected 1 post and no next cursor, got %d posts and cursor '%s'", resp.Count, resp.NextCursor)
+	}
+	if resp.Posts[0].ID != 1 {
+		t.Errorf("Expected oldest post (ID 1) on last page, got %d", resp.Posts[0].ID)
+	}
+}
+
+func TestPostService_GetUserPosts_InvalidPage(t *testing.T) {
+	postService, _, _ := setupPostServiceTest(t)
+
+	tests := []struct {
+		name     string
+		page     models.PageRequest
+		errorMsg string
+	}{
+		{
+			name:     "invalid cursor",
+			page:     models.PageRequest{Cursor: "not-a-cursor"},
+			errorMsg: "invalid cursor",
+		},
+		{
+			name:     "limit too large",
+			page:     models.PageRequest{Limit: MaxPageLimit + 1},
+			errorMsg: "limit must be at most 100",
+		},
+		{
+			name:     "negative limit",
+			page:     models.PageRequest{Limit: -1},
+			errorMsg: "limit must be at least 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := postService.GetUserPosts(1, 0, tt.page)
+			if err == nil {
+				t.Errorf("Expected error but got none")
+			} else if err.Error() != tt.errorMsg {
+				t.Errorf("Expected error '%s', got '%s'", tt.errorMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestPostService_CreateReply(t *testing.T) {
+	postService, _, _ := setupPostServiceTest(t)
+	parent, _ := postService.CreatePost(1, models.CreatePostRequest{Content: "원글"})
+
+	tests := []struct {
+		name     string
+		parentID int
+		userID   int
+		content  string
+		kind     error
+	}{
+		{name: "successful reply", parentID: parent.PostID, userID: 2, content: "답글"},
+		{name: "parent not found", parentID: 999, userID: 2, content: "답글", kind: ErrNotFound},
+		{name: "missing parent", parentID: 0, userID: 2, content: "답글", kind: ErrValidation},
+		{name: "empty content", parentID: parent.PostID, userID: 2, content: "", kind: ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := postService.CreateReply(tt.parentID, tt.userID, models.CreatePostRequest{Content: tt.content})
+			if tt.kind != nil {
+				if !errors.Is(err, tt.kind) {
+					t.Errorf("Expected error kind '%v', got '%v'", tt.kind, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if resp.Post.InReplyToPostID == nil || *resp.Post.InReplyToPostID != tt.parentID {
+				t.Errorf("Expected reply to post %d, got %v", tt.parentID, resp.Post.InReplyToPostID)
+			}
+		})
+	}
+}
+
+func TestPostService_GetThread(t *testing.T) {
+	postService, _, _ := setupPostServiceTest(t)
+
+	// root <- reply <- nested, plus two more replies to reply
+	root, _ := postService.CreatePost(1, models.CreatePostRequest{Content: "원글"})
+	reply, _ := postService.CreateReply(root.PostID, 2, models.CreatePostRequest{Content: "답글"})
+	var nestedIDs []int
+	for i := 0; i < 3; i++ {
+		nested, err := postService.CreateReply(reply.PostID, 3, models.CreatePostRequest{Content: "답글의 답글"})
+		if err != nil {
+			t.Fatalf("Failed to create reply: %v", err)
+		}
+		nestedIDs = append(nestedIDs, nested.PostID)
+	}
+
+	thread, err := postService.GetThread(reply.PostID, 0, models.PageRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(thread.Ancestors) != 1 || thread.Ancestors[0].ID != root.PostID || thread.Ancestors[0].UserName != "User1" {
+		t.Errorf("Expected the root as the only ancestor, got %+v", thread.Ancestors)
+	}
+	if thread.Ancestors[0].ReplyCount != 1 {
+		t.Errorf("Expected root reply count 1, got %d", thread.Ancestors[0].ReplyCount)
+	}
+	if thread.Post.ID != reply.PostID || thread.Post.ReplyCount != 3 {
+		t.Errorf("Expected post %d with 3 replies, got %+v", reply.PostID, thread.Post)
+	}
+	if thread.Count != 2 || thread.NextCursor == "" {
+		t.Fatalf("Expected a first page of 2 replies, got %d (next cursor %q)", thread.Count, thread.NextCursor)
+	}
+
+	rest, err := postService.GetThread(reply.PostID, 0, models.PageRequest{Limit: 2, Cursor: thread.NextCursor})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rest.Count != 1 || rest.NextCursor != "" || rest.Replies[0].ID != nestedIDs[0] {
+		t.Errorf("Expected the oldest reply on the last page, got %+v", rest.Replies)
+	}
+
+	// A post that is not a reply has no ancestors
+	top, err := postService.GetThread(root.PostID, 0, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(top.Ancestors) != 0 || top.Count != 1 {
+		t.Errorf("Expected no ancestors and 1 reply, got %d and %d", len(top.Ancestors), top.Count)
+	}
+
+	if _, err := postService.GetThread(999, 0, models.PageRequest{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestPostService_DeletePost_KeepsReplies(t *testing.T) {
+	postService, _, _ := setupPostServiceTest(t)
+	root, _ := postService.CreatePost(1, models.CreatePostRequest{Content: "원글"})
+	reply, _ := postService.CreateReply(root.PostID, 2, models.CreatePostRequest{Content: "답글"})
+
+	if _, err := postService.DeletePost(root.PostID, 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	thread, err := postService.GetThread(reply.PostID, 0, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("Expected the reply to survive its parent: %v", err)
+	}
+	if len(thread.Ancestors) != 0 {
+		t.Errorf("Expected the deleted parent to be hidden, got %+v", thread.Ancestors)
+	}
+
+	// Replying to the deleted post is no longer possible
+	if _, err := postService.CreateReply(root.PostID, 2, models.CreatePostRequest{Content: "늦은 답글"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	// Restoring the parent reattaches the reply
+	if _, err := postService.RestorePost(root.PostID, 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	thread, err = postService.GetThread(reply.PostID, 0, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(thread.Ancestors) != 1 || thread.Ancestors[0].ID != root.PostID {
+		t.Errorf("Expected the restored parent as the ancestor, got %+v", thread.Ancestors)
+	}
+}
+
+func TestPostService_RepostAndQuote(t *testing.T) {
+	postService, _, _ := setupPostServiceTest(t)
+	original, _ := postService.CreatePost(1, models.CreatePostRequest{Content: "원글"})
+
+	repost, err := postService.Repost(original.PostID, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if repost.Post.Kind != models.PostKindRepost || *repost.Post.OriginalPostID != original.PostID || repost.Post.Content != "" {
+		t.Errorf("Expected a repost of post %d, got %+v", original.PostID, repost.Post)
+	}
+
+	// Reposting a repost shares the original
+	quote, err := postService.QuotePost(repost.PostID, 3, models.CreatePostRequest{Content: "인용"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if quote.Post.Kind != models.PostKindQuote || *quote.Post.OriginalPostID != original.PostID {
+		t.Errorf("Expected a quote of post %d, got %+v", original.PostID, quote.Post)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		kind error
+	}{
+		{
+			name: "repost twice",
+			call: func() error {
+				_, err := postService.Repost(original.PostID, 2)
+				return err
+			},
+			kind: ErrConflict,
+		},
+		{
+			name: "repost through a repost",
+			call: func() error {
+				_, err := postService.Repost(repost.PostID, 2)
+				return err
+			},
+			kind: ErrConflict,
+		},
+		{
+			name: "repost missing post",
+			call: func() error {
+				_, err := postService.Repost(999, 2)
+				return err
+			},
+			kind: ErrNotFound,
+		},
+		{
+			name: "quote without content",
+			call: func() error {
+				_, err := postService.QuotePost(original.PostID, 2, models.CreatePostRequest{})
+				return err
+			},
+			kind: ErrValidation,
+		},
+		{
+			name: "edit a repost",
+			call: func() error {
+				_, err := postService.UpdatePost(repost.PostID, 2, models.UpdatePostRequest{Content: "수정"})
+				return err
+			},
+			kind: ErrValidation,
+		},
+		{
+			name: "undo a repost that does not exist",
+			call: func() error {
+				_, err := postService.UndoRepost(original.PostID, 3)
+				return err
+			},
+			kind: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, tt.kind) {
+				t.Errorf("Expected error kind '%v', got '%v'", tt.kind, err)
+			}
+		})
+	}
+
+	undo, err := postService.UndoRepost(original.PostID, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if undo.PostID != repost.PostID || undo.OriginalPostID != original.PostID {
+		t.Errorf("Expected repost %d of %d undone, got %+v", repost.PostID, original.PostID, undo)
+	}
+	if _, err := postService.Repost(original.PostID, 2); err != nil {
+		t.Errorf("Expected to repost again after undoing, got %v", err)
+	}
+}
+
+func TestPostService_GetTimeline_Reposts(t *testing.T) {
+	postService, _, followService := setupPostServiceTest(t)
+
+	// User 1 follows users 2 and 3; user 2 reposts and quotes user 3's post
+	followService.Follow(1, 2)
+	followService.Follow(1, 3)
+	original, _ := postService.CreatePost(3, models.CreatePostRequest{Content: "원글"})
+	postService.Repost(original.PostID, 2)
+	quote, _ := postService.QuotePost(original.PostID, 2, models.CreatePostRequest{Content: "인용"})
+
+	resp, err := postService.GetTimeline(1, 1, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The quote and one appearance of the original: user 2's newer repost
+	if resp.Count != 2 {
+		t.Fatalf("Expected 2 posts after de-duplication, got %d: %+v", resp.Count, resp.Posts)
+	}
+	if resp.Posts[0].ID != quote.PostID || resp.Posts[0].Original == nil || resp.Posts[0].Original.Content != "원글" {
+		t.Errorf("Expected the quote with its original attached first, got %+v", resp.Posts[0])
+	}
+	shared := resp.Posts[1]
+	if shared.Kind != models.PostKindRepost || shared.UserID != 2 {
+		t.Errorf("Expected user 2's repost, got %+v", shared)
+	}
+	if shared.Original == nil || shared.Original.UserName != "User3" || shared.Original.ID != original.PostID {
+		t.Errorf("Expected the original by User3 attached, got %+v", shared.Original)
+	}
+
+	// Deleting the original removes the repost but keeps the quote
+	if _, err := postService.DeletePost(original.PostID, 3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp, err = postService.GetTimeline(1, 1, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resp.Count != 1 || resp.Posts[0].ID != quote.PostID || resp.Posts[0].Original != nil {
+		t.Errorf("Expected only the detached quote, got %+v", resp.Posts)
+	}
+}
+
+func TestPostService_GetTimeline_RepostsAcrossPages(t *testing.T) {
+	postService, _, followService := setupPostServiceTest(t)
+	followService.Follow(1, 2)
+	followService.Follow(1, 3)
+
+	// Users 2 and 3 both repost user 1's post with other posts in between, so
+	// newest first the timeline is: repost by 3, b, a, repost by 2, older
+	older, _ := postService.CreatePost(2, models.CreatePostRequest{Content: "older"})
+	original, _ := postService.CreatePost(1, models.CreatePostRequest{Content: "원글"})
+	postService.Repost(original.PostID, 2)
+	a, _ := postService.CreatePost(3, models.CreatePostRequest{Content: "a"})
+	b, _ := postService.CreatePost(3, models.CreatePostRequest{Content: "b"})
+	latest, _ := postService.Repost(original.PostID, 3)
+
+	var pages [][]int
+	page := models.PageRequest{Limit: 2}
+	for {
+		resp, err := postService.GetTimeline(1, 1, page)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		ids := make([]int, len(resp.Posts))
+		for i, post := range resp.Posts {
+			ids[i] = post.ID
+		}
+		pages = append(pages, ids)
+		if resp.NextCursor == "" {
+			break
+		}
+		page.Cursor = resp.NextCursor
+	}
+
+	// The older repost is dropped on the second page too, and the page is
+	// filled from further down instead of coming back short
+	want := [][]int{{latest.PostID, b.PostID}, {a.PostID, older.PostID}}
+	if fmt.Sprint(pages) != fmt.Sprint(want) {
+		t.Errorf("Expected pages %v, got %v", want, pages)
+	}
+}
+
+func TestPostService_GetPost(t *testing.T) {
+	env := setupLikeServiceTest(t)
+	original, _ := env.postService.CreatePost(1, models.CreatePostRequest{Content: "원글"})
+	quote, _ := env.postService.QuotePost(original.PostID, 2, models.CreatePostRequest{Content: "인용"})
+	env.postService.CreateReply(quote.PostID, 3, models.CreatePostRequest{Content: "답글"})
+	env.likeService.Like(quote.PostID, 3)
+
+	post, err := env.postService.GetPost(quote.PostID, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if post.UserName != "User2" || post.Content != "인용" {
+		t.Errorf("Expected the quote by User2, got %+v", post)
+	}
+	if post.LikeCount != 1 || !post.LikedByMe || post.ReplyCount != 1 {
+		t.Errorf("Expected 1 like by the viewer and 1 reply, got %+v", post)
+	}
+	if post.Original == nil || post.Original.ID != original.PostID || post.Original.UserName != "User1" {
+		t.Errorf("Expected the original by User1 attached, got %+v", post.Original)
+	}
+
+	// Deleted posts are gone
+	env.postService.DeletePost(quote.PostID, 2)
+	if _, err := env.postService.GetPost(quote.PostID, 0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a deleted post, got %v", err)
+	}
+}
+
+func TestPostService_RestorePost(t *testing.T) {
+	postService, _, followService := setupPostServiceTest(t)
+	clock := &fakeClock{now: time.Now()}
+	postService.now = clock.Now
+
+	followService.Follow(3, 1)
+	post, _ := postService.CreatePost(1, models.CreatePostRequest{Content: "게시글"})
+	repost, _ := postService.Repost(post.PostID, 2)
+	if _, err := postService.DeletePost(post.PostID, 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		postID int
+		userID int
+		kind   error
+	}{
+		{name: "restore another user's post", postID: post.PostID, userID: 2, kind: ErrForbidden},
+		{name: "restore a live post", postID: 999, userID: 1, kind: ErrNotFound},
+		{name: "restore a repost", postID: repost.PostID, userID: 2, kind: ErrValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := postService.RestorePost(tt.postID, tt.userID); !errors.Is(err, tt.kind) {
+				t.Errorf("Expected error kind '%v', got '%v'", tt.kind, err)
+			}
+		})
+	}
+
+	resp, err := postService.RestorePost(post.PostID, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resp.Post.Content != "게시글" {
+		t.Errorf("Expected the restored post, got %+v", resp.Post)
+	}
+	if _, err := postService.GetPost(repost.PostID, 0); err != nil {
+		t.Errorf("Expected the repost restored with its original, got %v", err)
+	}
+	timeline, err := postService.GetTimeline(3, 3, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if timeline.Count != 1 || timeline.Posts[0].ID != post.PostID {
+		t.Errorf("Expected the restored post back in the timeline, got %+v", timeline.Posts)
+	}
+
+	// Posts deleted longer ago than the window stay deleted
+	postService.DeletePost(post.PostID, 1)
+	clock.Advance(PostRestoreWindow + time.Hour)
+	if _, err := postService.RestorePost(post.PostID, 1); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected ErrConflict after the restore window, got %v", err)
+	}
+}
+
+func TestPostService_GetPostHistory(t *testing.T) {
+	postService, _, _ := setupPostServiceTest(t)
+	post, _ := postService.CreatePost(1, models.CreatePostRequest{Content: "첫 번째"})
+
+	created, _ := postService.GetPost(post.PostID, 0)
+	if created.EditedAt != nil {
+		t.Errorf("Expected no edited_at before any edit, got %v", created.EditedAt)
+	}
+
+	for _, content := range []string{"두 번째", "세 번째"} {
+		if _, err := postService.UpdatePost(post.PostID, 1, models.UpdatePostRequest{Content: content}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	edited, _ := postService.GetPost(post.PostID, 0)
+	if edited.Content != "세 번째" || edited.EditedAt == nil {
+		t.Errorf("Expected the latest content marked as edited, got %+v", edited)
+	}
+
+	var contents []string
+	page := models.PageRequest{Limit: 1}
+	for {
+		resp, err := postService.GetPostHistory(post.PostID, page)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, revision := range resp.Revisions {
+			contents = append(contents, revision.Content)
+		}
+		if resp.NextCursor == "" {
+			break
+		}
+		page.Cursor = resp.NextCursor
+	}
+	if strings.Join(contents, ",") != "두 번째,첫 번째" {
+		t.Errorf("Expected earlier versions newest first, got %v", contents)
+	}
+
+	// The history of a deleted post is gone with it
+	postService.DeletePost(post.PostID, 1)
+	if _, err := postService.GetPostHistory(post.PostID, models.PageRequest{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestPostService_HashtagsAndMentions(t *testing.T) {
+	userRepo := repository.NewInMemoryUserRepository()
+	postRepo := repository.NewInMemoryPostRepository()
+	followRepo := repository.NewInMemoryFollowRepository()
+	timelineService := NewTimelineService(repository.NewInMemoryTimelineStore(), postRepo, userRepo, followRepo)
+	postService := NewPostService(postRepo, userRepo, followRepo, repository.NewInMemoryLikeRepository(), timelineService, newTestSearchService(postRepo, userRepo), newTestTrendService(postRepo), newTestNotificationService(postRepo, userRepo))
+
+	for i, name := range []string{"alice", "bob", "twin", "twin"} {
+		userRepo.Create(&models.User{Name: name, Email: fmt.Sprintf("user%d@test.com", i+1)})
+	}
+
+	// Mentions link to the user with exactly that name; shared names stay plain text
+	created, err := postService.CreatePost(1, models.CreatePostRequest{Content: "#Go 모임 @bob @twin @nobody #모임"})
+	if err != nil {
+		t.Fatalf("CreatePost failed: %v", err)
+	}
+	entities := created.Post.Entities
+	if len(entities.Hashtags) != 2 || entities.Hashtags[0].Tag != "go" || entities.Hashtags[1].Tag != "모임" {
+		t.Errorf("Expected hashtags go and 모임, got %+v", entities.Hashtags)
+	}
+	if len(entities.Mentions) != 1 || entities.Mentions[0].UserID != 2 || entities.Mentions[0].Start != 7 || entities.Mentions[0].End != 11 {
+		t.Errorf("Expected one mention of bob at [7, 11), got %+v", entities.Mentions)
+	}
+	if _, err := postService.CreatePost(2, models.CreatePostRequest{Content: "#go too"}); err != nil {
+		t.Fatalf("CreatePost failed: %v", err)
+	}
+
+	// Feeds accept the hashtag with or without #, in any case
+	feed, err := postService.GetHashtagPosts("#GO", 0, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("GetHashtagPosts failed: %v", err)
+	}
+	if feed.Tag != "go" || feed.Count != 2 || feed.Posts[0].ID != 2 || feed.Posts[1].ID != 1 {
+		t.Errorf("Expected posts [2 1] tagged go, got %+v", feed)
+	}
+	if len(feed.Posts[1].Entities.Mentions) != 1 {
+		t.Errorf("Expected feed posts to carry entities, got %+v", feed.Posts[1].Entities)
+	}
+
+	mentions, err := postService.GetMentions(2, 0, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("GetMentions failed: %v", err)
+	}
+	if mentions.Count != 1 || mentions.Posts[0].ID != 1 {
+		t.Errorf("Expected post 1 to mention bob, got %+v", mentions.Posts)
+	}
+
+	// Mentions keep pointing at the user after a rename
+	bob, _ := userRepo.GetByID(2)
+	bob.Name = "robert"
+	userRepo.Update(&bob)
+	post, err := postService.GetPost(1, 0)
+	if err != nil {
+		t.Fatalf("GetPost failed: %v", err)
+	}
+	if len(post.Entities.Mentions) != 1 || post.Entities.Mentions[0].UserID != 2 || post.Entities.Mentions[0].Name != "bob" {
+		t.Errorf("Expected the mention of bob to survive the rename, got %+v", post.Entities.Mentions)
+	}
+
+	// Edits replace hashtags and mentions
+	updated, err := postService.UpdatePost(1, 1, models.UpdatePostRequest{Content: "@alice #rust"})
+	if err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	if len(updated.Post.Entities.Mentions) != 1 || updated.Post.Entities.Mentions[0].UserID != 1 {
+		t.Errorf("Expected the edit to mention alice, got %+v", updated.Post.Entities.Mentions)
+	}
+	if feed, _ := postService.GetHashtagPosts("go", 0, models.PageRequest{}); feed.Count != 1 {
+		t.Errorf("Expected only post 2 tagged go after the edit, got %+v", feed.Posts)
+	}
+	if mentions, _ := postService.GetMentions(2, 0, models.PageRequest{}); mentions.Count != 0 {
+		t.Errorf("Expected no mentions of bob after the edit, got %+v", mentions.Posts)
+	}
+
+	// Deleted posts leave the feeds and come back with their entities when restored
+	if _, err := postService.DeletePost(1, 1); err != nil {
+		t.Fatalf("DeletePost failed: %v", err)
+	}
+	if feed, _ := postService.GetHashtagPosts("rust", 0, models.PageRequest{}); feed.Count != 0 {
+		t.Errorf("Expected no posts tagged rust after delete, got %+v", feed.Posts)
+	}
+	restored, err := postService.RestorePost(1, 1)
+	if err != nil {
+		t.Fatalf("RestorePost failed: %v", err)
+	}
+	if len(restored.Post.Entities.Hashtags) != 1 || len(restored.Post.Entities.Mentions) != 1 {
+		t.Errorf("Expected the restored post to carry its entities, got %+v", restored.Post.Entities)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		kind error
+	}{
+		{"invalid hashtag", func() error {
+			_, err := postService.GetHashtagPosts("1", 0, models.PageRequest{})
+			return err
+		}, ErrValidation},
+		{"hashtag with punctuation", func() error {
+			_, err := postService.GetHashtagPosts("go!", 0, models.PageRequest{})
+			return err
+		}, ErrValidation},
+		{"mentions of missing user", func() error {
+			_, err := postService.GetMentions(999, 0, models.PageRequest{})
+			return err
+		}, ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, tt.kind) {
+				t.Errorf("Expected %v error, got %v", tt.kind, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+// ProfileService handles reading and editing user profiles
+type ProfileService struct {
+	userRepo   repository.UserRepository
+	followRepo repository.FollowRepository
+	postRepo   repository.PostRepository
+	search     *SearchService
+}
+
+// NewProfileService creates a new profile service
+func NewProfileService(userRepo repository.UserRepository, followRepo repository.FollowRepository, postRepo repository.PostRepository, search *SearchService) *ProfileService {
+	return &ProfileService{
+		userRepo:   userRepo,
+		followRepo: followRepo,
+		postRepo:   postRepo,
+		search:     search,
+	}
+}
+
+// GetProfile returns a user's public profile
+func (s *ProfileService) GetProfile(userID int) (models.UserProfile, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return models.UserProfile{}, err
+	}
+	return s.buildProfile(user)
+}
+
+// GetMyProfile returns the authenticated user's own profile
+func (s *ProfileService) GetMyProfile(userID int) (models.MyProfile, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return models.MyProfile{}, err
+	}
+
+	profile, err := s.buildProfile(user)
+	if err != nil {
+		return models.MyProfile{}, err
+	}
+	return models.MyProfile{
+		UserProfile:   profile,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		UpdatedAt:     user.UpdatedAt,
+	}, nil
+}
+
+// UpdateProfile changes the authenticated user's name and/or profile text
+func (s *ProfileService) UpdateProfile(userID int, req models.UpdateProfileRequest) (models.MyProfile, error) {
+	if req.Name == nil && req.Profile == nil {
+		return models.MyProfile{}, NewError(ErrValidation, "name or profile is required")
+	}
+
+	if err := validateRequest(req); err != nil {
+		return models.MyProfile{}, err
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return models.MyProfile{}, err
+	}
+
+	if req.Name != nil {
+		user.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Profile != nil {
+		user.Profile = *req.Profile
+	}
+
+	if err := s.userRepo.Update(&user); err != nil {
+		return models.MyProfile{}, fmt.Errorf("failed to update user: %w", err)
+	}
+	s.search.UserChanged(user)
+
+	return s.GetMyProfile(userID)
+}
+
+// buildProfile attaches follower, following and post counts to a user
+func (s *ProfileService) buildProfile(user models.User) (models.UserProfile, error) {
+	followers, err := s.followRepo.CountFollowers([]int{user.ID})
+	if err != nil {
+		return models.UserProfile{}, fmt.Errorf("failed to count followers: %w", err)
+	}
+	following, err := s.followRepo.CountFollowing(user.ID)
+	if err != nil {
+		return models.UserProfile{}, fmt.Errorf("failed to count following: %w", err)
+	}
+	posts, err := s.postRepo.CountByUserID(user.ID)
+	if err != nil {
+		return models.UserProfile{}, fmt.Errorf("failed to count posts: %w", err)
+	}
+
+	return models.UserProfile{
+		ID:             user.ID,
+		Name:           user.Name,
+		Profile:        user.Profile,
+		CreatedAt:      user.CreatedAt,
+		FollowerCount:  followers[user.ID],
+		FollowingCount: following,
+		PostCount:      posts,
+	}, nil
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+func setupProfileServiceTest(t *testing.T) *ProfileService {
+	t.Helper()
+
+	userRepo := repository.NewInMemoryUserRepository()
+	followRepo := repository.NewInMemoryFollowRepository()
+	postRepo := repository.NewInMemoryPostRepository()
+
+	// Create test users
+	for i := 1; i <= 3; i++ {
+		userRepo.Create(&models.User{
+			Name:    "User" + string(rune('0'+i)),
+			Email:   "user" + string(rune('0'+i)) + "@test.com",
+			Profile: "profile",
+		})
+	}
+
+	// User 1 is followed by users 2 and 3, follows user 2 and has two posts
+	followRepo.Create(models.Follow{UserID: 2, FollowUserID: 1})
+	followRepo.Create(models.Follow{UserID: 3, FollowUserID: 1})
+	followRepo.Create(models.Follow{UserID: 1, FollowUserID: 2})
+	postRepo.Create(&models.Post{UserID: 1, Content: "first"})
+	postRepo.Create(&models.Post{UserID: 1, Content: "second"})
+
+	return NewProfileService(userRepo, followRepo, postRepo, newTestSearchService(postRepo, userRepo))
+}
+
+func TestProfileService_GetProfile(t *testing.T) {
+	profileService := setupProfileServiceTest(t)
+
+	profile, err := profileService.GetProfile(1)
+	if err != nil {
+		t.Fatalf("GetProfile failed: %v", err)
+	}
+	if profile.Name != "User1" || profile.FollowerCount != 2 || profile.FollowingCount != 1 || profile.PostCount != 2 {
+		t.Errorf("Unexpected profile: %+v", profile)
+	}
+
+	empty, err := profileService.GetProfile(3)
+	if err != nil {
+		t.Fatalf("GetProfile failed: %v", err)
+	}
+	if empty.FollowerCount != 0 || empty.FollowingCount != 1 || empty.PostCount != 0 {
+		t.Errorf("Unexpected profile: %+v", empty)
+	}
+
+	if _, err := profileService.GetProfile(999); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestProfileService_GetMyProfile(t *testing.T) {
+	profileService := setupProfileServiceTest(t)
+
+	me, err := profileService.GetMyProfile(1)
+	if err != nil {
+		t.Fatalf("GetMyProfile failed: %v", err)
+	}
+	if me.Email != "user1@test.com" || me.FollowerCount != 2 {
+		t.Errorf("Unexpected profile: %+v", me)
+	}
+}
+
+func TestProfileService_UpdateProfile(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name        string
+		request     models.UpdateProfileRequest
+		expectError bool
+		errorMsg    string
+		wantName    string
+		wantProfile string
+	}{
+		{
+			name:        "update name and profile",
+			request:     models.UpdateProfileRequest{Name: strPtr("  새 이름  "), Profile: strPtr("새 소개")},
+			wantName:    "새 이름",
+			wantProfile: "새 소개",
+		},
+		{
+			name:        "update profile only",
+			request:     models.UpdateProfileRequest{Profile: strPtr("")},
+			wantName:    "User1",
+			wantProfile: "",
+		},
+		{
+			name:        "name and profile at their limits",
+			request:     models.UpdateProfileRequest{Name: strPtr(strings.Repeat("가", 255)), Profile: strPtr(strings.Repeat("a", 2000))},
+			wantName:    strings.Repeat("가", 255),
+			wantProfile: strings.Repeat("a", 2000),
+		},
+		{
+			name:        "empty request",
+			request:     models.UpdateProfileRequest{},
+			expectError: true,
+			errorMsg:    "name or profile is required",
+		},
+		{
+			name:        "blank name",
+			request:     models.UpdateProfileRequest{Name: strPtr("   ")},
+			expectError: true,
+			errorMsg:    "name must not be blank",
+		},
+		{
+			name:        "name too long",
+			request:     models.UpdateProfileRequest{Name: strPtr(strings.Repeat("가", 256))},
+			expectError: true,
+			errorMsg:    "name must be at most 255 characters",
+		},
+		{
+			name:        "profile too long",
+			request:     models.UpdateProfileRequest{Profile: strPtr(strings.Repeat("a", 2001))},
+			expectError: true,
+			errorMsg:    "profile must be at most 2000 characters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profileService := setupProfileServiceTest(t)
+
+			resp, err := profileService.UpdateProfile(1, tt.request)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+					return
+				}
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("Expected ErrValidation, got %v", err)
+				}
+				if err.Error() != tt.errorMsg {
+					t.Errorf("Expected error message '%s', got '%s'", tt.errorMsg, err.Error())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if resp.Name != tt.wantName || resp.Profile != tt.wantProfile {
+				t.Errorf("Expected name %q and profile %q, got %q and %q", tt.wantName, tt.wantProfile, resp.Name, resp.Profile)
+			}
+			if resp.PostCount != 2 {
+				t.Errorf("Expected counts in response, got %+v", resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+// searchRebuildBatch is how many users or posts Rebuild loads at a time
+const searchRebuildBatch = 500
+
+// SearchService keeps the post and user search indexes in sync with writes
+// and answers search queries
+type SearchService struct {
+	postIndex repository.SearchIndex
+	userIndex repository.SearchIndex
+	postRepo  repository.PostRepository
+	userRepo  repository.UserRepository
+	likeRepo  repository.LikeRepository
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(postIndex, userIndex repository.SearchIndex, postRepo repository.PostRepository, userRepo repository.UserRepository, likeRepo repository.LikeRepository) *SearchService {
+	return &SearchService{
+		postIndex: postIndex,
+		userIndex: userIndex,
+		postRepo:  postRepo,
+		userRepo:  userRepo,
+		likeRepo:  likeRepo,
+	}
+}
+
+// PostChanged indexes a new or edited post. Reposts have no text of their own
+// and are not indexed.
+func (s *SearchService) PostChanged(post models.Post) {
+	if post.Kind == models.PostKindRepost {
+		return
+	}
+	doc := repository.SearchDocument{ID: post.ID, Text: post.Content, CreatedAt: post.CreatedAt}
+	if err := s.postIndex.Index(doc); err != nil {
+		slog.Warn("Search indexing failed", "post_id", post.ID, "error", err)
+	}
+}
+
+// PostRemoved removes a deleted post from the index
+func (s *SearchService) PostRemoved(post models.Post) {
+	if err := s.postIndex.Remove(post.ID); err != nil {
+		slog.Warn("Search removal failed", "post_id", post.ID, "error", err)
+	}
+}
+
+// UserChanged indexes a new or updated user by name and profile
+func (s *SearchService) UserChanged(user models.User) {
+	doc := repository.SearchDocument{ID: user.ID, Text: user.Name + "\n" + user.Profile, CreatedAt: user.CreatedAt}
+	if err := s.userIndex.Index(doc); err != nil {
+		slog.Warn("Search indexing failed", "user_id", user.ID, "error", err)
+	}
+}
+
+// Rebuild indexes every user and post. The indexes are kept in process, so
+// they start empty and are rebuilt from the database at startup.
+func (s *SearchService) Rebuild() error {
+	users, err := rebuildIndex(s.userRepo.List, s.UserChanged, func(u models.User) repository.Cursor {
+		return repository.Cursor{CreatedAt: u.CreatedAt, ID: u.ID}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to index users: %w", err)
+	}
+
+	posts, err := rebuildIndex(s.postRepo.List, s.PostChanged, postCursor)
+	if err != nil {
+		return fmt.Errorf("failed to index posts: %w", err)
+	}
+
+	slog.Info("Search index rebuilt", "users", users, "posts", posts)
+	return nil
+}
+
+// rebuildIndex pages through every item returned by list, passing each to
+// index, and returns how many there were
+func rebuildIndex[T any](list func(repository.PageQuery) ([]T, error), index func(T), key func(T) repository.Cursor) (int, error) {
+	query := repository.PageQuery{Limit: searchRebuildBatch}
+	total := 0
+	for {
+		items, err := list(query)
+		if err != nil {
+			return total, err
+		}
+		for _, item := range items {
+			index(item)
+		}
+		total += len(items)
+
+		if len(items) < searchRebuildBatch {
+			return total, nil
+		}
+		cursor := key(items[len(items)-1])
+		query.Cursor = &cursor
+	}
+}
+
+// SearchPosts retrieves a page of posts matching a query, best match first,
+// as seen by viewerID (0 for an anonymous viewer)
+func (s *SearchService) SearchPosts(text string, viewerID int, page models.PageRequest) (models.PostSearchResponse, error) {
+	// Validate query and pagination
+	query, limit, err := searchQuery(text, page)
+	if err != nil {
+		return models.PostSearchResponse{}, err
+	}
+
+	// Search
+	hits, err := s.postIndex.Search(query)
+	if err != nil {
+		return models.PostSearchResponse{}, fmt.Errorf("failed to search posts: %w", err)
+	}
+	hits, nextCursor := nextPage(hits, limit, hitCursor(query))
+
+	// Load the matching posts with their authors, in ranking order
+	ids := make([]int, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+	loaded, err := loadPostsWithUsers(s.postRepo, s.userRepo, ids)
+	if err != nil {
+		return models.PostSearchResponse{}, fmt.Errorf("failed to get posts: %w", err)
+	}
+	byID := make(map[int]models.PostWithUser, len(loaded))
+	for _, post := range loaded {
+		byID[post.ID] = post
+	}
+	posts := make([]models.PostWithUser, 0, len(ids))
+	for _, id := range ids {
+		if post, ok := byID[id]; ok {
+			posts = append(posts, post)
+		}
+	}
+
+	if err := attachEngagement(s.postRepo, s.userRepo, s.likeRepo, posts, viewerID); err != nil {
+		return models.PostSearchResponse{}, err
+	}
+
+	return models.PostSearchResponse{
+		Posts:      posts,
+		Count:      len(posts),
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// SearchUsers retrieves a page of users whose name or profile matches a query, best match first
+func (s *SearchService) SearchUsers(text string, page models.PageRequest) (models.UserSearchResponse, error) {
+	// Validate query and pagination
+	query, limit, err := searchQuery(text, page)
+	if err != nil {
+		return models.UserSearchResponse{}, err
+	}
+
+	// Search
+	hits, err := s.userIndex.Search(query)
+	if err != nil {
+		return models.UserSearchResponse{}, fmt.Errorf("failed to search users: %w", err)
+	}
+	hits, nextCursor := nextPage(hits, limit, hitCursor(query))
+
+	// Convert to public user info with a single batch lookup
+	ids := make([]int, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+	users, err := loadPublicUsers(s.userRepo, ids)
+	if err != nil {
+		return models.UserSearchResponse{}, err
+	}
+
+	return models.UserSearchResponse{
+		Users:      users,
+		Count:      len(users),
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// searchQuery validates a search and builds an index query that fetches one
+// extra hit so the caller can tell whether a next page exists
+func searchQuery(text string, page models.PageRequest) (repository.SearchQuery, int, error) {
+	if err := validateRequest(models.SearchRequest{Query: text}); err != nil {
+		return repository.SearchQuery{}, 0, err
+	}
+	if err := validateRequest(page); err != nil {
+		return repository.SearchQuery{}, 0, err
+	}
+
+	limit := page.Limit
+	if limit == 0 {
+		limit = DefaultPageLimit
+	}
+
+	// Later pages score recency from the time of the first
+	query := repository.SearchQuery{Text: text, Limit: limit + 1, Now: time.Now()}
+	if page.Cursor != "" {
+		cursor, err := repository.DecodeSearchCursor(page.Cursor)
+		if err != nil {
+			return repository.SearchQuery{}, 0, NewError(ErrValidation, "invalid cursor")
+		}
+		query.Cursor = &cursor
+		query.Now = cursor.Now
+	}
+
+	return query, limit, nil
+}
+
+// hitCursor returns a function giving the pagination cursor of a search hit
+// returned for query
+func hitCursor(query repository.SearchQuery) func(repository.SearchHit) repository.SearchCursor {
+	return func(hit repository.SearchHit) repository.SearchCursor {
+		return repository.SearchCursor{Score: hit.Score, ID: hit.ID, Now: query.Now}
+	}
+}
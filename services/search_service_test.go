@@ -0,0 +1,229 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+// newTestSearchService creates a search service over fresh in-memory indexes
+func newTestSearchService(postRepo repository.PostRepository, userRepo repository.UserRepository) *SearchService {
+	return NewSearchService(repository.NewInMemorySearchIndex(), repository.NewInMemorySearchIndex(), postRepo, userRepo, repository.NewInMemoryLikeRepository())
+}
+
+func setupSearchServiceTest(t *testing.T) (*SearchService, *PostService, *ProfileService) {
+	t.Helper()
+
+	userRepo := repository.NewInMemoryUserRepository()
+	followRepo := repository.NewInMemoryFollowRepository()
+	postRepo := repository.NewInMemoryPostRepository()
+	searchService := newTestSearchService(postRepo, userRepo)
+
+	timelineService := NewTimelineService(repository.NewInMemoryTimelineStore(), postRepo, userRepo, followRepo)
+	postService := NewPostService(postRepo, userRepo, followRepo, repository.NewInMemoryLikeRepository(), timelineService, searchService, newTestTrendService(postRepo), newTestNotificationService(postRepo, userRepo))
+	profileService := NewProfileService(userRepo, followRepo, postRepo, searchService)
+
+	// Create test users
+	for i := 1; i <= 3; i++ {
+		userRepo.Create(&models.User{
+			Name:  "User" + string(rune('0'+i)),
+			Email: "user" + string(rune('0'+i)) + "@test.com",
+		})
+	}
+
+	return searchService, postService, profileService
+}
+
+func searchPostIDs(t *testing.T, searchService *SearchService, query string) []int {
+	t.Helper()
+
+	resp, err := searchService.SearchPosts(query, 0, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("SearchPosts(%q) failed: %v", query, err)
+	}
+	ids := make([]int, len(resp.Posts))
+	for i, post := range resp.Posts {
+		ids[i] = post.ID
+	}
+	return ids
+}
+
+func TestSearchService_SearchPosts(t *testing.T) {
+	searchService, postService, _ := setupSearchServiceTest(t)
+
+	for _, content := range []string{"새 게시글을 올렸습니다", "오늘 날씨 맑음", "게시글 수정 기능"} {
+		if _, err := postService.CreatePost(1, models.CreatePostRequest{Content: content}); err != nil {
+			t.Fatalf("CreatePost failed: %v", err)
+		}
+	}
+	if _, err := postService.CreateReply(1, 2, models.CreatePostRequest{Content: "축하해요"}); err != nil {
+		t.Fatalf("CreateReply failed: %v", err)
+	}
+	// Reposts have no text of their own and must not show up twice
+	if _, err := postService.Repost(1, 2); err != nil {
+		t.Fatalf("Repost failed: %v", err)
+	}
+
+	resp, err := searchService.SearchPosts("게시글", 2, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("SearchPosts failed: %v", err)
+	}
+	if resp.Count != 2 || resp.Posts[0].ID != 3 || resp.Posts[1].ID != 1 {
+		t.Fatalf("Expected posts [3 1], got %+v", resp.Posts)
+	}
+	if resp.Posts[1].UserName != "User1" || resp.Posts[1].ReplyCount != 1 {
+		t.Errorf("Expected author and engagement on results, got %+v", resp.Posts[1])
+	}
+
+	// Edits are re-indexed
+	if _, err := postService.UpdatePost(2, 1, models.UpdatePostRequest{Content: "게시글 없이 날씨만"}); err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	if ids := searchPostIDs(t, searchService, "맑음"); len(ids) != 0 {
+		t.Errorf("Expected no match for replaced text, got %v", ids)
+	}
+
+	// Deleted posts disappear from results and come back when restored
+	if _, err := postService.DeletePost(3, 1); err != nil {
+		t.Fatalf("DeletePost failed: %v", err)
+	}
+	if ids := searchPostIDs(t, searchService, "게시글"); len(ids) != 2 || ids[0] != 2 || ids[1] != 1 {
+		t.Errorf("Expected posts [2 1] after delete, got %v", ids)
+	}
+	if _, err := postService.RestorePost(3, 1); err != nil {
+		t.Fatalf("RestorePost failed: %v", err)
+	}
+	if ids := searchPostIDs(t, searchService, "수정 기능"); len(ids) != 1 || ids[0] != 3 {
+		t.Errorf("Expected restored post 3, got %v", ids)
+	}
+}
+
+func TestSearchService_SearchPosts_Pagination(t *testing.T) {
+	searchService, postService, _ := setupSearchServiceTest(t)
+
+	for i := 0; i < 5; i++ {
+		if _, err := postService.CreatePost(1, models.CreatePostRequest{Content: "golang tips"}); err != nil {
+			t.Fatalf("CreatePost failed: %v", err)
+		}
+	}
+
+	var seen []int
+	cursor := ""
+	for page := 0; page < 3; page++ {
+		resp, err := searchService.SearchPosts("golang", 0, models.PageRequest{Limit: 2, Cursor: cursor})
+		if err != nil {
+			t.Fatalf("SearchPosts failed: %v", err)
+		}
+		for _, post := range resp.Posts {
+			seen = append(seen, post.ID)
+		}
+		cursor = resp.NextCursor
+		if cursor == "" {
+			break
+		}
+	}
+
+	if len(seen) != 5 || cursor != "" {
+		t.Fatalf("Expected all 5 posts over 3 pages, got %v (next cursor %q)", seen, cursor)
+	}
+	for i := 1; i < len(seen); i++ {
+		if seen[i] >= seen[i-1] {
+			t.Errorf("Expected newest equally relevant posts first, got %v", seen)
+			break
+		}
+	}
+}
+
+func TestSearchService_SearchUsers(t *testing.T) {
+	searchService, _, profileService := setupSearchServiceTest(t)
+	if err := searchService.Rebuild(); err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+
+	resp, err := searchService.SearchUsers("user2", models.PageRequest{})
+	if err != nil {
+		t.Fatalf("SearchUsers failed: %v", err)
+	}
+	if resp.Count != 1 || resp.Users[0].ID != 2 || resp.Users[0].Name != "User2" {
+		t.Fatalf("Expected User2, got %+v", resp.Users)
+	}
+
+	// Profile updates are re-indexed
+	name, profile := "김철수", "백엔드 개발자입니다"
+	if _, err := profileService.UpdateProfile(3, models.UpdateProfileRequest{Name: &name, Profile: &profile}); err != nil {
+		t.Fatalf("UpdateProfile failed: %v", err)
+	}
+	resp, _ = searchService.SearchUsers("개발자", models.PageRequest{})
+	if resp.Count != 1 || resp.Users[0].ID != 3 {
+		t.Errorf("Expected user 3 by profile, got %+v", resp.Users)
+	}
+	if resp, _ = searchService.SearchUsers("user3", models.PageRequest{}); resp.Count != 0 {
+		t.Errorf("Expected old name to no longer match, got %+v", resp.Users)
+	}
+}
+
+func TestSearchService_InvalidRequest(t *testing.T) {
+	searchService, _, _ := setupSearchServiceTest(t)
+
+	tests := []struct {
+		name  string
+		query string
+		page  models.PageRequest
+		field string
+	}{
+		{"empty query", "", models.PageRequest{}, "q"},
+		{"blank query", "   ", models.PageRequest{}, "q"},
+		{"limit too large", "go", models.PageRequest{Limit: MaxPageLimit + 1}, "limit"},
+		{"invalid cursor", "go", models.PageRequest{Cursor: "nope"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := searchService.SearchPosts(tt.query, 0, tt.page)
+
+			var svcErr *Error
+			if !errors.As(err, &svcErr) || svcErr.Kind != ErrValidation {
+				t.Fatalf("Expected validation error, got %v", err)
+			}
+			if tt.field != "" {
+				if _, ok := svcErr.Fields[tt.field]; !ok {
+					t.Errorf("Expected field %q in %v", tt.field, svcErr.Fields)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchService_Rebuild(t *testing.T) {
+	userRepo := repository.NewInMemoryUserRepository()
+	postRepo := repository.NewInMemoryPostRepository()
+
+	userRepo.Create(&models.User{Name: "Writer", Email: "writer@test.com"})
+	for i := 0; i < searchRebuildBatch+1; i++ {
+		postRepo.Create(&models.Post{UserID: 1, Content: "batch post"})
+	}
+	original := models.Post{UserID: 1, Content: "repost me"}
+	postRepo.Create(&original)
+	postRepo.Create(&models.Post{UserID: 1, Kind: models.PostKindRepost, OriginalPostID: &original.ID})
+
+	searchService := newTestSearchService(postRepo, userRepo)
+	if err := searchService.Rebuild(); err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+
+	resp, err := searchService.SearchPosts("batch", 0, models.PageRequest{Limit: MaxPageLimit})
+	if err != nil {
+		t.Fatalf("SearchPosts failed: %v", err)
+	}
+	if resp.Count != MaxPageLimit || resp.NextCursor == "" {
+		t.Errorf("Expected a full page with more to come, got %d posts", resp.Count)
+	}
+	if ids := searchPostIDs(t, searchService, "repost"); len(ids) != 1 || ids[0] != original.ID {
+		t.Errorf("Expected only the original post, got %v", ids)
+	}
+	if resp, _ := searchService.SearchUsers("writer", models.PageRequest{}); resp.Count != 1 {
+		t.Errorf("Expected the user to be indexed, got %+v", resp.Users)
+	}
+}
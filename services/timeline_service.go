@@ -0,0 +1,319 @@
+package services
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+const (
+	// FanoutFollowerThreshold is the follower count above which an author's posts
+	// are no longer pushed to followers' timelines but pulled at read time
+	FanoutFollowerThreshold = 10000
+	// TimelineCacheSize is the number of entries kept per materialized timeline
+	TimelineCacheSize = 800
+)
+
+// TimelineService maintains precomputed home timelines (fan-out-on-write)
+type TimelineService struct {
+	store      repository.TimelineStore
+	postRepo   repository.PostRepository
+	userRepo   repository.UserRepository
+	followRepo repository.FollowRepository
+
+	fanoutThreshold int
+	cacheSize       int
+}
+
+// NewTimelineService creates a new timeline service
+func NewTimelineService(store repository.TimelineStore, postRepo repository.PostRepository, userRepo repository.UserRepository, followRepo repository.FollowRepository) *TimelineService {
+	return &TimelineService{
+		store:           store,
+		postRepo:        postRepo,
+		userRepo:        userRepo,
+		followRepo:      followRepo,
+		fanoutThreshold: FanoutFollowerThreshold,
+		cacheSize:       TimelineCacheSize,
+	}
+}
+
+// PostCreated pushes a new post into the timelines of the author's followers.
+// Posts by high-fanout authors are skipped and pulled at read time instead.
+func (s *TimelineService) PostCreated(post models.Post) {
+	highFanout, err := s.isHighFanout(post.UserID)
+	if err != nil {
+		slog.Warn("Timeline fan-out skipped", "post_id", post.ID, "error", err)
+		return
+	}
+	if highFanout {
+		return
+	}
+
+	followers, err := s.followRepo.GetFollowers(post.UserID, repository.PageQuery{})
+	if err != nil {
+		slog.Warn("Timeline fan-out skipped", "post_id", post.ID, "error", err)
+		return
+	}
+
+	entry := timelineEntry(post)
+	for _, follow := range followers {
+		if err := s.store.Add(follow.UserID, entry); err != nil {
+			slog.Warn("Timeline fan-out failed", "post_id", post.ID, "user_id", follow.UserID, "error", err)
+			continue
+		}
+		if err := s.store.Trim(follow.UserID, s.cacheSize); err != nil {
+			slog.Warn("Timeline trim failed", "user_id", follow.UserID, "error", err)
+		}
+	}
+}
+
+// PostDeleted removes a post from the timelines of the author's followers
+func (s *TimelineService) PostDeleted(post models.Post) {
+	followers, err := s.followRepo.GetFollowers(post.UserID, repository.PageQuery{})
+	if err != nil {
+		slog.Warn("Timeline removal skipped", "post_id", post.ID, "error", err)
+		return
+	}
+
+	for _, follow := range followers {
+		if err := s.store.Remove(follow.UserID, post.ID); err != nil {
+			slog.Warn("Timeline removal failed", "post_id", post.ID, "user_id", follow.UserID, "error", err)
+		}
+	}
+}
+
+// Followed backfills the follower's timeline with the followed user's recent posts
+func (s *TimelineService) Followed(followerID, followingID int) {
+	if exists, err := s.store.Exists(followerID); err != nil || !exists {
+		return // built from scratch on first read
+	}
+
+	highFanout, err := s.isHighFanout(followingID)
+	if err != nil || highFanout {
+		return
+	}
+
+	posts, err := s.postRepo.GetByUserID(followingID, repository.PageQuery{Limit: s.cacheSize})
+	if err != nil {
+		slog.Warn("Timeline backfill failed", "user_id", followerID, "following_id", followingID, "error", err)
+		return
+	}
+
+	entries := make([]repository.TimelineEntry, len(posts))
+	for i, post := range posts {
+		entries[i] = timelineEntry(post)
+	}
+	if err := s.store.Add(followerID, entries...); err != nil {
+		slog.Warn("Timeline backfill failed", "user_id", followerID, "following_id", followingID, "error", err)
+		return
+	}
+	if err := s.store.Trim(followerID, s.cacheSize); err != nil {
+		slog.Warn("Timeline trim failed", "user_id", followerID, "error", err)
+	}
+}
+
+// Unfollowed removes the unfollowed user's posts from the follower's timeline
+func (s *TimelineService) Unfollowed(followerID, followingID int) {
+	if err := s.store.RemoveAuthor(followerID, followingID); err != nil {
+		slog.Warn("Timeline trim failed", "user_id", followerID, "following_id", followingID, "error", err)
+	}
+}
+
+// Page returns a page of userID's home timeline built from the users they follow.
+// Posts by regular authors come from the materialized timeline; posts by
+// high-fanout authors are fetched at read time and merged in.
+func (s *TimelineService) Page(userID int, followingIDs []int, query repository.PageQuery) ([]models.PostWithUser, error) {
+	// Split followed users into pushed and pulled authors
+	counts, err := s.followRepo.CountFollowers(followingIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count followers: %w", err)
+	}
+	pushIDs := make([]int, 0, len(followingIDs))
+	pullIDs := make([]int, 0)
+	for _, id := range followingIDs {
+		if counts[id] > s.fanoutThreshold {
+			pullIDs = append(pullIDs, id)
+		} else {
+			pushIDs = append(pushIDs, id)
+		}
+	}
+
+	// Materialize the timeline on first read
+	exists, err := s.store.Exists(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read timeline: %w", err)
+	}
+	if !exists {
+		if err := s.rebuild(userID, pushIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := s.store.Range(userID, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read timeline: %w", err)
+	}
+
+	// Older entries may have been trimmed; serve the rest of the feed from the database
+	if query.Limit > 0 && len(entries) < query.Limit {
+		size, err := s.store.Len(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read timeline: %w", err)
+		}
+		if size >= s.cacheSize {
+			return s.postsByAuthors(followingIDs, query)
+		}
+	}
+
+	postIDs := make([]int, len(entries))
+	for i, entry := range entries {
+		postIDs[i] = entry.PostID
+	}
+	posts, err := s.postsByIDs(postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get posts: %w", err)
+	}
+
+	if len(pullIDs) > 0 {
+		pulled, err := s.postsByAuthors(pullIDs, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get posts: %w", err)
+		}
+		posts = append(posts, pulled...)
+	}
+
+	return mergePosts(posts, query.Limit), nil
+}
+
+// postsByIDs loads posts with their authors
+func (s *TimelineService) postsByIDs(postIDs []int) ([]models.PostWithUser, error) {
+	return loadPostsWithUsers(s.postRepo, s.userRepo, postIDs)
+}
+
+// postsByAuthors loads a page of posts by the given authors with author information
+func (s *TimelineService) postsByAuthors(authorIDs []int, query repository.PageQuery) ([]models.PostWithUser, error) {
+	if reader, ok := s.postRepo.(repository.PostWithUserReader); ok {
+		return reader.GetWithUsersByUserIDs(authorIDs, query)
+	}
+
+	posts, err := s.postRepo.GetByUserIDs(authorIDs, query)
+	if err != nil {
+		return nil, err
+	}
+	return attachUsers(s.userRepo, posts)
+}
+
+// rebuild materializes a user's timeline from the database
+func (s *TimelineService) rebuild(userID int, authorIDs []int) error {
+	entries := make([]repository.TimelineEntry, 0)
+	if len(authorIDs) > 0 {
+		posts, err := s.postRepo.GetByUserIDs(authorIDs, repository.PageQuery{Limit: s.cacheSize})
+		if err != nil {
+			return fmt.Errorf("failed to get posts: %w", err)
+		}
+		for _, post := range posts {
+			entries = append(entries, timelineEntry(post))
+		}
+	}
+
+	if err := s.store.Replace(userID, entries); err != nil {
+		return fmt.Errorf("failed to build timeline: %w", err)
+	}
+	return nil
+}
+
+// isHighFanout reports whether an author has too many followers for fan-out-on-write
+func (s *TimelineService) isHighFanout(authorID int) (bool, error) {
+	counts, err := s.followRepo.CountFollowers([]int{authorID})
+	if err != nil {
+		return false, err
+	}
+	return counts[authorID] > s.fanoutThreshold, nil
+}
+
+// timelineEntry converts a post to a timeline entry. The post must come from
+// the repository, whose Create cuts CreatedAt to the stored precision, so the
+// entry orders and pages exactly like the database.
+func timelineEntry(post models.Post) repository.TimelineEntry {
+	return repository.TimelineEntry{
+		PostID:    post.ID,
+		AuthorID:  post.UserID,
+		CreatedAt: post.CreatedAt,
+	}
+}
+
+// loadPostsWithUsers loads posts with their authors, joining in the repository
+// when supported. The result is not ordered.
+func loadPostsWithUsers(postRepo repository.PostRepository, userRepo repository.UserRepository, postIDs []int) ([]models.PostWithUser, error) {
+	if reader, ok := postRepo.(repository.PostWithUserReader); ok {
+		return reader.GetWithUsersByIDs(postIDs)
+	}
+
+	posts, err := postRepo.GetByIDs(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	return attachUsers(userRepo, posts)
+}
+
+// attachUsers converts posts to PostWithUser with a single batch user lookup.
+// Posts whose author no longer exists are skipped.
+func attachUsers(userRepo repository.UserRepository, posts []models.Post) ([]models.PostWithUser, error) {
+	authorIDs := make([]int, 0, len(posts))
+	for _, post := range posts {
+		authorIDs = append(authorIDs, post.UserID)
+	}
+
+	users, err := userRepo.GetByIDs(authorIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	postsWithUser := make([]models.PostWithUser, 0, len(posts))
+	for _, post := range posts {
+		user, exists := users[post.UserID]
+		if !exists {
+			continue
+		}
+		postsWithUser = append(postsWithUser, models.PostWithUser{
+			ID:              post.ID,
+			UserID:          post.UserID,
+			UserName:        user.Name,
+			Kind:            post.Kind,
+			Content:         post.Content,
+			InReplyToPostID: post.InReplyToPostID,
+			OriginalPostID:  post.OriginalPostID,
+			CreatedAt:       post.CreatedAt,
+			EditedAt:        post.EditedAt,
+		})
+	}
+	return postsWithUser, nil
+}
+
+// mergePosts de-duplicates posts, orders them by (created_at, id) descending
+// and keeps at most limit of them (0 means no limit)
+func mergePosts(posts []models.PostWithUser, limit int) []models.PostWithUser {
+	seen := make(map[int]bool, len(posts))
+	merged := make([]models.PostWithUser, 0, len(posts))
+	for _, post := range posts {
+		if !seen[post.ID] {
+			seen[post.ID] = true
+			merged = append(merged, post)
+		}
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].CreatedAt.Equal(merged[j].CreatedAt) {
+			return merged[i].ID > merged[j].ID
+		}
+		return merged[i].CreatedAt.After(merged[j].CreatedAt)
+	})
+
+	if limit > 0 && len(merged) > limit {
+		merged = merged[:limit]
+	}
+	return merged
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"testing"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+type timelineTestEnv struct {
+	postService     *PostService
+	followService   *FollowService
+	timelineService *TimelineService
+	store           *repository.InMemoryTimelineStore
+}
+
+func setupTimelineServiceTest(_ *testing.T) *timelineTestEnv {
+	userRepo := repository.NewInMemoryUserRepository()
+	followRepo := repository.NewInMemoryFollowRepository()
+	postRepo := repository.NewInMemoryPostRepository()
+	store := repository.NewInMemoryTimelineStore()
+
+	timelineService := NewTimelineService(store, postRepo, userRepo, followRepo)
+
+	// Create test users
+	for i := 1; i <= 3; i++ {
+		userRepo.Create(&models.User{
+			Name:  "User" + string(rune('0'+i)),
+			Email: "user" + string(rune('0'+i)) + "@test.com",
+		})
+	}
+
+	return &timelineTestEnv{
+		postService:     NewPostService(postRepo, userRepo, followRepo, repository.NewInMemoryLikeRepository(), timelineService, newTestSearchService(postRepo, userRepo), newTestTrendService(postRepo), newTestNotificationService(postRepo, userRepo)),
+		followService:   NewFollowService(followRepo, userRepo, timelineService, newTestNotificationService(postRepo, userRepo)),
+		timelineService: timelineService,
+		store:           store,
+	}
+}
+
+func timelinePostIDs(t *testing.T, postService *PostService, userID int) []int {
+	t.Helper()
+	resp, err := postService.GetTimeline(userID, 0, models.PageRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ids := make([]int, len(resp.Posts))
+	for i, post := range resp.Posts {
+		ids[i] = post.ID
+	}
+	return ids
+}
+
+func assertPostIDs(t *testing.T, got, expected []int) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Fatalf("Expected post IDs %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("Expected post IDs %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestTimelineService_FanoutOnWrite(t *testing.T) {
+	env := setupTimelineServiceTest(t)
+
+	env.followService.Follow(1, 2)
+
+	// First read materializes User 1's timeline
+	assertPostIDs(t, timelinePostIDs(t, env.postService, 1), []int{})
+	if exists, _ := env.store.Exists(1); !exists {
+		t.Fatal("Expected timeline to be materialized after first read")
+	}
+
+	// New posts are pushed into the materialized timeline
+	env.postService.CreatePost(2, models.CreatePostRequest{Content: "첫 게시글"})
+	env.postService.CreatePost(2, models.CreatePostRequest{Content: "두 번째 게시글"})
+	if n, _ := env.store.Len(1); n != 2 {
+		t.Errorf("Expected 2 cached entries, got %d", n)
+	}
+	assertPostIDs(t, timelinePostIDs(t, env.postService, 1), []int{2, 1})
+
+	// Deleted posts are removed
+	if _, err := env.postService.DeletePost(1, 2); err != nil {
+		t.Fatalf("Failed to delete post: %v", err)
+	}
+	if n, _ := env.store.Len(1); n != 1 {
+		t.Errorf("Expected 1 cached entry, got %d", n)
+	}
+	assertPostIDs(t, timelinePostIDs(t, env.postService, 1), []int{2})
+}
+
+func TestTimelineService_FollowBackfillAndUnfollowTrim(t *testing.T) {
+	env := setupTimelineServiceTest(t)
+
+	env.postService.CreatePost(2, models.CreatePostRequest{Content: "User 2의 게시글"})
+	env.postService.CreatePost(3, models.CreatePostRequest{Content: "User 3의 게시글"})
+
+	env.followService.Follow(1, 2)
+	assertPostIDs(t, timelinePostIDs(t, env.postService, 1), []int{1})
+
+	// Following backfills existing posts
+	env.followService.Follow(1, 3)
+	assertPostIDs(t, timelinePostIDs(t, env.postService, 1), []int{2, 1})
+
+	// Unfollowing trims them again
+	env.followService.Unfollow(1, 2)
+	assertPostIDs(t, timelinePostIDs(t, env.postService, 1), []int{2})
+	if n, _ := env.store.Len(1); n != 1 {
+		t.Errorf("Expected 1 cached entry, got %d", n)
+	}
+}
+
+func TestTimelineService_HighFanoutAuthorsArePulled(t *testing.T) {
+	env := setupTimelineServiceTest(t)
+	env.timelineService.fanoutThreshold = 1
+
+	// User 2 has two followers and exceeds the threshold; User 3 has one
+	env.followService.Follow(1, 2)
+	env.followService.Follow(3, 2)
+	env.followService.Follow(1, 3)
+	assertPostIDs(t, timelinePostIDs(t, env.postService, 1), []int{})
+
+	env.postService.CreatePost(2, models.CreatePostRequest{Content: "인기 사용자의 게시글"})
+	env.postService.CreatePost(3, models.CreatePostRequest{Content: "일반 사용자의 게시글"})
+
+	// Only the regular author's post was pushed
+	if n, _ := env.store.Len(1); n != 1 {
+		t.Errorf("Expected 1 cached entry, got %d", n)
+	}
+
+	// Both show up, merged in order
+	assertPostIDs(t, timelinePostIDs(t, env.postService, 1), []int{2, 1})
+}
+
+func TestTimelineService_TrimmedCacheFallsBackToDatabase(t *testing.T) {
+	env := setupTimelineServiceTest(t)
+	env.timelineService.cacheSize = 2
+
+	env.followService.Follow(1, 2)
+	timelinePostIDs(t, env.postService, 1)
+
+	for i := 0; i < 4; i++ {
+		env.postService.CreatePost(2, models.CreatePostRequest{Content: "게시글"})
+	}
+	if n, _ := env.store.Len(1); n != 2 {
+		t.Errorf("Expected cache trimmed to 2 entries, got %d", n)
+	}
+
+	// Paging past the cached entries still returns every post
+	var postIDs []int
+	page := models.PageRequest{Limit: 3}
+	for {
+		resp, err := env.postService.GetTimeline(1, 0, page)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, post := range resp.Posts {
+			postIDs = append(postIDs, post.ID)
+		}
+		if resp.NextCursor == "" {
+			break
+		}
+		page.Cursor = resp.NextCursor
+	}
+	assertPostIDs(t, postIDs, []int{4, 3, 2, 1})
+}
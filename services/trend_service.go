@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+// Trend settings. A hashtag trends when its uses in the last TrendWindow are
+// well above its rate over the rest of TrendBaselineWindow.
+const (
+	TrendWindow          = time.Hour
+	TrendBaselineWindow  = 24 * time.Hour
+	TrendRefreshInterval = time.Minute
+	MinTrendUses         = 3  // fewest uses in TrendWindow for a hashtag to trend
+	MaxTrends            = 10 // most trends returned
+)
+
+// trendLoadBatch is how many posts Load reads at a time
+const trendLoadBatch = 500
+
+// TrendService counts hashtag uses as posts are created, edited, deleted and
+// restored, and periodically ranks the hashtags whose use is rising fastest.
+// A post's hashtags count at the time it was created.
+type TrendService struct {
+	store    repository.TrendStore
+	postRepo repository.PostRepository
+	now      func() time.Time
+
+	mu     sync.RWMutex
+	trends models.TrendsResponse // latest ranking, replaced by Refresh
+}
+
+// NewTrendService creates a new trend service
+func NewTrendService(store repository.TrendStore, postRepo repository.PostRepository) *TrendService {
+	return &TrendService{
+		store:    store,
+		postRepo: postRepo,
+		now:      time.Now,
+		trends:   models.TrendsResponse{Trends: []models.Trend{}},
+	}
+}
+
+// PostCreated counts the hashtags of a new or restored post, once each
+func (s *TrendService) PostCreated(post models.Post) {
+	s.count(post.ID, distinctTags(post.Entities.Hashtags), post.CreatedAt)
+}
+
+// PostUpdated recounts an edited post: the hashtags the edit dropped from
+// previous are taken back and the ones it added are counted
+func (s *TrendService) PostUpdated(previous, post models.Post) {
+	before := distinctTags(parseEntities(previous.Content, nil).Hashtags)
+	after := distinctTags(post.Entities.Hashtags)
+
+	removed := slices.DeleteFunc(slices.Clone(before), func(tag string) bool { return slices.Contains(after, tag) })
+	added := slices.DeleteFunc(slices.Clone(after), func(tag string) bool { return slices.Contains(before, tag) })
+	s.uncount(post.ID, removed, post.CreatedAt)
+	s.count(post.ID, added, post.CreatedAt)
+}
+
+// PostDeleted takes back the hashtags of a deleted post
+func (s *TrendService) PostDeleted(post models.Post) {
+	s.uncount(post.ID, distinctTags(parseEntities(post.Content, nil).Hashtags), post.CreatedAt)
+}
+
+// count adds one use of each hashtag at a time
+func (s *TrendService) count(postID int, tags []string, at time.Time) {
+	if len(tags) == 0 {
+		return
+	}
+	if err := s.store.Add(tags, at); err != nil {
+		slog.Warn("Trend counting failed", "post_id", postID, "error", err)
+	}
+}
+
+// uncount takes back one use of each hashtag at a time
+func (s *TrendService) uncount(postID int, tags []string, at time.Time) {
+	if len(tags) == 0 {
+		return
+	}
+	if err := s.store.Remove(tags, at); err != nil {
+		slog.Warn("Trend uncounting failed", "post_id", postID, "error", err)
+	}
+}
+
+// distinctTags lists the distinct tags of hashtags, in order
+func distinctTags(hashtags []models.HashtagEntity) []string {
+	tags := make([]string, 0, len(hashtags))
+	for _, hashtag := range hashtags {
+		if !slices.Contains(tags, hashtag.Tag) {
+			tags = append(tags, hashtag.Tag)
+		}
+	}
+	return tags
+}
+
+// Load counts the hashtags of the posts created within TrendBaselineWindow
+// and computes the first ranking. Counts are kept in process, so they start
+// empty and are reloaded from the database at startup.
+func (s *TrendService) Load() error {
+	since := s.now().Add(-TrendBaselineWindow)
+	query := repository.PageQuery{Limit: trendLoadBatch}
+	total := 0
+	for {
+		posts, err := s.postRepo.List(query)
+		if err != nil {
+			return fmt.Errorf("failed to list posts: %w", err)
+		}
+		done := len(posts) < trendLoadBatch
+		for _, post := range posts {
+			if post.CreatedAt.Before(since) {
+				done = true // Older posts follow; they are outside every window
+				break
+			}
+			s.count(post.ID, distinctTags(parseEntities(post.Content, nil).Hashtags), post.CreatedAt)
+			total++
+		}
+
+		if done {
+			break
+		}
+		cursor := postCursor(posts[len(posts)-1])
+		query.Cursor = &cursor
+	}
+
+	slog.Info("Trend counts loaded", "posts", total)
+	return s.Refresh()
+}
+
+// Refresh ranks the hashtags by their counts as of now and forgets counts
+// older than TrendBaselineWindow
+func (s *TrendService) Refresh() error {
+	now := s.now()
+	daySince := now.Add(-TrendBaselineWindow + repository.TrendBucket)
+	day, err := s.store.Counts(daySince, now)
+	if err != nil {
+		return fmt.Errorf("failed to count hashtags: %w", err)
+	}
+	hour, err := s.store.Counts(now.Add(-TrendWindow+repository.TrendBucket), now)
+	if err != nil {
+		return fmt.Errorf("failed to count hashtags: %w", err)
+	}
+
+	trends := rankTrends(hour, day)
+	s.mu.Lock()
+	s.trends = models.TrendsResponse{Trends: trends, Count: len(trends), ComputedAt: now}
+	s.mu.Unlock()
+
+	if err := s.store.Prune(daySince); err != nil {
+		return fmt.Errorf("failed to prune hashtag counts: %w", err)
+	}
+	return nil
+}
+
+// rankTrends scores every hashtag used at least MinTrendUses times in the
+// last TrendWindow against its rate over the rest of TrendBaselineWindow and
+// returns the MaxTrends highest. The score is the excess over the expected
+// uses in standard deviations (treating uses as a Poisson process), so a
+// hashtag used steadily all day does not trend while a new one soon does.
+func rankTrends(hour, day map[string]int) []models.Trend {
+	baselineScale := float64(TrendWindow) / float64(TrendBaselineWindow-TrendWindow)
+
+	trends := make([]models.Trend, 0)
+	for tag, recent := range hour {
+		if recent < MinTrendUses {
+			continue
+		}
+		expected := float64(day[tag]-recent) * baselineScale
+		score := (float64(recent) - expected) / math.Sqrt(expected+1)
+		if score <= 0 {
+			continue
+		}
+		trends = append(trends, models.Trend{
+			Tag:       tag,
+			HourCount: recent,
+			DayCount:  day[tag],
+			Score:     math.Round(score*100) / 100,
+		})
+	}
+
+	sort.Slice(trends, func(i, j int) bool {
+		if trends[i].Score != trends[j].Score {
+			return trends[i].Score > trends[j].Score
+		}
+		if trends[i].HourCount != trends[j].HourCount {
+			return trends[i].HourCount > trends[j].HourCount
+		}
+		return trends[i].Tag < trends[j].Tag
+	})
+	if len(trends) > MaxTrends {
+		trends = trends[:MaxTrends]
+	}
+	return trends
+}
+
+// Run refreshes the trends every TrendRefreshInterval until ctx is cancelled
+func (s *TrendService) Run(ctx context.Context) {
+	ticker := time.NewTicker(TrendRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Trend refresher stopped")
+			return
+		case <-ticker.C:
+			if err := s.Refresh(); err != nil {
+				slog.Warn("Trend refresh failed", "error", err)
+			}
+		}
+	}
+}
+
+// GetTrends returns the latest ranking of trending hashtags
+func (s *TrendService) GetTrends() models.TrendsResponse {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	trends := s.trends
+	trends.Trends = slices.Clone(trends.Trends)
+	return trends
+}
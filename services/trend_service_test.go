@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+// newTestTrendService creates a trend service over a fresh in-memory store
+func newTestTrendService(postRepo repository.PostRepository) *TrendService {
+	return NewTrendService(repository.NewInMemoryTrendStore(), postRepo)
+}
+
+// taggedPost returns a post created at a time whose entities hold the given hashtags
+func taggedPost(at time.Time, tags ...string) models.Post {
+	post := models.Post{CreatedAt: at}
+	for _, tag := range tags {
+		post.Entities.Hashtags = append(post.Entities.Hashtags, models.HashtagEntity{Tag: tag})
+	}
+	return post
+}
+
+func trendTags(resp models.TrendsResponse) []string {
+	tags := make([]string, len(resp.Trends))
+	for i, trend := range resp.Trends {
+		tags[i] = trend.Tag
+	}
+	return tags
+}
+
+func TestTrendService_Refresh(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)}
+	trendService := newTestTrendService(repository.NewInMemoryPostRepository())
+	trendService.now = clock.Now
+
+	// "daily" is used three times an hour all day, including the last hour
+	for h := 23; h >= 0; h-- {
+		at := clock.now.Add(-time.Duration(h)*time.Hour - 30*time.Minute)
+		for i := 0; i < 3; i++ {
+			trendService.PostCreated(taggedPost(at, "daily"))
+		}
+	}
+	// "new" appears five times in the last hour; "rising" four times after a quiet day;
+	// "rare" only twice; "stale" was busy two days ago
+	for i := 0; i < 5; i++ {
+		trendService.PostCreated(taggedPost(clock.now.Add(-time.Duration(i)*time.Minute), "new", "new"))
+	}
+	trendService.PostCreated(taggedPost(clock.now.Add(-10*time.Hour), "rising"))
+	for i := 0; i < 4; i++ {
+		trendService.PostCreated(taggedPost(clock.now.Add(-time.Duration(i)*10*time.Minute), "rising"))
+	}
+	trendService.PostCreated(taggedPost(clock.now, "rare"))
+	trendService.PostCreated(taggedPost(clock.now, "rare"))
+	for i := 0; i < 10; i++ {
+		trendService.PostCreated(taggedPost(clock.now.Add(-48*time.Hour), "stale"))
+	}
+
+	if resp := trendService.GetTrends(); resp.Count != 0 || resp.Trends == nil {
+		t.Errorf("Expected no trends before the first refresh, got %+v", resp)
+	}
+	if err := trendService.Refresh(); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	resp := trendService.GetTrends()
+	if got := trendTags(resp); len(got) != 2 || got[0] != "new" || got[1] != "rising" {
+		t.Fatalf("Expected trends [new rising], got %+v", resp.Trends)
+	}
+	// Each post counts a hashtag once
+	if trend := resp.Trends[0]; trend.HourCount != 5 || trend.DayCount != 5 || trend.Score <= resp.Trends[1].Score {
+		t.Errorf("Unexpected trend for new: %+v", trend)
+	}
+	if trend := resp.Trends[1]; trend.HourCount != 4 || trend.DayCount != 5 {
+		t.Errorf("Unexpected trend for rising: %+v", trend)
+	}
+	if !resp.ComputedAt.Equal(clock.now) {
+		t.Errorf("Expected computed_at %v, got %v", clock.now, resp.ComputedAt)
+	}
+
+	// An hour later the burst is over
+	clock.Advance(TrendWindow)
+	if err := trendService.Refresh(); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	if resp := trendService.GetTrends(); resp.Count != 0 {
+		t.Errorf("Expected no trends after the burst, got %+v", resp.Trends)
+	}
+}
+
+func TestTrendService_MaxTrends(t *testing.T) {
+	trendService := newTestTrendService(repository.NewInMemoryPostRepository())
+	now := trendService.now()
+
+	for i := 0; i < MaxTrends+5; i++ {
+		tag := "tag" + string(rune('a'+i))
+		for n := 0; n < MinTrendUses+i; n++ {
+			trendService.PostCreated(taggedPost(now, tag))
+		}
+	}
+	if err := trendService.Refresh(); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	resp := trendService.GetTrends()
+	if resp.Count != MaxTrends || resp.Trends[0].Tag != "tago" || resp.Trends[0].HourCount != MinTrendUses+MaxTrends+4 {
+		t.Errorf("Expected the %d most used tags, busiest first, got %+v", MaxTrends, resp.Trends)
+	}
+}
+
+func TestTrendService_Load(t *testing.T) {
+	userRepo := repository.NewInMemoryUserRepository()
+	postRepo := repository.NewInMemoryPostRepository()
+	followRepo := repository.NewInMemoryFollowRepository()
+	userRepo.Create(&models.User{Name: "User1", Email: "user1@test.com"})
+
+	// Posts from before a restart: three recent, and older ones outside the baseline window
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		postRepo.Create(&models.Post{UserID: 1, Content: "#Launch day", CreatedAt: now.Add(-time.Duration(i) * time.Minute)})
+	}
+	for i := 0; i < trendLoadBatch+1; i++ {
+		postRepo.Create(&models.Post{UserID: 1, Content: "#old news", CreatedAt: now.Add(-TrendBaselineWindow - time.Hour)})
+	}
+
+	trendService := newTestTrendService(postRepo)
+	if err := trendService.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if got := trendTags(trendService.GetTrends()); len(got) != 1 || got[0] != "launch" {
+		t.Fatalf("Expected trends [launch] after Load, got %v", got)
+	}
+
+	// New posts are counted as they are created
+	timelineService := NewTimelineService(repository.NewInMemoryTimelineStore(), postRepo, userRepo, followRepo)
+	postService := NewPostService(postRepo, userRepo, followRepo, repository.NewInMemoryLikeRepository(), timelineService, newTestSearchService(postRepo, userRepo), trendService, newTestNotificationService(postRepo, userRepo))
+	for i := 0; i < MinTrendUses; i++ {
+		if _, err := postService.CreatePost(1, models.CreatePostRequest{Content: "#새기능 출시"}); err != nil {
+			t.Fatalf("CreatePost failed: %v", err)
+		}
+	}
+	if err := trendService.Refresh(); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	if got := trendTags(trendService.GetTrends()); len(got) != 2 {
+		t.Errorf("Expected launch and 새기능 to trend, got %v", got)
+	}
+}
+
+func TestTrendService_PostChanges(t *testing.T) {
+	userRepo := repository.NewInMemoryUserRepository()
+	postRepo := repository.NewInMemoryPostRepository()
+	followRepo := repository.NewInMemoryFollowRepository()
+	userRepo.Create(&models.User{Name: "User1", Email: "user1@test.com"})
+
+	trendService := newTestTrendService(postRepo)
+	timelineService := NewTimelineService(repository.NewInMemoryTimelineStore(), postRepo, userRepo, followRepo)
+	postService := NewPostService(postRepo, userRepo, followRepo, repository.NewInMemoryLikeRepository(), timelineService, newTestSearchService(postRepo, userRepo), trendService, newTestNotificationService(postRepo, userRepo))
+
+	counts := func() map[string]int {
+		t.Helper()
+		now := time.Now()
+		counts, err := trendService.store.Counts(now.Add(-TrendWindow), now)
+		if err != nil {
+			t.Fatalf("Counts failed: %v", err)
+		}
+		return counts
+	}
+
+	post, err := postService.CreatePost(1, models.CreatePostRequest{Content: "#go #rust"})
+	if err != nil {
+		t.Fatalf("CreatePost failed: %v", err)
+	}
+
+	// An edit counts the hashtags it adds and takes back the ones it drops
+	if _, err := postService.UpdatePost(post.PostID, 1, models.UpdatePostRequest{Content: "#go #zig"}); err != nil {
+		t.Fatalf("UpdatePost failed: %v", err)
+	}
+	if got := counts(); len(got) != 2 || got["go"] != 1 || got["zig"] != 1 {
+		t.Errorf("Expected go and zig counted once after the edit, got %v", got)
+	}
+
+	// Deleted posts stop counting until they are restored
+	if _, err := postService.DeletePost(post.PostID, 1); err != nil {
+		t.Fatalf("DeletePost failed: %v", err)
+	}
+	if got := counts(); len(got) != 0 {
+		t.Errorf("Expected no counts after the delete, got %v", got)
+	}
+	if _, err := postService.RestorePost(post.PostID, 1); err != nil {
+		t.Fatalf("RestorePost failed: %v", err)
+	}
+	if got := counts(); len(got) != 2 || got["go"] != 1 || got["zig"] != 1 {
+		t.Errorf("Expected go and zig counted again after the restore, got %v", got)
+	}
+}
+
+func TestTrendService_Run(t *testing.T) {
+	trendService := newTestTrendService(repository.NewInMemoryPostRepository())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		trendService.Run(ctx)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after its context was cancelled")
+	}
+}
@@ -1,7 +1,9 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
 
 	"golang.org/x/crypto/bcrypt"
 	"python-backend-with-go/models"
@@ -10,26 +12,30 @@ import (
 
 // UserService handles user business logic
 type UserService struct {
-	userRepo repository.UserRepository
+	userRepo     repository.UserRepository
+	verification *EmailVerificationService
+	search       *SearchService
 }
 
 // NewUserService creates a new user service
-func NewUserService(userRepo repository.UserRepository) *UserService {
+func NewUserService(userRepo repository.UserRepository, verification *EmailVerificationService, search *SearchService) *UserService {
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:     userRepo,
+		verification: verification,
+		search:       search,
 	}
 }
 
 // Signup registers a new user
 func (s *UserService) Signup(req models.SignupRequest) (models.SignupResponse, error) {
-	// Validate required fields
-	if req.Name == "" || req.Email == "" || req.Password == "" {
-		return models.SignupResponse{}, fmt.Errorf("name, email, and password are required")
+	// Validate fields
+	if err := validateRequest(req); err != nil {
+		return models.SignupResponse{}, err
 	}
 
 	// Check if email already exists
 	if s.userRepo.EmailExists(req.Email) {
-		return models.SignupResponse{}, fmt.Errorf("email already exists")
+		return models.SignupResponse{}, NewError(ErrConflict, "email already exists")
 	}
 
 	// Hash password using bcrypt
@@ -48,8 +54,17 @@ func (s *UserService) Signup(req models.SignupRequest) (models.SignupResponse, e
 
 	// Store user
 	if err := s.userRepo.Create(&newUser); err != nil {
+		if errors.Is(err, repository.ErrEmailTaken) {
+			return models.SignupResponse{}, NewError(ErrConflict, "email already exists")
+		}
 		return models.SignupResponse{}, fmt.Errorf("failed to create user: %w", err)
 	}
+	s.search.UserChanged(newUser)
+
+	// The account exists either way; the user can ask for the email again
+	if err := s.verification.SendVerification(newUser); err != nil {
+		slog.Error("Failed to send verification email", "user_id", newUser.ID, "error", err)
+	}
 
 	return models.SignupResponse{
 		Message: "회원가입이 완료되었습니다.",
@@ -62,20 +77,36 @@ func (s *UserService) GetUserByID(id int) (models.User, error) {
 	return s.userRepo.GetByID(id)
 }
 
-// GetUsersByIDs retrieves multiple users by their IDs
-func (s *UserService) GetUsersByIDs(ids []int) ([]models.UserInfo, error) {
-	users := make([]models.UserInfo, 0, len(ids))
+// GetUsersByIDs retrieves what anyone may see of multiple users in a single lookup
+func (s *UserService) GetUsersByIDs(ids []int) ([]models.PublicUser, error) {
+	return loadPublicUsers(s.userRepo, ids)
+}
+
+// loadPublicUsers loads users in one query and returns what anyone may see of
+// them, in the order of ids, skipping users that no longer exist. Every list of
+// users served to other users and anonymous callers uses it.
+func loadPublicUsers(userRepo repository.UserRepository, ids []int) ([]models.PublicUser, error) {
+	usersByID, err := userRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	users := make([]models.PublicUser, 0, len(ids))
 	for _, id := range ids {
-		user, err := s.userRepo.GetByID(id)
-		if err != nil {
+		user, exists := usersByID[id]
+		if !exists {
 			continue // Skip non-existent users
 		}
-		users = append(users, models.UserInfo{
-			ID:      user.ID,
-			Name:    user.Name,
-			Email:   user.Email,
-			Profile: user.Profile,
-		})
+		users = append(users, publicUser(user))
 	}
 	return users, nil
 }
+
+// publicUser returns what anyone may see of a user
+func publicUser(user models.User) models.PublicUser {
+	return models.PublicUser{
+		ID:      user.ID,
+		Name:    user.Name,
+		Profile: user.Profile,
+	}
+}
@@ -1,12 +1,19 @@
 package services
 
 import (
+	"strings"
 	"testing"
 
 	"python-backend-with-go/models"
 	"python-backend-with-go/repository"
 )
 
+// newTestUserService creates a user service whose verification emails are kept in memory
+func newTestUserService(userRepo repository.UserRepository) *UserService {
+	verification := NewEmailVerificationService(userRepo, &recordingMailer{}, repository.NewInMemoryRateLimitStore())
+	return NewUserService(userRepo, verification, newTestSearchService(repository.NewInMemoryPostRepository(), userRepo))
+}
+
 func TestUserService_Signup(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -31,7 +38,7 @@ func TestUserService_Signup(t *testing.T) {
 				Password: "password123",
 			},
 			expectError: true,
-			errorMsg:    "name, email, and password are required",
+			errorMsg:    "name is required",
 		},
 		{
 			name: "missing email",
@@ -40,7 +47,7 @@ func TestUserService_Signup(t *testing.T) {
 				Password: "password123",
 			},
 			expectError: true,
-			errorMsg:    "name, email, and password are required",
+			errorMsg:    "email is required",
 		},
 		{
 			name: "missing password",
@@ -49,7 +56,38 @@ func TestUserService_Signup(t *testing.T) {
 				Email: "hong@example.com",
 			},
 			expectError: true,
-			errorMsg:    "name, email, and password are required",
+			errorMsg:    "password is required",
+		},
+		{
+			name: "invalid email",
+			request: models.SignupRequest{
+				Name:     "홍길동",
+				Email:    "hong-at-example.com",
+				Password: "password123",
+			},
+			expectError: true,
+			errorMsg:    "email must be a valid email address",
+		},
+		{
+			name: "short password and blank name",
+			request: models.SignupRequest{
+				Name:     "   ",
+				Email:    "hong@example.com",
+				Password: "short",
+			},
+			expectError: true,
+			errorMsg:    "name must not be blank; password must be at least 8 characters",
+		},
+		{
+			name: "profile too long",
+			request: models.SignupRequest{
+				Name:     "홍길동",
+				Email:    "hong@example.com",
+				Password: "password123",
+				Profile:  strings.Repeat("a", 2001),
+			},
+			expectError: true,
+			errorMsg:    "profile must be at most 2000 characters",
 		},
 	}
 
@@ -57,7 +95,7 @@ func TestUserService_Signup(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Setup
 			userRepo := repository.NewInMemoryUserRepository()
-			userService := NewUserService(userRepo)
+			userService := newTestUserService(userRepo)
 
 			// Execute
 			resp, err := userService.Signup(tt.request)
@@ -83,7 +121,7 @@ func TestUserService_Signup(t *testing.T) {
 
 func TestUserService_Signup_DuplicateEmail(t *testing.T) {
 	userRepo := repository.NewInMemoryUserRepository()
-	userService := NewUserService(userRepo)
+	userService := newTestUserService(userRepo)
 
 	// Create first user
 	req1 := models.SignupRequest{
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+const (
+	// EmailVerificationTTL is how long an email verification token stays valid
+	EmailVerificationTTL = 24 * time.Hour
+	// EmailVerificationAudience marks verification tokens so they cannot be
+	// mistaken for any other token signed with JWT_SECRET
+	EmailVerificationAudience = "email-verification"
+)
+
+// VerificationResendLimit caps how many verification emails one account receives
+var VerificationResendLimit = repository.RateLimit{Requests: 3, Per: time.Hour}
+
+// EmailVerificationPolicy decides what unverified accounts may do.
+// It is configured with the EMAIL_VERIFICATION environment variable.
+type EmailVerificationPolicy string
+
+const (
+	// VerificationOptional lets unverified accounts log in and post (default)
+	VerificationOptional EmailVerificationPolicy = "optional"
+	// VerificationRequiredToPost lets unverified accounts log in but not create posts
+	VerificationRequiredToPost EmailVerificationPolicy = "post"
+	// VerificationRequiredToLogin blocks unverified accounts from logging in
+	VerificationRequiredToLogin EmailVerificationPolicy = "login"
+)
+
+// EmailVerificationPolicyFromEnv reads the policy from EMAIL_VERIFICATION
+func EmailVerificationPolicyFromEnv() (EmailVerificationPolicy, error) {
+	value := strings.ToLower(strings.TrimSpace(os.Getenv("EMAIL_VERIFICATION")))
+	switch policy := EmailVerificationPolicy(value); policy {
+	case "":
+		return VerificationOptional, nil
+	case VerificationOptional, VerificationRequiredToPost, VerificationRequiredToLogin:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid EMAIL_VERIFICATION %q (use optional, post or login)", value)
+	}
+}
+
+// currentVerificationPolicy returns the configured policy. A misconfigured
+// value is rejected at startup; should one appear later, fail closed.
+func currentVerificationPolicy() EmailVerificationPolicy {
+	policy, err := EmailVerificationPolicyFromEnv()
+	if err != nil {
+		return VerificationRequiredToLogin
+	}
+	return policy
+}
+
+// requireVerifiedEmail returns ErrForbidden for an unverified user when the
+// policy blocks the action
+func requireVerifiedEmail(user models.User, blocked bool) error {
+	if blocked && user.EmailVerifiedAt == nil {
+		return NewError(ErrForbidden, "email address is not verified")
+	}
+	return nil
+}
+
+// blocksLogin reports whether unverified accounts may not log in
+func (p EmailVerificationPolicy) blocksLogin() bool {
+	return p == VerificationRequiredToLogin
+}
+
+// blocksPosting reports whether unverified accounts may not create posts
+func (p EmailVerificationPolicy) blocksPosting() bool {
+	return p == VerificationRequiredToPost || p == VerificationRequiredToLogin
+}
+
+// EmailVerificationService handles email address verification
+type EmailVerificationService struct {
+	userRepo   repository.UserRepository
+	mailer     Mailer
+	rateLimits repository.RateLimitStore
+	now        func() time.Time
+}
+
+// NewEmailVerificationService creates a new email verification service
+func NewEmailVerificationService(userRepo repository.UserRepository, mailer Mailer, rateLimits repository.RateLimitStore) *EmailVerificationService {
+	return &EmailVerificationService{
+		userRepo:   userRepo,
+		mailer:     mailer,
+		rateLimits: rateLimits,
+		now:        time.Now,
+	}
+}
+
+// Verify marks the address in a verification token as verified. Verifying an
+// already verified address succeeds again.
+func (s *EmailVerificationService) Verify(req models.VerifyEmailRequest) (models.SuccessResponse, error) {
+	if err := validateRequest(req); err != nil {
+		return models.SuccessResponse{}, err
+	}
+
+	invalidToken := NewError(ErrValidation, "invalid or expired verification token")
+
+	claims, err := s.parseToken(req.Token)
+	if err != nil {
+		return models.SuccessResponse{}, invalidToken
+	}
+
+	user, err := s.userRepo.GetByID(claims.UserID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return models.SuccessResponse{}, invalidToken
+		}
+		return models.SuccessResponse{}, err
+	}
+
+	// A token issued for a previous address does not verify the current one
+	if !strings.EqualFold(user.Email, claims.Email) {
+		return models.SuccessResponse{}, invalidToken
+	}
+
+	if err := s.userRepo.MarkEmailVerified(user.ID, s.now()); err != nil {
+		return models.SuccessResponse{}, fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	return models.SuccessResponse{
+		Message: "이메일 인증이 완료되었습니다.",
+		Status:  "success",
+	}, nil
+}
+
+// ResendVerification emails a new verification token. The response is the same
+// whether the address is unknown, already verified or throttled, so it cannot be
+// used to discover accounts.
+func (s *EmailVerificationService) ResendVerification(req models.ResendVerificationRequest) (models.SuccessResponse, error) {
+	if err := validateRequest(req); err != nil {
+		return models.SuccessResponse{}, err
+	}
+
+	resp := models.SuccessResponse{
+		Message: "인증이 필요한 계정이라면 인증 메일을 다시 보냈습니다.",
+		Status:  "success",
+	}
+
+	user, err := s.userRepo.GetByEmail(req.Email)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return resp, nil
+		}
+		return models.SuccessResponse{}, err
+	}
+	if user.EmailVerifiedAt != nil {
+		return resp, nil
+	}
+
+	// Throttle per account so one mailbox cannot be flooded from many IPs
+	result, err := s.rateLimits.Take("verify-email:user:"+strconv.Itoa(user.ID), VerificationResendLimit, s.now())
+	if err != nil {
+		slog.Warn("Verification resend limit unavailable", "user_id", user.ID, "error", err)
+	} else if !result.Allowed {
+		return resp, nil
+	}
+
+	if err := s.SendVerification(user); err != nil {
+		slog.Error("Failed to send verification email", "user_id", user.ID, "error", err)
+	}
+
+	return resp, nil
+}
+
+// SendVerification emails a verification token for the user's current address
+func (s *EmailVerificationService) SendVerification(user models.User) error {
+	token, err := s.generateToken(user)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(Message{
+		To:      user.Email,
+		Subject: "이메일 주소 인증 안내",
+		Body: fmt.Sprintf("이메일 주소를 인증하려면 아래 토큰을 사용하세요. 토큰은 %d시간 동안 유효합니다.\n\n%s\n\n"+
+			"가입하지 않았다면 이 메일을 무시하세요.", int(EmailVerificationTTL.Hours()), token),
+	})
+}
+
+// generateToken creates a signed verification token for the user's current address
+func (s *EmailVerificationService) generateToken(user models.User) (string, error) {
+	// Get JWT secret from environment variable
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		return "", fmt.Errorf("JWT_SECRET environment variable is not set")
+	}
+
+	now := s.now()
+	claims := models.EmailVerificationClaims{
+		UserID: user.ID,
+		Email:  user.Email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{EmailVerificationAudience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(EmailVerificationTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign verification token: %w", err)
+	}
+	return tokenString, nil
+}
+
+// parseToken checks a verification token's signature, audience and expiry
+func (s *EmailVerificationService) parseToken(tokenString string) (*models.EmailVerificationClaims, error) {
+	// Get JWT secret from environment variable
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		return nil, fmt.Errorf("JWT_SECRET environment variable is not set")
+	}
+
+	token, err := jwt.ParseWithClaims(tokenString, &models.EmailVerificationClaims{}, func(token *jwt.Token) (interface{}, error) {
+		// Validate signing method
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	}, jwt.WithAudience(EmailVerificationAudience), jwt.WithExpirationRequired(), jwt.WithTimeFunc(s.now))
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	claims, ok := token.Claims.(*models.EmailVerificationClaims)
+	if !ok || !token.Valid || claims.UserID == 0 {
+		return nil, fmt.Errorf("invalid token claims")
+	}
+	return claims, nil
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"python-backend-with-go/models"
+	"python-backend-with-go/repository"
+)
+
+func setupVerificationTest(t *testing.T) (*EmailVerificationService, *UserService, *recordingMailer, *fakeClock, repository.UserRepository) {
+	t.Helper()
+	os.Setenv("JWT_SECRET", "test_secret_key_for_testing")
+	t.Cleanup(func() { os.Unsetenv("JWT_SECRET") })
+
+	userRepo := repository.NewInMemoryUserRepository()
+	mailer := &recordingMailer{}
+	verificationService := NewEmailVerificationService(userRepo, mailer, repository.NewInMemoryRateLimitStore())
+	clock := &fakeClock{now: time.Now()}
+	verificationService.now = clock.Now
+
+	return verificationService, NewUserService(userRepo, verificationService, newTestSearchService(repository.NewInMemoryPostRepository(), userRepo)), mailer, clock, userRepo
+}
+
+// signup registers a user and returns it as stored
+func signup(t *testing.T, userService *UserService, userRepo repository.UserRepository, email string) models.User {
+	t.Helper()
+	resp, err := userService.Signup(models.SignupRequest{Name: "홍길동", Email: email, Password: "password123"})
+	if err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	user, err := userRepo.GetByID(resp.UserID)
+	if err != nil {
+		t.Fatalf("Failed to get test user: %v", err)
+	}
+	return user
+}
+
+func TestEmailVerificationService_Verify(t *testing.T) {
+	verificationService, userService, mailer, _, userRepo := setupVerificationTest(t)
+
+	user := signup(t, userService, userRepo, "hong@test.com")
+	if user.EmailVerifiedAt != nil {
+		t.Fatal("Expected a new account to be unverified")
+	}
+	if len(mailer.sent) != 1 || mailer.sent[0].To != user.Email {
+		t.Fatalf("Expected a verification email to %s, got %+v", user.Email, mailer.sent)
+	}
+	token := mailer.lastToken(t)
+
+	if _, err := verificationService.Verify(models.VerifyEmailRequest{Token: token}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	verified, _ := userRepo.GetByID(user.ID)
+	if verified.EmailVerifiedAt == nil {
+		t.Error("Expected the account to be verified")
+	}
+
+	// Following the link twice is harmless
+	if _, err := verificationService.Verify(models.VerifyEmailRequest{Token: token}); err != nil {
+		t.Errorf("Expected verifying again to succeed, got %v", err)
+	}
+}
+
+func TestEmailVerificationService_Verify_InvalidToken(t *testing.T) {
+	verificationService, userService, mailer, clock, userRepo := setupVerificationTest(t)
+
+	user := signup(t, userService, userRepo, "hong@test.com")
+	token := mailer.lastToken(t)
+
+	// A token for an address the account no longer has
+	staleToken, err := verificationService.generateToken(models.User{ID: user.ID, Email: "old@test.com"})
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	// An access token is signed with the same secret but is not a verification token
+	authService := NewAuthService(userRepo, repository.NewInMemorySessionRepository(), repository.NewInMemoryLoginAttemptStore())
+	loginResp, err := authService.Login(models.LoginRequest{Email: user.Email, Password: "password123"})
+	if err != nil {
+		t.Fatalf("Failed to login: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		advance time.Duration
+	}{
+		{name: "malformed token", token: "not-a-token"},
+		{name: "stale address", token: staleToken},
+		{name: "access token", token: loginResp.AccessToken},
+		{name: "expired token", token: token, advance: EmailVerificationTTL + time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock.Advance(tt.advance)
+
+			_, err := verificationService.Verify(models.VerifyEmailRequest{Token: tt.token})
+			if !errors.Is(err, ErrValidation) {
+				t.Errorf("Expected ErrValidation, got %v", err)
+			}
+		})
+	}
+
+	if stored, _ := userRepo.GetByID(user.ID); stored.EmailVerifiedAt != nil {
+		t.Error("Expected the account to stay unverified")
+	}
+}
+
+func TestEmailVerificationService_ResendVerification(t *testing.T) {
+	verificationService, userService, mailer, clock, userRepo := setupVerificationTest(t)
+
+	unverified := signup(t, userService, userRepo, "hong@test.com")
+	verified := signup(t, userService, userRepo, "kim@test.com")
+	if err := userRepo.MarkEmailVerified(verified.ID, clock.Now()); err != nil {
+		t.Fatalf("Failed to verify test user: %v", err)
+	}
+	mailer.sent = nil
+
+	expected, err := verificationService.ResendVerification(models.ResendVerificationRequest{Email: unverified.Email})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(mailer.sent) != 1 || mailer.sent[0].To != unverified.Email {
+		t.Fatalf("Expected a verification email to %s, got %+v", unverified.Email, mailer.sent)
+	}
+
+	// Unknown and verified addresses get the same response and no email
+	for _, email := range []string{"nobody@test.com", verified.Email} {
+		resp, err := verificationService.ResendVerification(models.ResendVerificationRequest{Email: email})
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", email, err)
+		}
+		if resp != expected {
+			t.Errorf("Expected identical responses, got %+v and %+v", expected, resp)
+		}
+	}
+	if len(mailer.sent) != 1 {
+		t.Errorf("Expected no further emails, got %+v", mailer.sent)
+	}
+
+	// Each account only receives a limited number of emails
+	for i := 0; i < VerificationResendLimit.Requests; i++ {
+		if _, err := verificationService.ResendVerification(models.ResendVerificationRequest{Email: unverified.Email}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if len(mailer.sent) != VerificationResendLimit.Requests {
+		t.Errorf("Expected %d emails within the limit, got %d", VerificationResendLimit.Requests, len(mailer.sent))
+	}
+
+	clock.Advance(VerificationResendLimit.Per)
+	if _, err := verificationService.ResendVerification(models.ResendVerificationRequest{Email: unverified.Email}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(mailer.sent) != VerificationResendLimit.Requests+1 {
+		t.Errorf("Expected an email once the limit has passed, got %d", len(mailer.sent))
+	}
+}
+
+func TestEmailVerificationPolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       string
+		loginBlocked bool
+		postBlocked  bool
+	}{
+		{name: "default", policy: ""},
+		{name: "optional", policy: "optional"},
+		{name: "required to post", policy: "post", postBlocked: true},
+		{name: "required to login", policy: "login", loginBlocked: true, postBlocked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, userService, _, _, userRepo := setupVerificationTest(t)
+			os.Setenv("EMAIL_VERIFICATION", tt.policy)
+			t.Cleanup(func() { os.Unsetenv("EMAIL_VERIFICATION") })
+
+			authService := NewAuthService(userRepo, repository.NewInMemorySessionRepository(), repository.NewInMemoryLoginAttemptStore())
+			followRepo := repository.NewInMemoryFollowRepository()
+			postRepo := repository.NewInMemoryPostRepository()
+			timelineService := NewTimelineService(repository.NewInMemoryTimelineStore(), postRepo, userRepo, followRepo)
+			postService := NewPostService(postRepo, userRepo, followRepo, repository.NewInMemoryLikeRepository(), timelineService, newTestSearchService(postRepo, userRepo), newTestTrendService(postRepo), newTestNotificationService(postRepo, userRepo))
+
+			user := signup(t, userService, userRepo, "hong@test.com")
+
+			_, err := authService.Login(models.LoginRequest{Email: user.Email, Password: "password123"})
+			if tt.loginBlocked != errors.Is(err, ErrForbidden) {
+				t.Errorf("Login() error = %v, want blocked = %v", err, tt.loginBlocked)
+			}
+			_, err = postService.CreatePost(user.ID, models.CreatePostRequest{Content: "hello"})
+			if tt.postBlocked != errors.Is(err, ErrForbidden) {
+				t.Errorf("CreatePost() error = %v, want blocked = %v", err, tt.postBlocked)
+			}
+
+			// Verified accounts are never blocked
+			if err := userRepo.MarkEmailVerified(user.ID, time.Now()); err != nil {
+				t.Fatalf("Failed to verify test user: %v", err)
+			}
+			if _, err := authService.Login(models.LoginRequest{Email: user.Email, Password: "password123"}); err != nil {
+				t.Errorf("Login() after verification error = %v", err)
+			}
+			if _, err := postService.CreatePost(user.ID, models.CreatePostRequest{Content: "hello"}); err != nil {
+				t.Errorf("CreatePost() after verification error = %v", err)
+			}
+		})
+	}
+}
+
+func TestEmailVerificationPolicyFromEnv_Invalid(t *testing.T) {
+	os.Setenv("EMAIL_VERIFICATION", "sometimes")
+	defer os.Unsetenv("EMAIL_VERIFICATION")
+
+	if _, err := EmailVerificationPolicyFromEnv(); err == nil {
+		t.Error("Expected an error for an unknown policy")
+	}
+}
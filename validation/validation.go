@@ -0,0 +1,256 @@
+// Package validation checks request structs against declarative `validate` tags.
+//
+// Rules are comma-separated and checked in order; the first failing rule is
+// reported for the field. Fields are reported by their JSON name.
+//
+//	required    string non-empty, number non-zero, pointer non-nil
+//	notblank    string contains something other than whitespace
+//	min=N       string has at least N characters (runes); number is at least N
+//	max=N       string has at most N characters (runes); number is at most N
+//	maxbytes=N  string is at most N bytes long
+//	email       string is a bare email address (e.g. "hong@example.com")
+//
+// Rules other than required are skipped for nil pointers and for empty strings
+// that are not behind a pointer, so optional fields are only checked when present.
+package validation
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Errors maps the JSON name of each invalid field to the reason it is invalid
+type Errors map[string]string
+
+// Error lists every invalid field, ordered by field name
+func (e Errors) Error() string {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = field + " " + e[field]
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Struct validates v, a struct or pointer to struct, and returns the invalid
+// fields (nil if there are none). It panics on a malformed tag.
+func Struct(v any) Errors {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validation: %T is not a struct", v))
+	}
+
+	var errs Errors
+	for _, field := range fieldsOf(value.Type()) {
+		if reason := field.check(value.Field(field.index)); reason != "" {
+			if errs == nil {
+				errs = make(Errors)
+			}
+			errs[field.name] = reason
+		}
+	}
+	return errs
+}
+
+// rule is a single parsed validation rule
+type rule struct {
+	name string
+	arg  int
+}
+
+// field is a struct field with validation rules
+type field struct {
+	index int
+	name  string
+	rules []rule
+}
+
+// fieldCache holds the parsed fields of every struct type seen so far
+var fieldCache sync.Map // map[reflect.Type][]field
+
+// fieldsOf returns the validated fields of a struct type
+func fieldsOf(t reflect.Type) []field {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]field)
+	}
+
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		tag := structField.Tag.Get("validate")
+		if tag == "" || !structField.IsExported() {
+			continue
+		}
+		fields = append(fields, field{
+			index: i,
+			name:  jsonName(structField),
+			rules: parseRules(t, structField.Name, tag),
+		})
+	}
+
+	fieldCache.Store(t, fields)
+	return fields
+}
+
+// jsonName returns the name a field has in JSON
+func jsonName(structField reflect.StructField) string {
+	name, _, _ := strings.Cut(structField.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return structField.Name
+	}
+	return name
+}
+
+// parseRules parses a validate tag
+func parseRules(t reflect.Type, fieldName, tag string) []rule {
+	var rules []rule
+	for _, part := range strings.Split(tag, ",") {
+		name, arg, hasArg := strings.Cut(strings.TrimSpace(part), "=")
+		r := rule{name: name}
+
+		switch name {
+		case "required", "notblank", "email":
+			if hasArg {
+				panic(fmt.Sprintf("validation: %s.%s: rule %q takes no argument", t, fieldName, name))
+			}
+		case "min", "max", "maxbytes":
+			n, err := strconv.Atoi(arg)
+			if !hasArg || err != nil {
+				panic(fmt.Sprintf("validation: %s.%s: rule %q needs a number", t, fieldName, name))
+			}
+			r.arg = n
+		default:
+			panic(fmt.Sprintf("validation: %s.%s: unknown rule %q", t, fieldName, name))
+		}
+
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// check applies the field's rules to its value and returns the first failure
+func (f field) check(value reflect.Value) string {
+	present := true
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			if f.has("required") {
+				return "is required"
+			}
+			return ""
+		}
+		value = value.Elem()
+	} else if value.Kind() == reflect.String {
+		present = value.Len() > 0
+	}
+
+	for _, r := range f.rules {
+		if r.name != "required" && !present {
+			continue
+		}
+		if reason := r.check(value); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+// has reports whether the field has the named rule
+func (f field) has(name string) bool {
+	for _, r := range f.rules {
+		if r.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// check applies the rule to a (non-pointer) value
+func (r rule) check(value reflect.Value) string {
+	if value.Kind() == reflect.String {
+		return r.checkString(value.String())
+	}
+
+	var n int64
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = value.Int()
+	default:
+		panic(fmt.Sprintf("validation: rule %q does not apply to %s", r.name, value.Type()))
+	}
+
+	switch r.name {
+	case "required":
+		if n == 0 {
+			return "is required"
+		}
+	case "min":
+		if n < int64(r.arg) {
+			return fmt.Sprintf("must be at least %d", r.arg)
+		}
+	case "max":
+		if n > int64(r.arg) {
+			return fmt.Sprintf("must be at most %d", r.arg)
+		}
+	default:
+		panic(fmt.Sprintf("validation: rule %q does not apply to numbers", r.name))
+	}
+	return ""
+}
+
+// checkString applies the rule to a string
+func (r rule) checkString(s string) string {
+	switch r.name {
+	case "required":
+		if s == "" {
+			return "is required"
+		}
+	case "notblank":
+		if strings.TrimSpace(s) == "" {
+			return "must not be blank"
+		}
+	case "min":
+		if utf8.RuneCountInString(s) < r.arg {
+			return fmt.Sprintf("must be at least %d characters", r.arg)
+		}
+	case "max":
+		if utf8.RuneCountInString(s) > r.arg {
+			return fmt.Sprintf("must be at most %d characters", r.arg)
+		}
+	case "maxbytes":
+		if len(s) > r.arg {
+			return fmt.Sprintf("must be at most %d bytes", r.arg)
+		}
+	case "email":
+		if !isEmail(s) {
+			return "must be a valid email address"
+		}
+	}
+	return ""
+}
+
+// isEmail reports whether s is a bare address with a dotted domain
+func isEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Address != s {
+		return false
+	}
+	_, domain, _ := strings.Cut(addr.Address, "@")
+	return strings.Contains(domain, ".") && !strings.HasSuffix(domain, ".")
+}
@@ -0,0 +1,138 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+type testRequest struct {
+	Name     string  `json:"name" validate:"required,notblank,max=5"`
+	Email    string  `json:"email,omitempty" validate:"email"`
+	Password string  `json:"password" validate:"min=3,maxbytes=6"`
+	Bio      *string `json:"bio" validate:"notblank"`
+	Limit    int     `json:"limit" validate:"min=0,max=10"`
+	Count    int     `validate:"required"`
+	Ignored  string  `json:"ignored"`
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestStruct(t *testing.T) {
+	valid := testRequest{Name: "홍길동", Count: 1}
+
+	tests := []struct {
+		name   string
+		modify func(r *testRequest)
+		want   Errors
+	}{
+		{name: "valid", modify: func(r *testRequest) {}},
+		{
+			name:   "optional fields absent",
+			modify: func(r *testRequest) { r.Email, r.Password, r.Bio = "", "", nil },
+		},
+		{
+			name:   "required",
+			modify: func(r *testRequest) { r.Name, r.Count = "", 0 },
+			want:   Errors{"name": "is required", "Count": "is required"},
+		},
+		{
+			name:   "blank",
+			modify: func(r *testRequest) { r.Name = " \t" },
+			want:   Errors{"name": "must not be blank"},
+		},
+		{
+			name:   "blank behind a pointer",
+			modify: func(r *testRequest) { r.Bio = strPtr("") },
+			want:   Errors{"bio": "must not be blank"},
+		},
+		{
+			name:   "max counts characters",
+			modify: func(r *testRequest) { r.Name = "가나다라마바" },
+			want:   Errors{"name": "must be at most 5 characters"},
+		},
+		{
+			name:   "min and maxbytes",
+			modify: func(r *testRequest) { r.Password = "ab" },
+			want:   Errors{"password": "must be at least 3 characters"},
+		},
+		{
+			name:   "maxbytes counts bytes",
+			modify: func(r *testRequest) { r.Password = "가나다" },
+			want:   Errors{"password": "must be at most 6 bytes"},
+		},
+		{
+			name:   "number range",
+			modify: func(r *testRequest) { r.Limit = 11 },
+			want:   Errors{"limit": "must be at most 10"},
+		},
+		{
+			name:   "negative number",
+			modify: func(r *testRequest) { r.Limit = -1 },
+			want:   Errors{"limit": "must be at least 0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.modify(&req)
+
+			got := Struct(&req)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Struct() = %v, want %v", got, tt.want)
+			}
+			for field, reason := range tt.want {
+				if got[field] != reason {
+					t.Errorf("Struct()[%q] = %q, want %q", field, got[field], reason)
+				}
+			}
+		})
+	}
+}
+
+func TestStruct_Email(t *testing.T) {
+	tests := []struct {
+		email string
+		valid bool
+	}{
+		{email: "hong@example.com", valid: true},
+		{email: "hong.gildong+tag@mail.example.co.kr", valid: true},
+		{email: "hong-at-example.com"},
+		{email: "hong@localhost"},
+		{email: "hong@example."},
+		{email: "홍길동 <hong@example.com>"},
+		{email: " hong@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			errs := Struct(testRequest{Name: "a", Count: 1, Email: tt.email})
+			if got := errs["email"] == ""; got != tt.valid {
+				t.Errorf("valid = %v, want %v (errors %v)", got, tt.valid, errs)
+			}
+		})
+	}
+}
+
+func TestErrors_Error(t *testing.T) {
+	errs := Errors{"password": "is required", "email": "must be a valid email address"}
+
+	want := "email must be a valid email address; password is required"
+	if got := errs.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestStruct_MalformedTag(t *testing.T) {
+	type badRequest struct {
+		Name string `validate:"max=many"`
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil || !strings.Contains(r.(string), "needs a number") {
+			t.Errorf("Expected panic about the malformed rule, got %v", r)
+		}
+	}()
+	Struct(badRequest{})
+}